@@ -0,0 +1,7 @@
+# Fails with a diagnostic if the provider can't connect, e.g. in CI before planning
+data "redshift_connection" "check" {
+}
+
+output "redshift_version" {
+  value = data.redshift_connection.check.version
+}
@@ -0,0 +1,16 @@
+# An inbound datashare shared with this cluster by a producer
+data "redshift_datashare" "sales" {
+  name               = "sales_share"
+  share_type         = "inbound"
+  producer_namespace = "8fc8dbce-1a2b-4c5d-9e8f-0123456789ab"
+}
+
+resource "redshift_database" "sales" {
+  name = "sales_from_share"
+
+  datashare_source {
+    share_name = data.redshift_datashare.sales.name
+    account_id = data.redshift_datashare.sales.producer_account
+    namespace  = data.redshift_datashare.sales.producer_namespace
+  }
+}
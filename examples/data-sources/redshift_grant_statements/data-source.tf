@@ -0,0 +1,11 @@
+data "redshift_grant_statements" "analysts" {
+  group       = "analysts"
+  schema      = "reporting"
+  object_type = "table"
+  objects     = ["orders"]
+  privileges  = ["select"]
+}
+
+output "analysts_grant_statements" {
+  value = data.redshift_grant_statements.analysts.statements
+}
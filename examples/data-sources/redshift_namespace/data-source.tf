@@ -1,3 +1,14 @@
 data "redshift_namespace" "namespace" {
   # no attributes required. Cluster namespace will be set as the id.
 }
+
+# Grant usage on a producer datashare to the namespace of the consumer cluster,
+# read through a provider configured for the consumer.
+data "redshift_namespace" "consumer" {
+  provider = redshift.consumer
+}
+
+resource "redshift_datashare_privilege" "consumer" {
+  share_name = "my_datashare"
+  namespace  = data.redshift_namespace.consumer.namespace
+}
@@ -0,0 +1,9 @@
+data "redshift_role" "analysts" {
+  name = "analysts_role"
+}
+
+resource "redshift_role_grant" "analyst" {
+  role_name     = data.redshift_role.analysts.name
+  grant_to_type = "user"
+  grant_to_name = "john_doe"
+}
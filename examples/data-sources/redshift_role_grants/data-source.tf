@@ -0,0 +1,7 @@
+data "redshift_role_grants" "analyst" {
+  role_name = "analyst"
+}
+
+output "analyst_users" {
+  value = [for grant in data.redshift_role_grants.analyst.grants : grant.grantee_name if grant.grantee_type == "user"]
+}
@@ -0,0 +1,29 @@
+data "redshift_schema_grants" "reporting" {
+  name = "reporting"
+}
+
+locals {
+  reporting_grants = {
+    for grant in data.redshift_schema_grants.reporting.grants : grant.import_id => grant
+    if grant.import_id != ""
+  }
+}
+
+# Adopt the existing grants of the schema (requires Terraform 1.7 or later)
+import {
+  for_each = local.reporting_grants
+  to       = redshift_grant.reporting[each.key]
+  id       = each.key
+}
+
+resource "redshift_grant" "reporting" {
+  for_each = local.reporting_grants
+
+  user                 = each.value.grantee_type == "user" ? each.value.grantee : null
+  group                = each.value.grantee_type == "group" ? each.value.grantee : null
+  schema               = "reporting"
+  object_type          = each.value.object_type
+  objects              = each.value.object == "" ? [] : [each.value.object]
+  privileges           = each.value.privileges
+  grantable_privileges = each.value.grantable_privileges
+}
@@ -0,0 +1,13 @@
+data "redshift_schemas" "sales" {
+  pattern = "sales_%"
+}
+
+# Grant the analysts usage on every sales schema
+resource "redshift_grant" "sales_usage" {
+  for_each = toset([for schema in data.redshift_schemas.sales.schemas : schema.name if schema.type == "local"])
+
+  group       = "analysts"
+  schema      = each.key
+  object_type = "schema"
+  privileges  = ["usage"]
+}
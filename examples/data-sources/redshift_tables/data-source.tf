@@ -0,0 +1,13 @@
+data "redshift_tables" "facts" {
+  schema = "sales"
+  filter = "^fact_"
+}
+
+# Grant the analysts read access to every fact table and view
+resource "redshift_grant" "facts" {
+  group       = "analysts"
+  schema      = "sales"
+  object_type = "table"
+  objects     = [for table in data.redshift_tables.facts.tables : table.name if table.type != "external"]
+  privileges  = ["select"]
+}
@@ -0,0 +1,9 @@
+data "redshift_user_statements" "alice" {
+  name             = "alice"
+  password         = var.alice_password
+  connection_limit = 10
+}
+
+output "alice_create_statement" {
+  value = data.redshift_user_statements.alice.statements
+}
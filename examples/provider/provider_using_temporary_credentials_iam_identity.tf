@@ -0,0 +1,7 @@
+provider "redshift" {
+  host = var.redshift_host
+  temporary_credentials {
+    cluster_identifier = "my-cluster"
+    use_iam_identity   = true
+  }
+}
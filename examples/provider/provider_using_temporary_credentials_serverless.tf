@@ -0,0 +1,8 @@
+provider "redshift" {
+  host     = var.redshift_workgroup_endpoint
+  database = "dev"
+  temporary_credentials {
+    workgroup_name = "my-workgroup"
+    region         = "eu-central-1"
+  }
+}
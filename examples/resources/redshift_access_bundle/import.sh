@@ -0,0 +1,3 @@
+# Import an access bundle by the name of its role, the groups the role is granted to are not listed by Redshift
+
+terraform import redshift_access_bundle.analysts analysts_role
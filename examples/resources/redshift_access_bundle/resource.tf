@@ -0,0 +1,8 @@
+resource "redshift_access_bundle" "analysts" {
+  role                     = "analysts_role"
+  schemas                  = ["sales", "marketing"]
+  table_privileges         = ["select"]
+  default_privileges_owner = "etl_user"
+  groups                   = ["analysts"]
+  users                    = ["john_doe"]
+}
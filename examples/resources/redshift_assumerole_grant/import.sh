@@ -0,0 +1,3 @@
+# Import an assumerole grant using the grantee prefixed by `un:` (user), `gn:` (group) or `rn:` (role),
+# followed by `_ar:` and the IAM role ARN
+terraform import redshift_assumerole_grant.etl_copy "un:etl_service_ar:arn:aws:iam::123456789012:role/redshift-copy"
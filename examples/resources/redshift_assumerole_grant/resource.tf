@@ -0,0 +1,11 @@
+resource "redshift_assumerole_grant" "etl_copy" {
+  iam_role_arn = "arn:aws:iam::123456789012:role/redshift-copy"
+  user         = "etl_service"
+  for          = ["copy", "unload"]
+}
+
+resource "redshift_assumerole_grant" "analysts_ml" {
+  iam_role_arn = "arn:aws:iam::123456789012:role/redshift-ml"
+  role         = "analysts"
+  for          = ["create model"]
+}
@@ -3,6 +3,8 @@ resource "redshift_database" "db" {
   name = "my_database"
   owner = "my_user"
   connection_limit = 123456 # use -1 for unlimited
+  collation = "case_insensitive"
+  isolation_level = "snapshot"
 
   lifecycle {
     prevent_destroy = true
@@ -23,3 +25,13 @@ resource "redshift_database" "datashare_db" {
     namespace = "00000000-0000-0000-0000-000000000000" # producer cluster namespace (uuid)
   }
 }
+
+# Example resource declaration of the target database
+# of a zero-ETL integration
+resource "redshift_database" "zero_etl_db" {
+  name = "my_zero_etl_db"
+
+  integration_source {
+    integration_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
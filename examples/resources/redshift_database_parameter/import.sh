@@ -0,0 +1,2 @@
+# Import a database parameter using the database name and the parameter name separated by a dot
+terraform import redshift_database_parameter.search_path mydb.search_path
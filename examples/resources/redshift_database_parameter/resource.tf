@@ -0,0 +1,11 @@
+resource "redshift_database_parameter" "search_path" {
+  database  = "mydb"
+  parameter = "search_path"
+  value     = "$user, public"
+}
+
+resource "redshift_database_parameter" "case_sensitive_identifier" {
+  database  = "mydb"
+  parameter = "enable_case_sensitive_identifier"
+  value     = "true"
+}
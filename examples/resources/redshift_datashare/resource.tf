@@ -8,4 +8,9 @@ resource "redshift_datashare" "my_datashare" {
     "public",
     "other",
   ]
+
+  # Optional. Specifies single tables and views to expose, without the other tables of their schema.
+  tables = [
+    "sales.orders",
+  ]
 }
@@ -15,3 +15,11 @@ resource "redshift_datashare_privilege" "cross_account" {
   share_name = redshift_datashare.share.name # Required
   account    = "123456789012"                # Required
 }
+
+# Example: cross-account datashare permission through the AWS Glue Data Catalog
+# of the consumer account, for access controlled by AWS Lake Formation
+resource "redshift_datashare_privilege" "data_catalog" {
+  share_name       = redshift_datashare.share.name
+  account          = "123456789012"
+  via_data_catalog = true
+}
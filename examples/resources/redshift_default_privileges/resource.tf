@@ -11,3 +11,18 @@ resource "redshift_default_privileges" "user" {
   object_type = "table"
   privileges  = ["select", "update", "insert", "delete", "drop", "references"]
 }
+
+resource "redshift_default_privileges" "role" {
+  role        = "reporting"
+  owner       = "etl"
+  schema      = "sales"
+  object_type = "table"
+  privileges  = ["select"]
+}
+
+resource "redshift_default_privileges" "functions" {
+  role        = "reporting"
+  owner       = "etl"
+  object_type = "function"
+  privileges  = ["execute"]
+}
@@ -0,0 +1,7 @@
+# Import external schema with oid: SELECT esoid FROM svv_external_schemas WHERE schemaname = 'spectrum';
+
+terraform import redshift_external_schema.spectrum 234
+
+# Import an external schema of another database than the one of the provider by prefixing the oid with the database
+
+terraform import redshift_external_schema.spectrum analytics:234
@@ -0,0 +1,26 @@
+resource "redshift_external_schema" "spectrum" {
+  name          = "spectrum"
+  database_name = "spectrum_db"
+
+  data_catalog_source {
+    region        = "us-west-2"
+    iam_role_arns = ["arn:aws:iam::123456789012:role/myRedshiftRole"]
+
+    create_external_database_if_not_exists = true
+  }
+
+  drop_external_database = true
+}
+
+resource "redshift_external_schema" "federated" {
+  name          = "federated"
+  owner         = "etl"
+  database_name = "app"
+
+  rds_postgres_source {
+    hostname      = "app.cluster-abc123.us-west-2.rds.amazonaws.com"
+    schema        = "public"
+    iam_role_arns = ["arn:aws:iam::123456789012:role/myRedshiftRole"]
+    secret_arn    = "arn:aws:secretsmanager:us-west-2:123456789012:secret:app-credentials"
+  }
+}
@@ -0,0 +1,7 @@
+# Import a function by <schema>.<function>(<argument types>)
+
+terraform import redshift_function.f_sql_greater 'public.f_sql_greater(double precision,double precision)'
+
+# Import a function of another database than the one of the provider by <database>:<schema>.<function>(<argument types>)
+
+terraform import redshift_function.f_sql_greater 'warehouse:public.f_sql_greater(double precision,double precision)'
@@ -0,0 +1,38 @@
+resource "redshift_function" "f_sql_greater" {
+  schema     = "public"
+  name       = "f_sql_greater"
+  returns    = "float"
+  volatility = "stable"
+  body       = "SELECT CASE WHEN $1 > $2 THEN $1 ELSE $2 END"
+
+  argument {
+    type = "float"
+  }
+
+  argument {
+    type = "float"
+  }
+}
+
+resource "redshift_function" "f_py_greater" {
+  schema     = "public"
+  name       = "f_py_greater"
+  returns    = "float"
+  language   = "plpythonu"
+  volatility = "stable"
+  body       = <<-EOT
+    if a > b:
+      return a
+    return b
+  EOT
+
+  argument {
+    name = "a"
+    type = "float"
+  }
+
+  argument {
+    name = "b"
+    type = "float"
+  }
+}
@@ -0,0 +1,17 @@
+# Import a grant by <user|group>:<grantee>:<object_type>[:<schema>|:<database>][:<object>,...]
+# The privileges and grant options are read from the catalog. Grants to roles can't be imported.
+
+# All tables of a schema
+terraform import redshift_grant.analysts_tables group:analysts:table:sales
+
+# Some tables of a schema
+terraform import redshift_grant.john_tables user:john:table:sales:orders,customers
+
+# Functions and procedures including their argument types
+terraform import redshift_grant.john_functions 'user:john:function:sales:f_add(int, int)'
+
+# Schemas, databases, languages and datashares
+terraform import redshift_grant.analysts_schema group:analysts:schema:sales
+terraform import redshift_grant.public_database group:public:database
+terraform import redshift_grant.developers_languages group:developers:language:plpythonu
+terraform import redshift_grant.engineers_datashares group:data_engineers:datashare:sales
@@ -12,6 +12,16 @@ resource "redshift_grant" "group" {
   privileges  = ["usage"]
 }
 
+# Grants SELECT on all tables and views of the schema. Tables created afterwards are reported as drift
+# and get the privileges on the next apply, use redshift_default_privileges to cover them right away.
+resource "redshift_grant" "all_tables" {
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "table"
+  objects     = []
+  privileges  = ["select"]
+}
+
 # Granting permissions to execute functions or procedures requires providing their arguments' types
 resource "redshift_grant" "user" {
   user        = "john"
@@ -21,6 +31,22 @@ resource "redshift_grant" "user" {
   privileges  = ["execute"]
 }
 
+# Only developers can create Python UDFs
+resource "redshift_grant" "python_udfs" {
+  group       = "developers"
+  object_type = "language"
+  objects     = ["plpythonu"]
+  privileges  = ["usage"]
+}
+
+# The data engineers can add consumers to the datashare, the consumers are managed with redshift_datashare_privilege
+resource "redshift_grant" "datashare" {
+  group       = "data_engineers"
+  object_type = "datashare"
+  objects     = [redshift_datashare.sales.name]
+  privileges  = ["share"]
+}
+
 # Granting permission to PUBLIC (GRANT ... TO PUBLIC)
 resource "redshift_grant" "public" {
   group       = "public" // "public" or "PUBLIC" (it is case insensitive for this case) here indicates we want grant TO PUBLIC, not "public" group which cannot even be created in Redshift (keyword).
@@ -28,3 +54,23 @@ resource "redshift_grant" "public" {
   object_type = "schema"
   privileges  = ["usage"]
 }
+
+# Only SELECT can be granted further by the user, INSERT can't
+resource "redshift_grant" "delegate" {
+  user                 = "john"
+  schema               = "my_schema"
+  object_type          = "table"
+  objects              = ["my_table"]
+  privileges           = ["select", "insert"]
+  grantable_privileges = ["select"]
+}
+
+# Grants SELECT and UPDATE on some columns only, e.g. to hide personal data
+resource "redshift_grant" "columns" {
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "table"
+  objects     = ["customers"]
+  columns     = ["id", "country"]
+  privileges  = ["select", "update"]
+}
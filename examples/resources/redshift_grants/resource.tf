@@ -0,0 +1,31 @@
+resource "redshift_grants" "analytics" {
+  grant {
+    group       = "analysts"
+    schema      = "analytics"
+    object_type = "schema"
+    privileges  = ["usage"]
+  }
+
+  grant {
+    user        = "john_doe"
+    schema      = "analytics"
+    object_type = "schema"
+    privileges  = ["usage"]
+  }
+
+  # The grants below are applied as
+  # GRANT select ON ALL TABLES IN SCHEMA "analytics" TO GROUP "analysts", "john_doe"
+  grant {
+    group       = "analysts"
+    schema      = "analytics"
+    object_type = "table"
+    privileges  = ["select"]
+  }
+
+  grant {
+    user        = "john_doe"
+    schema      = "analytics"
+    object_type = "table"
+    privileges  = ["select"]
+  }
+}
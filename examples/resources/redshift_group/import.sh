@@ -1,3 +1,7 @@
 # Import group with grosysid: SELECT grosysid FROM pg_group WHERE groname = 'mygroup'
 
 terraform import redshift_group.mygroup 234
+
+# Import group by name
+
+terraform import redshift_group.mygroup mygroup
@@ -0,0 +1,3 @@
+# Import all members of a group by the group name
+
+terraform import redshift_group_membership.mygroup mygroup
@@ -0,0 +1,3 @@
+# Import role by its name
+
+terraform import redshift_role.myrole myrole
@@ -0,0 +1,3 @@
+# Import all users and roles the role is granted to by the role name, grants to groups are not listed by Redshift
+
+terraform import redshift_role_membership.analyst analyst
@@ -0,0 +1,13 @@
+resource "redshift_role_membership" "analyst" {
+  role_name = "analyst"
+  users     = ["alice", "bob"]
+  groups    = ["reporting"]
+  roles     = ["senior_analyst"]
+}
+
+# Revokes the role from all principals but the listed ones
+resource "redshift_role_membership" "admin" {
+  role_name = "admin"
+  users     = ["carol"]
+  exclusive = true
+}
@@ -1,3 +1,7 @@
 # Import schema with oid: SELECT oid FROM pg_catalog.pg_namespace WHERE nspname = 'myschema';
 
 terraform import redshift_schema.myschema 234
+
+# Import a schema of another database than the one of the provider by prefixing the oid with the database
+
+terraform import redshift_schema.myschema analytics:234
@@ -9,6 +9,12 @@ resource "redshift_schema" "schema" {
   quota = 150
 }
 
+# Schema owned by another team, adopted if it exists and kept on destroy
+resource "redshift_schema" "shared" {
+  name      = "shared_schema"
+  skip_drop = true
+}
+
 # External schema using AWS Glue Data Catalog
 resource "redshift_schema" "external_from_glue_data_catalog" {
   name = "spectrum_schema"
@@ -0,0 +1,7 @@
+# Import a table by <schema>.<table>
+
+terraform import redshift_table.events analytics.events
+
+# Import a table of another database than the one of the provider by <database>:<schema>.<table>
+
+terraform import redshift_table.events warehouse:analytics.events
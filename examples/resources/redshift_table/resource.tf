@@ -0,0 +1,28 @@
+resource "redshift_table" "events" {
+  schema = "analytics"
+  name   = "events"
+  owner  = "etl_user"
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    nullable = false
+    encode   = "az64"
+  }
+
+  column {
+    name = "payload"
+    type = "varchar(1024)"
+  }
+
+  column {
+    name    = "created_at"
+    type    = "timestamp"
+    default = "sysdate"
+  }
+
+  dist_key = "id"
+  sort_key = ["created_at"]
+
+  analyze_on_change = true
+}
@@ -8,3 +8,33 @@ resource "redshift_user" "user_with_unrestricted_syslog" {
   name          = "user_syslog"
   syslog_access = "UNRESTRICTED"
 }
+
+resource "redshift_user" "analyst" {
+  name        = "analyst"
+  search_path = ["$user", "analytics", "public"]
+  query_group = "reporting"
+}
+
+# The password is read from AWS Secrets Manager and never stored in the state
+resource "redshift_user" "etl" {
+  name                = "etl"
+  password_secret_arn = "arn:aws:secretsmanager:eu-central-1:123456789012:secret:redshift/etl-AbCdEf"
+}
+
+# The MD5 hash is "md5" followed by md5(password + user name)
+resource "redshift_user" "reporting" {
+  name            = "reporting"
+  password_hashed = "md5558ae60c68b7b80b807797fe50d6fb73"
+}
+
+# A user only logging in with IAM credentials, e.g. after rotating away from passwords
+resource "redshift_user" "iam_only" {
+  name              = "iam_only"
+  password_disabled = true
+}
+
+# A user federated from an identity provider, without a password
+resource "redshift_user" "federated" {
+  name        = "AzureAD:jane@example.com"
+  external_id = "7f2c9b1e-4d3a-4e6f-9a8b-0c1d2e3f4a5b"
+}
@@ -0,0 +1,2 @@
+# Import a user parameter using the user name and the parameter name separated by a dot
+terraform import redshift_user_parameter.query_group etl_service.query_group
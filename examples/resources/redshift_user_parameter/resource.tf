@@ -0,0 +1,11 @@
+resource "redshift_user_parameter" "query_group" {
+  user      = "etl_service"
+  parameter = "query_group"
+  value     = "etl"
+}
+
+resource "redshift_user_parameter" "statement_timeout" {
+  user      = "etl_service"
+  parameter = "statement_timeout"
+  value     = "3600000"
+}
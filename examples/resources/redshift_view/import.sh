@@ -0,0 +1,7 @@
+# Import a view by <schema>.<view>
+
+terraform import redshift_view.active_users analytics.active_users
+
+# Import a view of another database than the one of the provider by <database>:<schema>.<view>
+
+terraform import redshift_view.active_users warehouse:analytics.active_users
@@ -0,0 +1,11 @@
+resource "redshift_view" "active_users" {
+  schema = "analytics"
+  name   = "active_users"
+  query  = <<-EOT
+    SELECT id, name
+    FROM analytics.users
+    WHERE active
+  EOT
+
+  with_no_schema_binding = true
+}
@@ -0,0 +1,98 @@
+package redshift
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const awsJsonProtocolMediaType = "application/x-amz-json-1.1"
+
+// The provider only needs single operations of some AWS APIs, e.g. GetCredentials of Redshift Serverless,
+// so they are called directly with a signed request instead of depending on the whole service SDKs.
+type awsJsonApi struct {
+	// signingName is the name of the service the requests are signed for
+	signingName string
+	// targetPrefix prefixes the operation in the X-Amz-Target header
+	targetPrefix string
+	// endpointPrefix is the host prefix of the regional endpoint
+	endpointPrefix string
+}
+
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (api awsJsonApi) endpoint(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return fmt.Sprintf("https://%s.%s.amazonaws.com.cn/", api.endpointPrefix, region)
+	}
+	return fmt.Sprintf("https://%s.%s.amazonaws.com/", api.endpointPrefix, region)
+}
+
+// call sends the input of the operation to the endpoint and decodes the response into output.
+func (api awsJsonApi) call(ctx context.Context, cfg aws.Config, endpoint, operation string, input, output interface{}) error {
+	if cfg.Credentials == nil {
+		return fmt.Errorf("no AWS credentials found to call %s", operation)
+	}
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve AWS credentials: %w", err)
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", awsJsonProtocolMediaType)
+	req.Header.Set("X-Amz-Target", fmt.Sprintf("%s.%s", api.targetPrefix, operation))
+
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), api.signingName, cfg.Region, time.Now()); err != nil {
+		return fmt.Errorf("could not sign %s request: %w", operation, err)
+	}
+
+	var httpClient aws.HTTPClient = http.DefaultClient
+	if cfg.HTTPClient != nil {
+		httpClient = cfg.HTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read %s response: %w", operation, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResponse awsErrorResponse
+		if err := json.Unmarshal(respBody, &errResponse); err != nil || errResponse.Type == "" {
+			return fmt.Errorf("%s failed with status %d", operation, resp.StatusCode)
+		}
+		// Some services qualify the error type, e.g. `com.amazonaws.secretsmanager#ResourceNotFoundException`
+		errType := errResponse.Type[strings.LastIndex(errResponse.Type, "#")+1:]
+		return fmt.Errorf("%s failed: %s: %s", operation, errType, errResponse.Message)
+	}
+
+	if err := json.Unmarshal(respBody, output); err != nil {
+		return fmt.Errorf("could not parse %s response: %w", operation, err)
+	}
+	return nil
+}
@@ -1,28 +1,117 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/lib/pq"
 )
 
 var (
 	dbRegistryLock sync.Mutex
 	dbRegistry     = make(map[string]*DBConnection, 1)
+
+	serverlessRegistryLock sync.Mutex
+	serverlessRegistry     = make(map[string]*cachedCheck, 1)
+
+	caseSensitivityRegistryLock sync.Mutex
+	caseSensitivityRegistry     = make(map[string]*cachedCheck, 1)
+
+	serverVersionRegistryLock sync.Mutex
+	serverVersionRegistry     = make(map[string]*cachedServerVersion, 1)
 )
 
+// cachedCheck caches the result of a probe on a connection, e.g. whether it targets Redshift Serverless.
+// It is shared by all provider instances using the same connection string.
+type cachedCheck struct {
+	sync.Mutex
+	checked bool
+	result  bool
+}
+
+// cachedServerVersion caches the version of the server of a connection, shared like cachedCheck.
+type cachedServerVersion struct {
+	sync.Mutex
+	checked bool
+	version RedshiftVersion
+}
+
+// RedshiftVersion is the version of a Redshift cluster or workgroup, e.g. 1.0.38551. The patch
+// version is the build number which increases with every Redshift release.
+type RedshiftVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+var redshiftVersionRegexp = regexp.MustCompile(`Redshift (\d+)\.(\d+)\.(\d+)`)
+
 type Config struct {
 	DriverName string
 	ConnStr    string
 	Database   string
 	MaxConns   int
 
-	serverlessCheckMutex *sync.Mutex
-	isServerless         bool
-	checkedForServerless bool
+	// CheckPrivileges enables pre-checking the privileges of the connected user before an operation
+	CheckPrivileges bool
+
+	// DefaultSchemaOwner is the owner of created schemas not specifying one
+	DefaultSchemaOwner string
+
+	// MaxIdleConns is the number of idle connections kept open, they are closed before
+	// their database is dropped
+	MaxIdleConns int
+
+	// CreateAsRole is the user whose session authorization is assumed in the transactions creating objects
+	CreateAsRole string
+
+	// connStrForDatabase builds the connection string to another database of the same cluster or workgroup,
+	// nil if the configuration can't connect to other databases
+	connStrForDatabase func(database string) string
+
+	// awsConfigLoader loads the AWS configuration of the provider, including the assumed role
+	awsConfigLoader func() (aws.Config, error)
+
+	// ConnectRetries is the number of times a transient connection error is retried,
+	// waiting ConnectRetryInterval before the first retry and doubling it for every other one
+	ConnectRetries       int
+	ConnectRetryInterval time.Duration
+
+	// RetryableErrorCodes are the codes of the errors which are retried by ResourceRetryOnPQErrors,
+	// defaultRetryableErrorCodes are used if empty
+	RetryableErrorCodes []string
+
+	// MaxRetries is the number of times ResourceRetryOnPQErrors retries a failed operation,
+	// NewConfig sets it to the default of the provider
+	MaxRetries int
+
+	// ClusterType is clusterTypeServerless or clusterTypeProvisioned if the type of the cluster was configured,
+	// IsServerless probes the cluster otherwise
+	ClusterType string
+
+	// VisibilityRetries is the number of times waitUntilVisible checks again whether a created object
+	// is visible on Redshift Serverless, waiting VisibilityRetryInterval between the checks
+	VisibilityRetries       int
+	VisibilityRetryInterval time.Duration
 
 	usernameRetrievalMutex *sync.Mutex
 	retrievedUsername      string
+
+	privilegeCheckMutex *sync.Mutex
+	grantedPrivileges   map[string]bool
 }
 
 func NewConfig(driverName, connStr, database string, maxConns int) *Config {
@@ -31,9 +120,11 @@ func NewConfig(driverName, connStr, database string, maxConns int) *Config {
 		ConnStr:    connStr,
 		Database:   database,
 		MaxConns:   maxConns,
+		MaxRetries: defaultProviderMaxRetries,
 
-		serverlessCheckMutex:   &sync.Mutex{},
 		usernameRetrievalMutex: &sync.Mutex{},
+		privilegeCheckMutex:    &sync.Mutex{},
+		grantedPrivileges:      make(map[string]bool),
 	}
 }
 
@@ -48,6 +139,42 @@ type DBConnection struct {
 	*sql.DB
 
 	client *Client
+
+	// ctx is the context of the resource operation, the queries are cancelled when it is done
+	ctx context.Context
+}
+
+// withContext returns a copy of the connection running its queries with the given context,
+// so that e.g. interrupting terraform apply cancels the running statements.
+func (db *DBConnection) withContext(ctx context.Context) *DBConnection {
+	conn := *db
+	conn.ctx = ctx
+	return &conn
+}
+
+func (db *DBConnection) context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
+func (db *DBConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.QueryContext(db.context(), query, args...)
+}
+
+func (db *DBConnection) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRowContext(db.context(), query, args...)
+}
+
+func (db *DBConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.ExecContext(db.context(), query, args...)
+}
+
+// Begin starts a transaction with the context of the connection. The driver cancels the statement
+// running in the transaction when the context is done.
+func (db *DBConnection) Begin() (*sql.Tx, error) {
+	return db.DB.BeginTx(db.context(), nil)
 }
 
 // NewClient returns client config for the specified database.
@@ -57,36 +184,249 @@ func (c *Config) NewClient() *Client {
 	}
 }
 
+// ForDatabase returns a copy of the configuration connecting to another database of the same cluster or
+// workgroup with the same credentials, or the configuration itself for its own database.
+func (c *Config) ForDatabase(database string) (*Config, error) {
+	if database == "" || database == c.Database {
+		return c, nil
+	}
+	if c.connStrForDatabase == nil {
+		return nil, fmt.Errorf("the provider can't connect to database %q", database)
+	}
+
+	derived := *c
+	derived.ConnStr = c.connStrForDatabase(database)
+	derived.Database = database
+	// privileges are checked on the objects of the database
+	derived.privilegeCheckMutex = &sync.Mutex{}
+	derived.grantedPrivileges = make(map[string]bool)
+	return &derived, nil
+}
+
+// ForDatabase returns a client connecting to another database of the same cluster or workgroup.
+func (c *Client) ForDatabase(database string) (*Client, error) {
+	config, err := c.config.ForDatabase(database)
+	if err != nil {
+		return nil, err
+	}
+	if config == &c.config {
+		return c, nil
+	}
+	return config.NewClient(), nil
+}
+
+// AwsConfig returns the AWS configuration of the provider to call AWS APIs,
+// falling back to the default configuration if the provider was configured otherwise.
+func (c *Config) AwsConfig() (aws.Config, error) {
+	if c.awsConfigLoader == nil {
+		return config.LoadDefaultConfig(context.TODO())
+	}
+	return c.awsConfigLoader()
+}
+
+// IsServerless returns whether the provider is connected to Redshift Serverless. Unless the cluster type
+// was configured, the probe runs at most once per connection string, failed probes are not cached and run
+// again on the next call.
 func (c *Config) IsServerless(db *DBConnection) (bool, error) {
-	if c.serverlessCheckMutex == nil {
-		c.serverlessCheckMutex = &sync.Mutex{}
+	switch c.ClusterType {
+	case clusterTypeServerless:
+		return true, nil
+	case clusterTypeProvisioned:
+		return false, nil
+	}
+
+	return getServerlessCheck(c.ConnStr).get(func() (bool, error) {
+		return probeServerless(db)
+	})
+}
+
+func getServerlessCheck(connStr string) *cachedCheck {
+	serverlessRegistryLock.Lock()
+	defer serverlessRegistryLock.Unlock()
+
+	check, found := serverlessRegistry[connStr]
+	if !found {
+		check = &cachedCheck{}
+		serverlessRegistry[connStr] = check
+	}
+	return check
+}
+
+func (s *cachedCheck) get(probe func() (bool, error)) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.checked {
+		return s.result, nil
+	}
+
+	result, err := probe()
+	if err != nil {
+		return false, err
+	}
+
+	s.checked = true
+	s.result = result
+	return result, nil
+}
+
+// ServerVersion returns the version of Redshift the provider is connected to, to tell features the
+// cluster doesn't support yet apart from other errors. The version is read at most once per connection string.
+func (c *Config) ServerVersion(db *DBConnection) (RedshiftVersion, error) {
+	cached := getServerVersionCache(c.ConnStr)
+	cached.Lock()
+	defer cached.Unlock()
+	if cached.checked {
+		return cached.version, nil
+	}
+
+	var rawVersion string
+	if err := db.QueryRow("SELECT version()").Scan(&rawVersion); err != nil {
+		return RedshiftVersion{}, fmt.Errorf("could not read the Redshift version: %w", err)
+	}
+	version, err := parseRedshiftVersion(rawVersion)
+	if err != nil {
+		return RedshiftVersion{}, err
+	}
+
+	cached.checked = true
+	cached.version = version
+	return version, nil
+}
+
+// ServerVersion returns the version of Redshift the connection is connected to.
+func (db *DBConnection) ServerVersion() (RedshiftVersion, error) {
+	return db.client.config.ServerVersion(db)
+}
+
+// cachedServerVersion returns the version of Redshift if it was already read, without querying it.
+func (c *Config) cachedServerVersion() (RedshiftVersion, bool) {
+	cached := getServerVersionCache(c.ConnStr)
+	cached.Lock()
+	defer cached.Unlock()
+	return cached.version, cached.checked
+}
+
+func getServerVersionCache(connStr string) *cachedServerVersion {
+	serverVersionRegistryLock.Lock()
+	defer serverVersionRegistryLock.Unlock()
+
+	cached, found := serverVersionRegistry[connStr]
+	if !found {
+		cached = &cachedServerVersion{}
+		serverVersionRegistry[connStr] = cached
+	}
+	return cached
+}
+
+// parseRedshiftVersion parses the Redshift version from the result of version(), e.g.
+// `PostgreSQL 8.0.2 on i686-pc-linux-gnu, compiled by GCC gcc (GCC) 3.4.2 20041017 (Red Hat 3.4.2-6.fc3), Redshift 1.0.38551`.
+func parseRedshiftVersion(version string) (RedshiftVersion, error) {
+	match := redshiftVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return RedshiftVersion{}, fmt.Errorf("could not find the Redshift version in %q", version)
+	}
+
+	var parsed RedshiftVersion
+	for i, part := range []*int{&parsed.Major, &parsed.Minor, &parsed.Patch} {
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return RedshiftVersion{}, fmt.Errorf("invalid Redshift version in %q: %w", version, err)
+		}
+		*part = value
+	}
+	return parsed, nil
+}
+
+func (v RedshiftVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast returns whether the version is the same as or newer than the other version.
+func (v RedshiftVersion) AtLeast(other RedshiftVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
 	}
-	c.serverlessCheckMutex.Lock()
-	defer c.serverlessCheckMutex.Unlock()
-	if c.checkedForServerless {
-		return c.isServerless, nil
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
 	}
+	return v.Patch >= other.Patch
+}
+
+// unsupportedSyntaxError explains syntax errors of statements which older Redshift versions don't support
+// with the version of the cluster. Other errors are returned unchanged. The version is read by startTransaction
+// and isn't queried here, as the transaction of the statement may hold the only connection of the pool.
+func unsupportedSyntaxError(db *DBConnection, feature string, err error) error {
+	if !isPqErrorWithCode(err, pqErrorCodeSyntaxError) {
+		return err
+	}
+	version, known := db.client.config.cachedServerVersion()
+	if !known {
+		return err
+	}
+	return fmt.Errorf("%s may not be supported by Redshift %s of the cluster, check whether it needs to be updated: %w", feature, version, err)
+}
 
-	c.checkedForServerless = true
+// CaseSensitiveIdentifiers returns whether enable_case_sensitive_identifier is on for the sessions
+// of the provider, e.g. because it was set for the database or the user. The probe runs at most once
+// per connection string: if the setting can't be read, identifiers are assumed to be case insensitive,
+// the Redshift default, rather than probing again while a transaction may hold the only connection.
+func (c *Config) CaseSensitiveIdentifiers(db *DBConnection) bool {
+	caseSensitive, _ := getCaseSensitivityCheck(c.ConnStr).get(func() (bool, error) {
+		caseSensitive, err := probeCaseSensitiveIdentifiers(db)
+		if err != nil {
+			log.Printf("[WARN] could not detect whether identifiers are case sensitive, assuming they are not: %v", err)
+		}
+		return caseSensitive, nil
+	})
+	return caseSensitive
+}
+
+func getCaseSensitivityCheck(connStr string) *cachedCheck {
+	caseSensitivityRegistryLock.Lock()
+	defer caseSensitivityRegistryLock.Unlock()
+
+	check, found := caseSensitivityRegistry[connStr]
+	if !found {
+		check = &cachedCheck{}
+		caseSensitivityRegistry[connStr] = check
+	}
+	return check
+}
+
+func probeCaseSensitiveIdentifiers(db *DBConnection) (bool, error) {
+	var value string
+	if err := db.QueryRow("SHOW enable_case_sensitive_identifier").Scan(&value); err != nil {
+		return false, fmt.Errorf("could not read enable_case_sensitive_identifier: %w", err)
+	}
+	return parseBoolSetting(value), nil
+}
 
+// parseBoolSetting parses the value of a boolean configuration parameter as returned by SHOW.
+func parseBoolSetting(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "true", "yes", "1":
+		return true
+	}
+	return false
+}
+
+func probeServerless(db *DBConnection) (bool, error) {
 	rows, err := db.Query("SELECT 1 FROM SYS_SERVERLESS_USAGE")
 	// No error means we have accessed the view and are running Redshift Serverless
 	if err == nil {
-		defer rows.Close()
-		c.isServerless = true
+		rows.Close()
 		return true, nil
 	}
 
 	// Insuficcient privileges means we do not have access to this view ergo we run on Redshift classic
 	if isPqErrorWithCode(err, pgErrorCodeInsufficientPrivileges) {
-		_, err := db.Query("SELECT 1 FROM SVL_QUERY_SUMMARY")
+		rows, err := db.Query("SELECT 1 FROM SVL_QUERY_SUMMARY")
 		// An error means we are running Multi-AZ Provisioned Redshift which behaves in some cases as serverless
 		if err != nil {
-			c.isServerless = true
 			return true, nil
 		}
+		rows.Close()
 
-		c.isServerless = false
 		return false, nil
 	}
 
@@ -114,47 +454,175 @@ func (c *Config) GetUsername(db *DBConnection) (string, error) {
 	return c.retrievedUsername, nil
 }
 
+// HasPrivilege returns whether the connected user has the given privilege on an object.
+// Granted privileges are cached, missing ones are checked again as they might be granted during the same apply.
+func (c *Config) HasPrivilege(db *DBConnection, objectType, objectName, privilege string) (bool, error) {
+	query, err := privilegeCheckQuery(objectType)
+	if err != nil {
+		return false, err
+	}
+
+	username, err := c.GetUsername(db)
+	if err != nil {
+		return false, err
+	}
+
+	if c.privilegeCheckMutex == nil {
+		c.privilegeCheckMutex = &sync.Mutex{}
+	}
+	c.privilegeCheckMutex.Lock()
+	defer c.privilegeCheckMutex.Unlock()
+	if c.grantedPrivileges == nil {
+		c.grantedPrivileges = make(map[string]bool)
+	}
+	key := fmt.Sprintf("%s/%s/%s", objectType, objectName, privilege)
+	if c.grantedPrivileges[key] {
+		return true, nil
+	}
+
+	var granted bool
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s, $3=%s\n", query, username, objectName, privilege)
+	if err := db.QueryRow(query, username, objectName, privilege).Scan(&granted); err != nil {
+		return false, fmt.Errorf("error checking %s privilege on %s %q: %w", privilege, objectType, objectName, err)
+	}
+	if granted {
+		c.grantedPrivileges[key] = true
+	}
+	return granted, nil
+}
+
 // Connect returns a copy to an sql.Open()'ed database connection wrapped in a DBConnection struct.
 // Callers must return their database resources. Use of QueryRow() or Exec() is encouraged.
 // Query() must have their rows.Close()'ed.
 func (c *Client) Connect() (*DBConnection, error) {
+	dsn := c.config.ConnStr
+
+	dbRegistryLock.Lock()
+	registered, found := dbRegistry[dsn]
+	if found && registered.Ping() == nil {
+		dbRegistryLock.Unlock()
+		return registered, nil
+	}
+	dbRegistryLock.Unlock()
+
+	// The connection is opened without holding the lock, as its retries may take a while
+	conn, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+
 	dbRegistryLock.Lock()
 	defer dbRegistryLock.Unlock()
 
-	dsn := c.config.ConnStr
-	driverName := c.config.DriverName
-	conn, found := dbRegistry[dsn]
+	// Another caller may have connected in the meantime, its connection is shared instead
+	if current, found := dbRegistry[dsn]; found && current != registered {
+		conn.Close()
+		return current, nil
+	}
+	dbRegistry[dsn] = conn
 
-	if !found || conn.Ping() != nil {
-		db, err := sql.Open(driverName, dsn)
-		if err != nil {
-			return nil, fmt.Errorf("error creating Redshift driver instance (driver: %q): %w", driverName, err)
-		}
+	return conn, nil
+}
 
-		// We don't want to retain connection
-		// So when we connect on a specific database which might be managed by terraform,
-		// we don't keep opened connection in case of the db has to be dropped in the plan.
-		db.SetMaxIdleConns(0)
-		db.SetMaxOpenConns(c.config.MaxConns)
+// open opens a new connection pool and checks that it can connect, the pool is closed if it can't.
+func (c *Client) open() (*DBConnection, error) {
+	driverName := c.config.DriverName
+	db, err := sql.Open(driverName, c.config.ConnStr)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Redshift driver instance (driver: %q): %w", driverName, err)
+	}
 
-		conn = &DBConnection{
-			db,
-			c,
-		}
+	// By default we don't want to retain connections,
+	// so when we connect on a specific database which might be managed by terraform,
+	// we don't keep opened connections in case the db has to be dropped in the plan.
+	// Otherwise they are released by releaseConnections before dropping the database.
+	db.SetMaxIdleConns(c.config.MaxIdleConns)
+	db.SetMaxOpenConns(c.config.MaxConns)
 
-		_, err = c.config.GetUsername(conn)
-		if err != nil {
-			return nil, fmt.Errorf("error retrieving username from Redshift database (driver: %q): %w", driverName, err)
-		}
+	conn := &DBConnection{
+		DB:     db,
+		client: c,
+	}
 
-		dbRegistry[dsn] = conn
+	err = retryOnTransientConnectionErrors(c.config.ConnectRetries, c.config.ConnectRetryInterval, conn.Ping)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error connecting to Redshift database (driver: %q): %w", driverName, err)
+	}
+
+	_, err = c.config.GetUsername(conn)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error retrieving username from Redshift database (driver: %q): %w", driverName, err)
 	}
 
 	return conn, nil
 }
 
+// releaseConnections closes the connection pools to the given database and removes them from the registry,
+// as a database can't be dropped while connections to it are open. Connections in use are closed as soon as
+// they are released, later callers open a new pool.
+func releaseConnections(database string) {
+	dbRegistryLock.Lock()
+	defer dbRegistryLock.Unlock()
+
+	for dsn, conn := range dbRegistry {
+		if strings.EqualFold(conn.client.config.Database, database) {
+			log.Printf("[DEBUG] closing connections to database %s\n", database)
+			conn.Close()
+			delete(dbRegistry, dsn)
+		}
+	}
+}
+
 func (c *Client) Close() {
 	if c.db != nil {
 		c.db.Close()
 	}
 }
+
+// retryOnTransientConnectionErrors calls connect until it succeeds, it fails with an error which
+// isn't transient or the retries are exhausted. The interval between attempts doubles every time.
+func retryOnTransientConnectionErrors(retries int, interval time.Duration, connect func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			return nil
+		}
+		if attempt > retries || !isTransientConnectionError(err) {
+			return err
+		}
+
+		log.Printf("[DEBUG] Connection attempt %d of %d failed, retrying in %s: %v\n", attempt, retries+1, interval, err)
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// isTransientConnectionError returns true for errors which may resolve by themselves, e.g. while
+// a paused cluster is resuming. Authentication failures are never transient.
+func isTransientConnectionError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// 08: connection exception, 57P03: cannot connect now, e.g. the cluster is starting up
+		return pqErr.Code.Class() == "08" || pqErr.Code == "57P03"
+	}
+
+	// A host which can't be resolved is a misconfiguration, unless the lookup itself failed temporarily
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return strings.Contains(err.Error(), "connection refused")
+}
@@ -2,6 +2,8 @@ package redshift
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -10,20 +12,65 @@ import (
 
 const redshiftDataDriverName = "redshift-data"
 
-func NewDataApiConfig(workgroupName, database, awsRegion string, maxConns int) *Config {
-	connStr := buildConnStrFromDataApiConfig(workgroupName, database, awsRegion)
-	return NewConfig(redshiftDataDriverName, connStr, database, maxConns)
+func NewDataApiConfig(workgroupName, database, awsRegion string, pollingInterval, timeout, maxConns int) *Config {
+	connStrForDatabase := func(database string) string {
+		return buildConnStrFromDataApiConfig(workgroupName, database, awsRegion, pollingInterval, timeout)
+	}
+	cfg := NewConfig(redshiftDataDriverName, connStrForDatabase(database), database, maxConns)
+	cfg.connStrForDatabase = connStrForDatabase
+	return cfg
 }
 
-func buildConnStrFromDataApiConfig(workgroupName, database, awsRegion string) string {
+func buildConnStrFromDataApiConfig(workgroupName, database, awsRegion string, pollingInterval, timeout int) string {
 	return fmt.Sprintf(
-		"workgroup(%s)/%s?region=%s&transactionMode=non-transactional&requestMode=blocking",
-		workgroupName, database, awsRegion,
+		"workgroup(%s)/%s?%s",
+		workgroupName, database, buildDataApiConnParams(awsRegion, pollingInterval, timeout),
 	)
 }
 
+// NewDataApiClusterConfig returns the configuration to use the Data API with a provisioned cluster,
+// authenticating as the database user with temporary credentials.
+func NewDataApiClusterConfig(clusterIdentifier, dbUser, database, awsRegion string, pollingInterval, timeout, maxConns int) *Config {
+	connStrForDatabase := func(database string) string {
+		return buildConnStrFromDataApiClusterConfig(clusterIdentifier, dbUser, database, awsRegion, pollingInterval, timeout)
+	}
+	cfg := NewConfig(redshiftDataDriverName, connStrForDatabase(database), database, maxConns)
+	cfg.connStrForDatabase = connStrForDatabase
+	return cfg
+}
+
+func buildConnStrFromDataApiClusterConfig(clusterIdentifier, dbUser, database, awsRegion string, pollingInterval, timeout int) string {
+	return fmt.Sprintf(
+		"%s@cluster(%s)/%s?%s",
+		url.User(dbUser).String(), clusterIdentifier, database, buildDataApiConnParams(awsRegion, pollingInterval, timeout),
+	)
+}
+
+// buildDataApiConnParams returns the query parameters understood by the Data API driver.
+// The driver polls DescribeStatement every pollingInterval seconds until the statement
+// finishes or timeout seconds have passed.
+func buildDataApiConnParams(awsRegion string, pollingInterval, timeout int) string {
+	params := fmt.Sprintf("region=%s&transactionMode=non-transactional&requestMode=blocking", awsRegion)
+	if pollingInterval > 0 {
+		params += "&polling=" + (time.Duration(pollingInterval) * time.Second).String()
+	}
+	if timeout > 0 {
+		params += "&timeout=" + (time.Duration(timeout) * time.Second).String()
+	}
+	return params
+}
+
 func getConfigFromDataApiResourceData(d *schema.ResourceData, database string) (*Config, error) {
-	workgroupName := d.Get("data_api.0.workgroup_name").(string)
 	region := d.Get("data_api.0.region").(string)
-	return NewDataApiConfig(workgroupName, database, region, 1), nil
+	pollingInterval := d.Get("data_api.0.polling_interval").(int)
+	timeout := d.Get("data_api.0.timeout").(int)
+	if clusterIdentifier := d.Get("data_api.0.cluster_identifier").(string); clusterIdentifier != "" {
+		dbUser := d.Get("data_api.0.db_user").(string)
+		return NewDataApiClusterConfig(clusterIdentifier, dbUser, database, region, pollingInterval, timeout, 1), nil
+	}
+	workgroupName := d.Get("data_api.0.workgroup_name").(string)
+	if workgroupName == "" {
+		return nil, fmt.Errorf("data_api requires either a workgroup_name or a cluster_identifier")
+	}
+	return NewDataApiConfig(workgroupName, database, region, pollingInterval, timeout, 1), nil
 }
@@ -0,0 +1,106 @@
+package redshift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBuildConnStrFromDataApiConfig(t *testing.T) {
+	expected := "workgroup(some-workgroup)/some-database?region=eu-central-1&transactionMode=non-transactional&requestMode=blocking&polling=2s&timeout=10m0s"
+	if got := buildConnStrFromDataApiConfig("some-workgroup", "some-database", "eu-central-1", 2, 600); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestBuildConnStrFromDataApiClusterConfig(t *testing.T) {
+	expected := "etl@cluster(some-cluster)/some-database?region=eu-central-1&transactionMode=non-transactional&requestMode=blocking&polling=1s&timeout=5m0s"
+	if got := buildConnStrFromDataApiClusterConfig("some-cluster", "etl", "some-database", "eu-central-1", 1, 300); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+// TestAccRedshiftDataApi_UserGroupRole runs the CRUD operations of the user, group, role and role grant
+// resources through the Data API, whose driver executes the statements with ExecuteStatement.
+func TestAccRedshiftDataApi_UserGroupRole(t *testing.T) {
+	workgroupName := getEnvOrSkip("REDSHIFT_DATA_API_SERVERLESS_WORKGROUP_NAME", t)
+	defer unsetAndSetEnvVars("REDSHIFT_HOST", "REDSHIFT_PASSWORD")()
+
+	userName := generateRandomObjectName("tf_acc_data_api")
+	groupName := generateRandomObjectName("tf_acc_data_api")
+	roleName := generateRandomObjectName("tf_acc_data_api")
+
+	config := func(groupUsers, roleGranteeType, roleGranteeName string) string {
+		return fmt.Sprintf(`
+provider "redshift" {
+  data_api {
+    workgroup_name = %[1]q
+  }
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_group" "group" {
+  name  = %[3]q
+  users = %[4]s
+}
+
+resource "redshift_role" "role" {
+  name = %[5]q
+}
+
+resource "redshift_role_grant" "grant" {
+  role_name     = redshift_role.role.name
+  grant_to_type = %[6]q
+  grant_to_name = %[7]s
+}
+`, workgroupName, userName, groupName, groupUsers, roleName, roleGranteeType, roleGranteeName)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckRedshiftRoleDestroy(s); err != nil {
+				return err
+			}
+			if err := testAccCheckRedshiftGroupDestroy(s); err != nil {
+				return err
+			}
+			return testAccCheckRedshiftUserDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("[]", "user", "redshift_user.user.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_group.group", "name", groupName),
+					resource.TestCheckResourceAttr("redshift_group.group", "users.#", "0"),
+					resource.TestCheckResourceAttr("redshift_role.role", "name", roleName),
+					resource.TestCheckResourceAttr("redshift_role_grant.grant", "id", generateRoleGrantID(roleName, "user", userName)),
+				),
+			},
+			{
+				Config: config("[redshift_user.user.name]", "group", "redshift_group.group.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_group.group", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_group.group", "users.*", userName),
+					resource.TestCheckResourceAttr("redshift_role_grant.grant", "id", generateRoleGrantID(roleName, "group", groupName)),
+				),
+			},
+			{
+				ResourceName:      "redshift_group.group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,10 +2,14 @@ package redshift
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"log"
+	"net"
 	"net/url"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,16 +24,123 @@ import (
 
 type temporaryCredentialsResolverFunc func(username string, d *schema.ResourceData) (string, string, error)
 
-func NewPqConfig(host, database, username, password string, port int, sslMode string, maxConns int) *Config {
-	connStr := buildConnStrFromPqConfig(host, database, username, password, port, sslMode)
-	return NewConfig(proxyDriverName, connStr, database, maxConns)
+// SSLConfig configures the TLS connection, see the libpq parameters of the same names.
+type SSLConfig struct {
+	Mode     string
+	Cert     string
+	Key      string
+	RootCert string
+	// Inline is set when Cert, Key and RootCert are PEM encoded contents instead of file paths.
+	Inline bool
+	// RootCertPEM is the PEM encoded content of the root certificate, for when only the root certificate is inline.
+	RootCertPEM string
 }
 
-func buildConnStrFromPqConfig(host, database, username, password string, port int, sslMode string) string {
+// validate checks that the certificate files can be read, or that the inline certificates are PEM encoded,
+// before the first connection fails with a less helpful error.
+func (c SSLConfig) validate() error {
+	if (c.Cert == "") != (c.Key == "") {
+		return fmt.Errorf("sslcert and sslkey must be set together")
+	}
+	if c.RootCertPEM != "" {
+		if c.RootCert != "" {
+			return fmt.Errorf("only one of sslrootcert and sslrootcert_pem can be set")
+		}
+		if block, _ := pem.Decode([]byte(c.RootCertPEM)); block == nil {
+			return fmt.Errorf("sslrootcert_pem is not PEM encoded")
+		}
+	}
+	for _, file := range []struct{ param, value string }{
+		{"sslcert", c.Cert},
+		{"sslkey", c.Key},
+		{"sslrootcert", c.RootCert},
+	} {
+		if file.value == "" {
+			continue
+		}
+		if c.Inline {
+			if block, _ := pem.Decode([]byte(file.value)); block == nil {
+				return fmt.Errorf("%s is not PEM encoded, although sslinline is set", file.param)
+			}
+			continue
+		}
+		f, err := os.Open(file.value)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", file.param, err)
+		}
+		f.Close()
+	}
+	return nil
+}
+
+// withInlineRootCert returns the configuration passing the root certificate of RootCertPEM inline. As lib/pq
+// passes either all or none of the certificates inline, the client certificate and key are read from their files.
+func (c SSLConfig) withInlineRootCert() (SSLConfig, error) {
+	if c.RootCertPEM == "" {
+		return c, nil
+	}
+	if !c.Inline && c.Cert != "" {
+		cert, err := os.ReadFile(c.Cert)
+		if err != nil {
+			return c, fmt.Errorf("could not read sslcert: %w", err)
+		}
+		key, err := os.ReadFile(c.Key)
+		if err != nil {
+			return c, fmt.Errorf("could not read sslkey: %w", err)
+		}
+		c.Cert, c.Key = string(cert), string(key)
+	}
+	c.RootCert, c.RootCertPEM, c.Inline = c.RootCertPEM, "", true
+	return c, nil
+}
+
+func NewPqConfig(host, database, username, password string, port int, ssl SSLConfig, timezone, applicationName string, connectTimeout, statementTimeout, maxConns int, keepAlive net.KeepAliveConfig) *Config {
+	connStrForDatabase := func(database string) string {
+		return buildConnStrFromPqConfig(host, database, username, password, port, ssl, timezone, applicationName, connectTimeout, statementTimeout, keepAlive)
+	}
+	cfg := NewConfig(proxyDriverName, connStrForDatabase(database), database, maxConns)
+	cfg.connStrForDatabase = connStrForDatabase
+	return cfg
+}
+
+func buildConnStrFromPqConfig(host, database, username, password string, port int, ssl SSLConfig, timezone, applicationName string, connectTimeout, statementTimeout int, keepAlive net.KeepAliveConfig) string {
 	params := map[string]string{}
 
-	params["sslmode"] = sslMode
-	params["connect_timeout"] = "180"
+	params["sslmode"] = ssl.Mode
+	if ssl.Cert != "" {
+		params["sslcert"] = ssl.Cert
+	}
+	if ssl.Key != "" {
+		params["sslkey"] = ssl.Key
+	}
+	if ssl.RootCert != "" {
+		params["sslrootcert"] = ssl.RootCert
+	}
+	if ssl.Inline {
+		params["sslinline"] = "true"
+	}
+	params["connect_timeout"] = strconv.Itoa(connectTimeout)
+	if timezone != "" {
+		// Sent as a run-time parameter on connect, pinning the session time zone
+		params["timezone"] = timezone
+	}
+	if applicationName != "" {
+		// Identifies the provider's sessions, e.g. in stl_connection_log and stl_query
+		params["application_name"] = applicationName
+	}
+	if statementTimeout > 0 {
+		// Sent as a run-time parameter as well, so it applies to every pooled connection
+		params["statement_timeout"] = strconv.Itoa(statementTimeout)
+	}
+	if keepAlive.Enable {
+		// Handled by the proxy driver, see keepAliveParams
+		params["keepalives"] = "1"
+		params["keepalives_idle"] = strconv.Itoa(int(keepAlive.Idle / time.Second))
+		params["keepalives_interval"] = strconv.Itoa(int(keepAlive.Interval / time.Second))
+		params["keepalives_count"] = strconv.Itoa(keepAlive.Count)
+	} else {
+		params["keepalives"] = "0"
+	}
 
 	var paramsArray []string
 	for key, value := range params {
@@ -54,7 +165,30 @@ func getConfigFromPqResourceData(d *schema.ResourceData, database string, maxCon
 	host := d.Get("host").(string)
 	username := d.Get("username").(string)
 	port := d.Get("port").(int)
-	sslMode := d.Get("sslmode").(string)
+	ssl := SSLConfig{
+		Mode:        d.Get("sslmode").(string),
+		Cert:        d.Get("sslcert").(string),
+		Key:         d.Get("sslkey").(string),
+		RootCert:    d.Get("sslrootcert").(string),
+		Inline:      d.Get("sslinline").(bool),
+		RootCertPEM: d.Get("sslrootcert_pem").(string),
+	}
+	if err := ssl.validate(); err != nil {
+		return nil, err
+	}
+	if ssl, err = ssl.withInlineRootCert(); err != nil {
+		return nil, err
+	}
+	timezone := d.Get("timezone").(string)
+	applicationName := d.Get("application_name").(string)
+	keepAlive := net.KeepAliveConfig{
+		Enable:   d.Get("keepalives").(bool),
+		Idle:     time.Duration(d.Get("keepalives_idle").(int)) * time.Second,
+		Interval: time.Duration(d.Get("keepalives_interval").(int)) * time.Second,
+		Count:    d.Get("keepalives_count").(int),
+	}
+	connectTimeout := d.Get("connect_timeout").(int)
+	statementTimeout := d.Get("statement_timeout").(int)
 	_, useTemporaryCredentials := d.GetOk("temporary_credentials")
 	if useTemporaryCredentials {
 		log.Println("[DEBUG] using temporary credentials authentication")
@@ -67,11 +201,16 @@ func getConfigFromPqResourceData(d *schema.ResourceData, database string, maxCon
 		log.Println("[DEBUG] using password authentication")
 		password = d.Get("password").(string)
 	}
-	return NewPqConfig(host, database, username, password, port, sslMode, maxConnections), nil
+	return NewPqConfig(host, database, username, password, port, ssl, timezone, applicationName, connectTimeout, statementTimeout, maxConnections, keepAlive), nil
 }
 
-// temporaryCredentials gets temporary credentials using GetClusterCredentials
+// temporaryCredentials gets temporary credentials using GetClusterCredentials,
+// or GetClusterCredentialsWithIAM when use_iam_identity is set.
+// For Redshift Serverless workgroups the redshift-serverless GetCredentials is used instead.
 func temporaryCredentials(username string, d *schema.ResourceData) (string, string, error) {
+	if workgroupName, ok := d.GetOk("temporary_credentials.0.workgroup_name"); ok {
+		return serverlessTemporaryCredentials(workgroupName.(string), d)
+	}
 	sdkClient, err := redshiftSdkClient(d)
 	if err != nil {
 		return "", "", err
@@ -80,6 +219,9 @@ func temporaryCredentials(username string, d *schema.ResourceData) (string, stri
 	if !clusterIdentifierIsSet {
 		return "", "", fmt.Errorf("temporary_credentials not configured")
 	}
+	if d.Get("temporary_credentials.0.use_iam_identity").(bool) {
+		return temporaryCredentialsWithIAM(sdkClient, clusterIdentifier.(string), d)
+	}
 	input := &redshift.GetClusterCredentialsInput{
 		ClusterIdentifier: aws.String(clusterIdentifier.(string)),
 		DbName:            aws.String(d.Get("database").(string)),
@@ -116,11 +258,42 @@ func temporaryCredentials(username string, d *schema.ResourceData) (string, stri
 	return aws.ToString(response.DbUser), aws.ToString(response.DbPassword), nil
 }
 
+// temporaryCredentialsWithIAM gets temporary credentials using GetClusterCredentialsWithIAM.
+// The database user is derived from the IAM identity, so the configured username is ignored.
+func temporaryCredentialsWithIAM(sdkClient *redshift.Client, clusterIdentifier string, d *schema.ResourceData) (string, string, error) {
+	input := &redshift.GetClusterCredentialsWithIAMInput{
+		ClusterIdentifier: aws.String(clusterIdentifier),
+		DbName:            aws.String(d.Get("database").(string)),
+	}
+	if durationSeconds, ok := d.GetOk("temporary_credentials.0.duration_seconds"); ok {
+		duration := durationSeconds.(int)
+		if duration > 0 {
+			input.DurationSeconds = aws.Int32(int32(duration))
+		}
+	}
+	log.Println("[DEBUG] making GetClusterCredentialsWithIAM request")
+	response, err := sdkClient.GetClusterCredentialsWithIAM(context.TODO(), input)
+	if err != nil {
+		return "", "", err
+	}
+	return aws.ToString(response.DbUser), aws.ToString(response.DbPassword), nil
+}
+
 func redshiftSdkClient(d *schema.ResourceData) (*redshift.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := temporaryCredentialsAwsConfig(d)
 	if err != nil {
 		return nil, err
 	}
+	return redshift.NewFromConfig(cfg), nil
+}
+
+// temporaryCredentialsAwsConfig loads the AWS configuration used to request temporary credentials,
+// applying the region and the role to assume of the temporary_credentials block.
+func temporaryCredentialsAwsConfig(d *schema.ResourceData) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		return aws.Config{}, err
+	}
 
 	if region := d.Get("temporary_credentials.0.region").(string); region != "" {
 		cfg.Region = region
@@ -144,5 +317,5 @@ func redshiftSdkClient(d *schema.ResourceData) (*redshift.Client, error) {
 		stsClient := sts.NewFromConfig(cfg)
 		cfg.Credentials = stscreds.NewAssumeRoleProvider(stsClient, parsedRoleArn, opts)
 	}
-	return redshift.NewFromConfig(cfg), nil
+	return cfg, nil
 }
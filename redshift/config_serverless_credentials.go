@@ -0,0 +1,61 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// See https://docs.aws.amazon.com/redshift-serverless/latest/APIReference/API_GetCredentials.html
+var serverlessApi = awsJsonApi{
+	signingName:    "redshift-serverless",
+	targetPrefix:   "RedshiftServerless",
+	endpointPrefix: "redshift-serverless",
+}
+
+type serverlessGetCredentialsInput struct {
+	WorkgroupName   string `json:"workgroupName"`
+	DbName          string `json:"dbName,omitempty"`
+	DurationSeconds int32  `json:"durationSeconds,omitempty"`
+}
+
+type serverlessGetCredentialsOutput struct {
+	DbUser     string `json:"dbUser"`
+	DbPassword string `json:"dbPassword"`
+}
+
+// serverlessTemporaryCredentials gets temporary credentials for a Redshift Serverless workgroup.
+// The database user is derived from the IAM identity, so the configured username is ignored.
+func serverlessTemporaryCredentials(workgroupName string, d *schema.ResourceData) (string, string, error) {
+	cfg, err := temporaryCredentialsAwsConfig(d)
+	if err != nil {
+		return "", "", err
+	}
+	if cfg.Region == "" {
+		return "", "", fmt.Errorf("the region of the Redshift Serverless workgroup %s is not configured", workgroupName)
+	}
+	input := serverlessGetCredentialsInput{
+		WorkgroupName: workgroupName,
+		DbName:        d.Get("database").(string),
+	}
+	if durationSeconds, ok := d.GetOk("temporary_credentials.0.duration_seconds"); ok {
+		input.DurationSeconds = int32(durationSeconds.(int))
+	}
+	log.Println("[DEBUG] making redshift-serverless GetCredentials request")
+	output, err := serverlessGetCredentials(context.TODO(), cfg, serverlessApi.endpoint(cfg.Region), input)
+	if err != nil {
+		return "", "", err
+	}
+	return output.DbUser, output.DbPassword, nil
+}
+
+func serverlessGetCredentials(ctx context.Context, cfg aws.Config, endpoint string, input serverlessGetCredentialsInput) (*serverlessGetCredentialsOutput, error) {
+	var output serverlessGetCredentialsOutput
+	if err := serverlessApi.call(ctx, cfg, endpoint, "GetCredentials", input, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
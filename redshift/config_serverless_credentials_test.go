@@ -0,0 +1,83 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestServerlessGetCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "RedshiftServerless.GetCredentials" {
+			t.Errorf("Expected target %q but got %q", "RedshiftServerless.GetCredentials", target)
+		}
+		if auth := r.Header.Get("Authorization"); !strings.Contains(auth, "/eu-central-1/redshift-serverless/aws4_request") {
+			t.Errorf("Expected request signed for redshift-serverless in eu-central-1 but got %q", auth)
+		}
+
+		var input serverlessGetCredentialsInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatalf("Unable to decode request: %s", err)
+		}
+		if input.WorkgroupName != "my-workgroup" || input.DbName != "dev" || input.DurationSeconds != 900 {
+			t.Errorf("Unexpected request %+v", input)
+		}
+
+		w.Header().Set("Content-Type", awsJsonProtocolMediaType)
+		w.Write([]byte(`{"dbUser":"IAMR:admin","dbPassword":"secret","expiration":1.7E9}`))
+	}))
+	defer server.Close()
+
+	output, err := serverlessGetCredentials(context.Background(), testAwsConfig(), server.URL, serverlessGetCredentialsInput{
+		WorkgroupName:   "my-workgroup",
+		DbName:          "dev",
+		DurationSeconds: 900,
+	})
+	if err != nil {
+		t.Fatalf("serverlessGetCredentials() error = %v", err)
+	}
+	if output.DbUser != "IAMR:admin" || output.DbPassword != "secret" {
+		t.Errorf("Unexpected credentials %+v", output)
+	}
+}
+
+func TestServerlessGetCredentialsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Workgroup not found"}`))
+	}))
+	defer server.Close()
+
+	_, err := serverlessGetCredentials(context.Background(), testAwsConfig(), server.URL, serverlessGetCredentialsInput{
+		WorkgroupName: "missing",
+	})
+	expected := "GetCredentials failed: ResourceNotFoundException: Workgroup not found"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q but got %v", expected, err)
+	}
+}
+
+func TestAwsJsonApiEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"eu-central-1": "https://redshift-serverless.eu-central-1.amazonaws.com/",
+		"cn-north-1":   "https://redshift-serverless.cn-north-1.amazonaws.com.cn/",
+	}
+	for region, expected := range tests {
+		if got := serverlessApi.endpoint(region); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+}
+
+func testAwsConfig() aws.Config {
+	return aws.Config{
+		Region:      "eu-central-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+}
@@ -0,0 +1,535 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestServerlessCheckRunsProbeOnce(t *testing.T) {
+	check := getServerlessCheck("host=serverless-check-once")
+	if other := getServerlessCheck("host=serverless-check-once"); other != check {
+		t.Fatalf("Expected the check to be shared for the same connection string")
+	}
+
+	var probes int32
+	probe := func() (bool, error) {
+		atomic.AddInt32(&probes, 1)
+		return true, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			isServerless, err := check.get(probe)
+			if err != nil || !isServerless {
+				t.Errorf("Expected serverless without error but got %t, %v", isServerless, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if probes != 1 {
+		t.Errorf("Expected the probe to run once but it ran %d times", probes)
+	}
+}
+
+func TestIsServerlessSharedAcrossClients(t *testing.T) {
+	config := NewConfig(proxyDriverName, "host=serverless-check-clients", "db", 1)
+	if _, err := getServerlessCheck(config.ConnStr).get(func() (bool, error) { return true, nil }); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// every resource operates on a copy of the config, the cached result must be used
+	// without probing, which would fail on the missing connection
+	for i := 0; i < 10; i++ {
+		client := config.NewClient()
+		isServerless, err := client.config.IsServerless(nil)
+		if err != nil || !isServerless {
+			t.Errorf("Expected the cached result but got %t, %v", isServerless, err)
+		}
+	}
+}
+
+func TestServerlessCheckDoesNotCacheErrors(t *testing.T) {
+	check := getServerlessCheck("host=serverless-check-errors")
+
+	if _, err := check.get(func() (bool, error) { return false, errors.New("connection reset") }); err == nil {
+		t.Fatalf("Expected the probe error to be returned")
+	}
+
+	isServerless, err := check.get(func() (bool, error) { return true, nil })
+	if err != nil || !isServerless {
+		t.Errorf("Expected the probe to run again after an error but got %t, %v", isServerless, err)
+	}
+}
+
+func TestParseBoolSetting(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"on":    true,
+		"TRUE":  true,
+		" 1 ":   true,
+		"off":   false,
+		"false": false,
+		"":      false,
+	} {
+		if got := parseBoolSetting(value); got != expected {
+			t.Errorf("Expected %t for %q but got %t", expected, value, got)
+		}
+	}
+}
+
+func TestRetryOnTransientConnectionErrors(t *testing.T) {
+	transientErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	authErr := &pq.Error{Code: "28P01", Message: "password authentication failed"}
+
+	tests := map[string]struct {
+		retries          int
+		errs             []error
+		expectedAttempts int
+		wantErr          bool
+	}{
+		"no error": {
+			retries:          3,
+			expectedAttempts: 1,
+		},
+		"transient error resolved": {
+			retries:          3,
+			errs:             []error{transientErr, transientErr},
+			expectedAttempts: 3,
+		},
+		"retries exhausted": {
+			retries:          2,
+			errs:             []error{transientErr, transientErr, transientErr, transientErr},
+			expectedAttempts: 3,
+			wantErr:          true,
+		},
+		"no retries": {
+			errs:             []error{transientErr},
+			expectedAttempts: 1,
+			wantErr:          true,
+		},
+		"authentication failure": {
+			retries:          3,
+			errs:             []error{authErr},
+			expectedAttempts: 1,
+			wantErr:          true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			err := retryOnTransientConnectionErrors(tt.retries, time.Millisecond, func() error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryOnTransientConnectionErrors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts but got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestIsTransientConnectionError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"connection refused":       {err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, expected: true},
+		"wrapped connection reset": {err: fmt.Errorf("ping: %w", syscall.ECONNRESET), expected: true},
+		"unexpected EOF":           {err: io.ErrUnexpectedEOF, expected: true},
+		"cannot connect now":       {err: &pq.Error{Code: "57P03"}, expected: true},
+		"connection failure":       {err: &pq.Error{Code: "08006"}, expected: true},
+		"invalid password":         {err: &pq.Error{Code: "28P01"}, expected: false},
+		"invalid authorization":    {err: &pq.Error{Code: "28000"}, expected: false},
+		"other error":              {err: errors.New("syntax error"), expected: false},
+		"dial timeout":             {err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}}, expected: true},
+		"host not found":           {err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "redshfit.example.com", IsNotFound: true}}, expected: false},
+		"temporary lookup failure": {err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, expected: true},
+		"address error":            {err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.AddrError{Err: "missing port in address"}}, expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := isTransientConnectionError(tt.err); result != tt.expected {
+				t.Errorf("Expected result to be %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConfigForDatabase(t *testing.T) {
+	configs := map[string]*Config{
+		"pq":                 NewPqConfig("localhost", "dev", "user", "password", 5439, SSLConfig{Mode: "require"}, "UTC", "", 180, 0, 1, net.KeepAliveConfig{}),
+		"data api workgroup": NewDataApiConfig("workgroup", "dev", "eu-central-1", 1, 300, 1),
+		"data api cluster":   NewDataApiClusterConfig("cluster", "user", "dev", "eu-central-1", 1, 300, 1),
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			if same, err := config.ForDatabase("dev"); err != nil || same != config {
+				t.Errorf("Expected the configuration itself for its own database but got %v, %v", same, err)
+			}
+
+			other, err := config.ForDatabase("analytics")
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if other.Database != "analytics" || !strings.Contains(other.ConnStr, "/analytics?") {
+				t.Errorf("Expected a connection to database analytics but got %q, %q", other.Database, other.ConnStr)
+			}
+			if config.Database != "dev" || !strings.Contains(config.ConnStr, "/dev?") {
+				t.Errorf("Expected the original configuration to be unchanged but got %q, %q", config.Database, config.ConnStr)
+			}
+		})
+	}
+
+	if _, err := NewConfig(proxyDriverName, "host=for-database", "dev", 1).ForDatabase("analytics"); err == nil {
+		t.Errorf("Expected an error for a configuration which can't connect to other databases")
+	}
+}
+
+func TestIsServerlessWithClusterType(t *testing.T) {
+	for clusterType, expected := range map[string]bool{
+		clusterTypeServerless:  true,
+		clusterTypeProvisioned: false,
+	} {
+		config := NewConfig(proxyDriverName, "host=serverless-check-"+clusterType, "db", 1)
+		config.ClusterType = clusterType
+
+		// the configured type must be used without probing, which would fail on the missing connection
+		isServerless, err := config.NewClient().config.IsServerless(nil)
+		if err != nil || isServerless != expected {
+			t.Errorf("Expected %t for cluster type %s but got %t, %v", expected, clusterType, isServerless, err)
+		}
+		if getServerlessCheck(config.ConnStr).checked {
+			t.Errorf("Expected no probe for cluster type %s", clusterType)
+		}
+	}
+}
+
+func TestParseRedshiftVersion(t *testing.T) {
+	version, err := parseRedshiftVersion("PostgreSQL 8.0.2 on i686-pc-linux-gnu, compiled by GCC gcc (GCC) 3.4.2 20041017 (Red Hat 3.4.2-6.fc3), Redshift 1.0.38551")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := (RedshiftVersion{Major: 1, Minor: 0, Patch: 38551}); version != expected {
+		t.Errorf("Expected %v but got %v", expected, version)
+	}
+	if version.String() != "1.0.38551" {
+		t.Errorf("Expected 1.0.38551 but got %s", version)
+	}
+
+	if _, err := parseRedshiftVersion("PostgreSQL 8.0.2 on i686-pc-linux-gnu"); err == nil {
+		t.Error("Expected an error without Redshift version")
+	}
+}
+
+func TestRedshiftVersionAtLeast(t *testing.T) {
+	version := RedshiftVersion{Major: 1, Minor: 0, Patch: 38551}
+	for other, expected := range map[RedshiftVersion]bool{
+		{Major: 1, Minor: 0, Patch: 38551}: true,
+		{Major: 1, Minor: 0, Patch: 30000}: true,
+		{Major: 1, Minor: 0, Patch: 40000}: false,
+		{Major: 1, Minor: 1, Patch: 0}:     false,
+		{Major: 0, Minor: 9, Patch: 99999}: true,
+	} {
+		if got := version.AtLeast(other); got != expected {
+			t.Errorf("Expected %s at least %s to be %t", version, other, expected)
+		}
+	}
+}
+
+func TestServerVersionIsCached(t *testing.T) {
+	config := NewConfig(proxyDriverName, "host=server-version-cached", "db", 1)
+	cached := getServerVersionCache(config.ConnStr)
+	cached.checked = true
+	cached.version = RedshiftVersion{Major: 1, Minor: 0, Patch: 12345}
+
+	// the cached version must be used without querying, which would fail on the missing connection
+	db := &DBConnection{client: config.NewClient()}
+	version, err := db.ServerVersion()
+	if err != nil || version != cached.version {
+		t.Errorf("Expected the cached version but got %v, %v", version, err)
+	}
+}
+
+func TestUnsupportedSyntaxError(t *testing.T) {
+	config := NewConfig(proxyDriverName, "host=server-version-syntax", "db", 1)
+	cached := getServerVersionCache(config.ConnStr)
+	cached.checked = true
+	cached.version = RedshiftVersion{Major: 1, Minor: 0, Patch: 12345}
+	db := &DBConnection{client: config.NewClient()}
+
+	syntaxErr := &pq.Error{Code: pqErrorCodeSyntaxError, Message: `syntax error at or near "ROLE"`}
+	err := unsupportedSyntaxError(db, "CREATE ROLE", syntaxErr)
+	if !errors.Is(err, syntaxErr) || !strings.Contains(err.Error(), "CREATE ROLE may not be supported by Redshift 1.0.12345") {
+		t.Errorf("Expected the version in the error but got %v", err)
+	}
+
+	otherErr := &pq.Error{Code: pgErrorCodeInsufficientPrivileges, Message: "permission denied"}
+	if err := unsupportedSyntaxError(db, "CREATE ROLE", otherErr); err != otherErr {
+		t.Errorf("Expected other errors to be returned unchanged but got %v", err)
+	}
+}
+
+const blockingDriverName = "redshift-test-blocking"
+
+func init() {
+	sql.Register(blockingDriverName, blockingDriver{})
+}
+
+// blockingDriver runs every statement until its context is done, like a long running DDL statement.
+type blockingDriver struct{}
+
+func (blockingDriver) Open(string) (driver.Conn, error) {
+	return &blockingConn{}, nil
+}
+
+type blockingConn struct{}
+
+var (
+	blockingTxContextLock sync.Mutex
+	blockingTxContext     context.Context
+)
+
+func (c *blockingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *blockingConn) Close() error {
+	return nil
+}
+
+func (c *blockingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions require a context")
+}
+
+func (c *blockingConn) BeginTx(ctx context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	blockingTxContextLock.Lock()
+	defer blockingTxContextLock.Unlock()
+	blockingTxContext = ctx
+	return c, nil
+}
+
+func (c *blockingConn) Commit() error {
+	return nil
+}
+
+func (c *blockingConn) Rollback() error {
+	return nil
+}
+
+func (c *blockingConn) ExecContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *blockingConn) QueryContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestDBConnectionCancelsQueries(t *testing.T) {
+	sqlDB, err := sql.Open(blockingDriverName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	queries := map[string]func(db *DBConnection) error{
+		"exec": func(db *DBConnection) error {
+			_, err := db.Exec("CREATE TABLE t (id INT)")
+			return err
+		},
+		"query": func(db *DBConnection) error {
+			rows, err := db.Query("SELECT 1")
+			if err == nil {
+				rows.Close()
+			}
+			return err
+		},
+		"query row": func(db *DBConnection) error {
+			var result int
+			return db.QueryRow("SELECT 1").Scan(&result)
+		},
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			db := (&DBConnection{DB: sqlDB}).withContext(ctx)
+
+			done := make(chan error, 1)
+			go func() { done <- query(db) }()
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("Expected the query to be cancelled but got %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected the query to be cancelled with the context")
+			}
+		})
+	}
+}
+
+func TestDBConnectionBeginUsesContext(t *testing.T) {
+	sqlDB, err := sql.Open(blockingDriverName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := (&DBConnection{DB: sqlDB}).withContext(ctx).Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	// the driver cancels the statements of the transaction with the context it was started with
+	blockingTxContextLock.Lock()
+	txContext := blockingTxContext
+	blockingTxContextLock.Unlock()
+	cancel()
+	if txContext == nil || !errors.Is(txContext.Err(), context.Canceled) {
+		t.Errorf("Expected the transaction to be started with the context of the connection")
+	}
+}
+
+func TestTransactionStatementsDontWaitForConnections(t *testing.T) {
+	config := NewConfig(recordingDriverName, "host=transaction-single-connection", "db", 1)
+	config.retrievedUsername = "admin"
+	db, err := config.NewClient().Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deferredRollback(tx)
+
+	// the transaction holds the only connection, so the statements must not query the settings
+	done := make(chan error, 1)
+	go func() {
+		normalizeIdentifier(db, "Sales")
+		done <- unsupportedSyntaxError(db, "CREATE ROLE", &pq.Error{Code: pqErrorCodeSyntaxError})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the settings to be read before the transaction started")
+	}
+}
+
+const refusingDriverName = "redshift-test-refusing"
+
+func init() {
+	sql.Register(refusingDriverName, refusingDriver{})
+}
+
+// refusingDriver fails every connection attempt with a transient error.
+type refusingDriver struct{}
+
+func (refusingDriver) Open(string) (driver.Conn, error) {
+	return nil, &pq.Error{Code: "08001", Message: "could not connect to server: Connection refused"}
+}
+
+func TestConnectRetriesWithoutBlockingOtherConnections(t *testing.T) {
+	refused := NewConfig(refusingDriverName, "host=connect-refused", "db", 1)
+	refused.ConnectRetries = 3
+	refused.ConnectRetryInterval = 100 * time.Millisecond
+	refusedDone := make(chan error, 1)
+	go func() {
+		_, err := refused.NewClient().Connect()
+		refusedDone <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// other connections are opened while the refused one is retried
+	config := NewConfig(recordingDriverName, "host=connect-while-retrying", "db", 1)
+	config.retrievedUsername = "admin"
+	db, err := config.NewClient().Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	select {
+	case <-refusedDone:
+		t.Fatal("Expected the connection to be opened while the refused one was retried")
+	default:
+	}
+
+	if err := <-refusedDone; err == nil {
+		t.Fatal("Expected the refused connection to fail")
+	}
+	dbRegistryLock.Lock()
+	defer dbRegistryLock.Unlock()
+	if _, found := dbRegistry[refused.ConnStr]; found {
+		t.Error("Expected the refused connection not to be registered")
+	}
+}
+
+func TestNewConfigRetriesByDefault(t *testing.T) {
+	config := NewConfig(proxyDriverName, "host=new-config-retries", "db", 1)
+	if config.MaxRetries != defaultProviderMaxRetries {
+		t.Errorf("Expected %d retries by default but got %d", defaultProviderMaxRetries, config.MaxRetries)
+	}
+}
+
+func TestReleaseConnections(t *testing.T) {
+	connect := func(connStr, database string) *DBConnection {
+		config := NewConfig(recordingDriverName, connStr, database, 1)
+		config.retrievedUsername = "admin"
+		db, err := config.NewClient().Connect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return db
+	}
+	dropped := connect("host=release-dropped", "tf_acc_dropped")
+	kept := connect("host=release-kept", "tf_acc_kept")
+	defer kept.Close()
+
+	releaseConnections("TF_ACC_DROPPED")
+
+	if err := dropped.Ping(); err == nil {
+		t.Error("Expected the pool of the dropped database to be closed")
+	}
+	if err := kept.Ping(); err != nil {
+		t.Errorf("Expected the pool of the other database to be kept but got %v", err)
+	}
+	dbRegistryLock.Lock()
+	defer dbRegistryLock.Unlock()
+	if _, found := dbRegistry["host=release-dropped"]; found {
+		t.Error("Expected the pool of the dropped database to be removed from the registry")
+	}
+}
@@ -14,3 +14,14 @@ func forceNewIfListSizeChanged(key string) schema.CustomizeDiffFunc {
 func listSizeChanged(_ context.Context, old, new, _ interface{}) bool {
 	return len(old.([]interface{})) != len(new.([]interface{}))
 }
+
+// validatePrivilegesForObjectType checks during the plan that the privileges are supported by the object type,
+// unless one of them is only known during the apply.
+func validatePrivilegesForObjectType(privilegesAttr, objectTypeAttr string, validate func(privileges []string, objectType string) error) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown(privilegesAttr) || !d.NewValueKnown(objectTypeAttr) {
+			return nil
+		}
+		return validate(setToStringList(d.Get(privilegesAttr).(*schema.Set)), d.Get(objectTypeAttr).(string))
+	}
+}
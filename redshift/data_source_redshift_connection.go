@@ -0,0 +1,86 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	connectionVersionAttr     = "version"
+	connectionClusterTypeAttr = "cluster_type"
+	connectionDatabaseAttr    = "database"
+	connectionUserAttr        = "user"
+)
+
+func dataSourceRedshiftConnection() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Tests the connection of the provider, e.g. to check the credentials in CI before running a large plan. Reading the data source fails if the provider can't connect to Redshift.
+`,
+		ReadContext: dataSourceRedshiftConnectionRead,
+		Schema: map[string]*schema.Schema{
+			connectionVersionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version string of Redshift, as returned by `version()`.",
+			},
+			connectionClusterTypeAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the provider is connected to a `serverless` workgroup or a `provisioned` cluster.",
+			},
+			connectionDatabaseAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database the provider is connected to. Same as the `id`.",
+			},
+			connectionUserAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user the provider is connected as.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	db, err := client.Connect()
+	if err != nil {
+		return errorDiagnostics(fmt.Errorf("could not connect to Redshift: %w", err))
+	}
+
+	return errorDiagnostics(readConnection(db.withContext(ctx), d))
+}
+
+func readConnection(db *DBConnection, d *schema.ResourceData) error {
+	var version, database string
+	if err := db.QueryRow("SELECT version(), current_database()").Scan(&version, &database); err != nil {
+		return fmt.Errorf("could not query Redshift: %w", err)
+	}
+
+	username, err := db.client.config.GetUsername(db)
+	if err != nil {
+		return err
+	}
+
+	isServerless, err := db.client.config.IsServerless(db)
+	if err != nil {
+		return err
+	}
+	clusterType := clusterTypeProvisioned
+	if isServerless {
+		clusterType = clusterTypeServerless
+	}
+
+	d.SetId(database)
+	d.Set(connectionVersionAttr, version)
+	d.Set(connectionClusterTypeAttr, clusterType)
+	d.Set(connectionDatabaseAttr, database)
+	d.Set(connectionUserAttr, username)
+	return nil
+}
@@ -0,0 +1,32 @@
+package redshift
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftConnection(t *testing.T) {
+	config := `
+data "redshift_connection" "connection" {
+
+}
+`
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.redshift_connection.connection", connectionVersionAttr, regexp.MustCompile("Redshift")),
+					resource.TestMatchResourceAttr("data.redshift_connection.connection", connectionClusterTypeAttr, regexp.MustCompile("^(serverless|provisioned)$")),
+					resource.TestCheckResourceAttrSet("data.redshift_connection.connection", connectionDatabaseAttr),
+					resource.TestCheckResourceAttrPair("data.redshift_connection.connection", "id", "data.redshift_connection.connection", connectionDatabaseAttr),
+					resource.TestCheckResourceAttrSet("data.redshift_connection.connection", connectionUserAttr),
+				),
+			},
+		},
+	})
+}
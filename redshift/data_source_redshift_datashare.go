@@ -0,0 +1,250 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dataShareShareIdAttr           = "share_id"
+	dataShareShareTypeAttr         = "share_type"
+	dataShareConsumerNamespaceAttr = "consumer_namespace"
+	dataShareConsumerDatabaseAttr  = "consumer_database"
+	dataShareObjectsAttr           = "objects"
+
+	dataShareObjectTypeAttr = "type"
+	dataShareObjectNameAttr = "name"
+)
+
+var dataShareShareTypes = []string{"inbound", "outbound"}
+
+func dataSourceRedshiftDatashare() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Gets a datashare as listed in ` + "`svv_datashares`" + `, either an outbound datashare of this cluster or an inbound datashare
+shared with it by a producer, e.g. to create a database from a datashare which isn't managed by this configuration.
+
+If several datashares have the same name, e.g. inbound datashares of different producers, the datashare must be
+qualified with ` + "`share_type`" + `, ` + "`producer_namespace`" + ` or ` + "`consumer_namespace`" + `.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftDatashareRead),
+		Schema: map[string]*schema.Schema{
+			dataShareNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the datashare.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			dataShareShareTypeAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The type of the datashare, `outbound` for datashares of this cluster and `inbound` for datashares shared with it.",
+				ValidateFunc: validation.StringInSlice(dataShareShareTypes, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			dataShareProducerNamespaceAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The namespace of the producer cluster of the datashare. Set it to choose between inbound datashares of different producers.",
+			},
+			dataShareConsumerNamespaceAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The namespace of a consumer the outbound datashare must be granted to.",
+			},
+			dataShareShareIdAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the datashare, the ID of the `redshift_datashare` resource for outbound datashares.",
+			},
+			dataShareOwnerAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who owns the datashare. Empty for inbound datashares.",
+			},
+			dataSharePublicAccessibleAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the datashare can be shared to clusters that are publicly accessible.",
+			},
+			dataShareProducerAccountAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the producer account of the datashare.",
+			},
+			dataShareCreatedAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date when the datashare was created.",
+			},
+			dataShareManagedByAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The service managing the datashare, e.g. `ADX` for datashares published on AWS Data Exchange.",
+			},
+			dataShareConsumerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database created from the inbound datashare. Empty if there is none.",
+			},
+			dataShareObjectsAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The objects of the datashare as listed in `svv_datashare_objects`, ordered by type and name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dataShareObjectTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the object, e.g. `schema`, `table` or `view`.",
+						},
+						dataShareObjectNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the object, qualified by its schema for tables, views and functions.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) error {
+	shareName := strings.ToLower(d.Get(dataShareNameAttr).(string))
+
+	query, args := buildDatashareDataSourceQuery(
+		shareName,
+		d.Get(dataShareShareTypeAttr).(string),
+		d.Get(dataShareProducerNamespaceAttr).(string),
+		d.Get(dataShareConsumerNamespaceAttr).(string),
+	)
+	log.Printf("[DEBUG] %s, %v\n", query, args)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("could not read datashare %s: %w", shareName, err)
+	}
+	defer rows.Close()
+
+	var matches int
+	var shareId, shareType, owner, producerAccount, producerNamespace, created, managedBy, consumerDatabase string
+	var publicAccessible bool
+	for rows.Next() {
+		matches++
+		if err := rows.Scan(&shareId, &shareType, &owner, &publicAccessible, &producerAccount, &producerNamespace, &created, &managedBy, &consumerDatabase); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	switch {
+	case matches == 0:
+		return fmt.Errorf("datashare %s not found", shareName)
+	case matches > 1:
+		return fmt.Errorf("found %d datashares named %s, set `%s`, `%s` or `%s` to choose one", matches, shareName, dataShareShareTypeAttr, dataShareProducerNamespaceAttr, dataShareConsumerNamespaceAttr)
+	}
+
+	objects, err := readDatashareObjects(db, shareName, shareType, producerNamespace)
+	if err != nil {
+		return err
+	}
+
+	shareType = strings.ToLower(shareType)
+	d.SetId(fmt.Sprintf("%s:%s:%s", shareType, producerNamespace, shareName))
+	d.Set(dataShareNameAttr, shareName)
+	d.Set(dataShareShareTypeAttr, shareType)
+	d.Set(dataShareShareIdAttr, shareId)
+	d.Set(dataShareOwnerAttr, owner)
+	d.Set(dataSharePublicAccessibleAttr, publicAccessible)
+	d.Set(dataShareProducerAccountAttr, producerAccount)
+	d.Set(dataShareProducerNamespaceAttr, producerNamespace)
+	d.Set(dataShareCreatedAttr, created)
+	d.Set(dataShareManagedByAttr, strings.ToUpper(managedBy))
+	d.Set(dataShareConsumerDatabaseAttr, consumerDatabase)
+	d.Set(dataShareObjectsAttr, objects)
+
+	return nil
+}
+
+// buildDatashareDataSourceQuery returns the query of the datashares with the name, restricted by
+// the qualifiers which are set.
+func buildDatashareDataSourceQuery(shareName, shareType, producerNamespace, consumerNamespace string) (string, []interface{}) {
+	query := `
+	SELECT
+		COALESCE(svv_datashares.share_id::varchar, ''),
+		TRIM(svv_datashares.share_type),
+		TRIM(COALESCE(pg_user.usename, '')),
+		COALESCE(svv_datashares.is_publicaccessible, false),
+		TRIM(COALESCE(svv_datashares.producer_account, '')),
+		TRIM(COALESCE(svv_datashares.producer_namespace, '')),
+		COALESCE(REPLACE(TO_CHAR(svv_datashares.createdate, 'YYYY-MM-DD HH24:MI:SS'), ' ', 'T') || 'Z', ''),
+		TRIM(COALESCE(svv_datashares.managed_by, '')),
+		TRIM(COALESCE(svv_datashares.consumer_database, ''))
+	FROM svv_datashares
+	LEFT JOIN pg_user ON svv_datashares.share_owner = pg_user.usesysid
+	WHERE svv_datashares.share_name = $1`
+	args := []interface{}{shareName}
+
+	if shareType != "" {
+		args = append(args, strings.ToUpper(shareType))
+		query += fmt.Sprintf("\n\tAND svv_datashares.share_type = $%d", len(args))
+	}
+	if producerNamespace != "" {
+		args = append(args, producerNamespace)
+		query += fmt.Sprintf("\n\tAND svv_datashares.producer_namespace = $%d", len(args))
+	}
+	if consumerNamespace != "" {
+		args = append(args, consumerNamespace)
+		query += fmt.Sprintf(`
+	AND svv_datashares.share_type = 'OUTBOUND'
+	AND EXISTS (
+		SELECT 1 FROM svv_datashare_consumers
+		WHERE svv_datashare_consumers.share_name = svv_datashares.share_name
+		AND svv_datashare_consumers.consumer_namespace = $%d
+	)`, len(args))
+	}
+
+	return query, args
+}
+
+func readDatashareObjects(db *DBConnection, shareName, shareType, producerNamespace string) ([]map[string]interface{}, error) {
+	query := `
+	SELECT
+		TRIM(object_type),
+		TRIM(object_name)
+	FROM svv_datashare_objects
+	WHERE share_name = $1
+	AND share_type = $2
+	AND COALESCE(producer_namespace, '') = $3
+	ORDER BY object_type, object_name`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s, $3=%s\n", query, shareName, shareType, producerNamespace)
+	rows, err := db.Query(query, shareName, shareType, producerNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the objects of datashare %s: %w", shareName, err)
+	}
+	defer rows.Close()
+
+	objects := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		var objectType, objectName string
+		if err := rows.Scan(&objectType, &objectName); err != nil {
+			return nil, err
+		}
+		objects = append(objects, map[string]interface{}{
+			dataShareObjectTypeAttr: objectType,
+			dataShareObjectNameAttr: objectName,
+		})
+	}
+	return objects, rows.Err()
+}
@@ -0,0 +1,94 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftDatashare_Outbound(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	shareName := generateRandomObjectName("tf_acc_datashare_data_source")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_datashare" "share" {
+  name    = %[1]q
+  schemas = [redshift_schema.schema.name]
+}
+
+data "redshift_datashare" "share" {
+  name       = redshift_datashare.share.name
+  share_type = "OUTBOUND"
+}
+`, shareName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatashareDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.redshift_datashare.share", dataShareShareIdAttr, "redshift_datashare.share", "id"),
+					resource.TestCheckResourceAttr("data.redshift_datashare.share", dataShareNameAttr, shareName),
+					resource.TestCheckResourceAttr("data.redshift_datashare.share", dataShareShareTypeAttr, "outbound"),
+					resource.TestCheckResourceAttrPair("data.redshift_datashare.share", dataShareOwnerAttr, "redshift_datashare.share", dataShareOwnerAttr),
+					resource.TestCheckResourceAttrPair("data.redshift_datashare.share", dataShareProducerNamespaceAttr, "redshift_datashare.share", dataShareProducerNamespaceAttr),
+					resource.TestCheckResourceAttrPair("data.redshift_datashare.share", dataShareCreatedAttr, "redshift_datashare.share", dataShareCreatedAttr),
+					resource.TestCheckResourceAttr("data.redshift_datashare.share", "objects.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_datashare.share", "objects.0.type", "schema"),
+					resource.TestCheckResourceAttr("data.redshift_datashare.share", "objects.0.name", shareName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRedshiftDatashare_NotFound(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_datashare" "share" {
+  name = %q
+}
+`, generateRandomObjectName("tf_acc_datashare_missing")),
+				ExpectError: regexp.MustCompile("datashare .* not found"),
+			},
+		},
+	})
+}
+
+func TestBuildDatashareDataSourceQuery(t *testing.T) {
+	query, args := buildDatashareDataSourceQuery("share", "", "", "")
+	if !reflect.DeepEqual(args, []interface{}{"share"}) || strings.Contains(query, "$2") {
+		t.Errorf("Expected only the name to be used but got %v in %s", args, query)
+	}
+
+	query, args = buildDatashareDataSourceQuery("share", "inbound", "producer-ns", "")
+	if !reflect.DeepEqual(args, []interface{}{"share", "INBOUND", "producer-ns"}) {
+		t.Errorf("Unexpected arguments %v", args)
+	}
+	for _, condition := range []string{"svv_datashares.share_type = $2", "svv_datashares.producer_namespace = $3"} {
+		if !strings.Contains(query, condition) {
+			t.Errorf("Expected condition %q in %s", condition, query)
+		}
+	}
+
+	query, args = buildDatashareDataSourceQuery("share", "", "", "consumer-ns")
+	if !reflect.DeepEqual(args, []interface{}{"share", "consumer-ns"}) || !strings.Contains(query, "svv_datashare_consumers.consumer_namespace = $2") {
+		t.Errorf("Expected the consumer condition but got %v in %s", args, query)
+	}
+}
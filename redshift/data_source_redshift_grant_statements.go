@@ -0,0 +1,28 @@
+package redshift
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const statementsAttr = "statements"
+
+func dataSourceRedshiftGrantStatements() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source renders the SQL statements a ` + "`redshift_grant`" + ` resource with the same arguments executes on creation, without executing them. It can be used to review the privileges before applying them.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftGrantStatementsRead),
+		Schema:      statementsDataSourceSchema(redshiftGrant().Schema),
+	}
+}
+
+func dataSourceRedshiftGrantStatementsRead(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateGrantParameters(d); err != nil {
+		return err
+	}
+
+	d.SetId(generateGrantID(d))
+	d.Set(statementsAttr, grantStatements(d, getDatabaseName(db, d)))
+
+	return nil
+}
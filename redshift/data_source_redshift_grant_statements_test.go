@@ -0,0 +1,81 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceRedshiftGrantStatements_basic(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_data_grant_statements")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_grant_statements" "grant" {
+  group       = %[1]q
+  schema      = "public"
+  object_type = "schema"
+  privileges  = ["usage", "create"]
+}
+`, groupName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_grant_statements.grant", "statements.#", "2"),
+					resource.TestMatchResourceAttr("data.redshift_grant_statements.grant", "statements.0", regexp.MustCompile(fmt.Sprintf(`^REVOKE ALL PRIVILEGES ON SCHEMA "public" FROM GROUP "%s"$`, groupName))),
+					resource.TestMatchResourceAttr("data.redshift_grant_statements.grant", "statements.1", regexp.MustCompile(fmt.Sprintf(`^GRANT .* ON SCHEMA "public" TO GROUP "%s"$`, groupName))),
+				),
+			},
+		},
+	})
+}
+
+func TestGrantStatementsMatchResource(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"table privileges": {
+			grantUserAttr:            "bob",
+			grantSchemaAttr:          "test",
+			grantObjectTypeAttr:      "table",
+			grantObjectsAttr:         []interface{}{"tbl"},
+			grantPrivilegesAttr:      []interface{}{"select", "update"},
+			grantWithGrantOptionAttr: true,
+		},
+		"no privileges": {
+			grantGroupAttr:      "analysts",
+			grantSchemaAttr:     "test",
+			grantObjectTypeAttr: "schema",
+			grantPrivilegesAttr: []interface{}{},
+		},
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, redshiftGrant().Schema, raw)
+			dataSourceData := schema.TestResourceDataRaw(t, dataSourceRedshiftGrantStatements().Schema, raw)
+
+			expected := grantStatements(resourceData, "db")
+			if got := grantStatements(dataSourceData, "db"); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %q but got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestGrantStatements(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "schema",
+		grantPrivilegesAttr: []interface{}{},
+	})
+
+	statements := grantStatements(d, "db")
+	if len(statements) != 1 || statements[0] != createGrantsRevokeQuery(d, "db") {
+		t.Errorf("Expected only the revoke statement but got %q", statements)
+	}
+}
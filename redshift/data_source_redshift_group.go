@@ -1,6 +1,8 @@
 package redshift
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -31,7 +33,7 @@ Groups are collections of users who are all granted whatever privileges are asso
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "List of the user names who belong to the group",
+				Description: "List of the user names who belong to the group, e.g. to feed other resources like `redshift_group_membership`",
 			},
 		},
 	}
@@ -52,19 +54,23 @@ func dataSourceRedshiftGroupRead(db *DBConnection, d *schema.ResourceData) error
 	}
 	defer rows.Close()
 	for rows.Next() {
-		if err = rows.Err(); err != nil {
-			return fmt.Errorf("could not read group members for group name %q: %w", groupName, err)
-		}
 		var userName string
 		if err := rows.Scan(&userName, &groupId); err != nil {
 			return fmt.Errorf("could not read group members for group name %q: %w", groupName, err)
 		}
 		groupUsers = append(groupUsers, userName)
 	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("could not read group members for group name %q: %w", groupName, err)
+	}
 	if len(groupUsers) == 0 {
 		// no users found so the group id could not be fetched, we have to query for the name
 		query = `SELECT grosysid FROM pg_group WHERE groname = $1;`
-		if err := db.QueryRow(query, groupName).Scan(&groupId); err != nil {
+		err := db.QueryRow(query, groupName).Scan(&groupId)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("group %q does not exist", groupName)
+		case err != nil:
 			return err
 		}
 	}
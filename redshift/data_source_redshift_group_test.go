@@ -2,6 +2,7 @@ package redshift
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -29,6 +30,24 @@ func TestAccDataSourceRedshiftGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceRedshiftGroup_notFound(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_data_not_found")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_group" "group" {
+	%[1]s = %[2]q
+}
+`, groupNameAttr, groupName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("group %q does not exist", groupName)),
+			},
+		},
+	})
+}
+
 func testAccDataSourceRedshiftGroupConfigBasic(groupName string, userName string) string {
 	return fmt.Sprintf(`
 resource "redshift_user" "user" {
@@ -1,14 +1,42 @@
 package redshift
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const (
+	namespaceNamespaceAttr     = "namespace"
+	namespaceAdminUsernameAttr = "admin_username"
+)
+
+// The admin user, created together with the cluster or the serverless namespace, always gets this system ID
+const redshiftAdminUserSysID = 100
+
 func dataSourceRedshiftNamespace() *schema.Resource {
 	return &schema.Resource{
-		Description: `Gets the cluster namespace (unique ID) of the Amazon Redshift cluster.`,
+		Description: `
+Gets the cluster namespace (unique ID) of the Amazon Redshift cluster, e.g. to grant datashare usage to the namespace.
+
+Only metadata available through SQL is exposed. The IAM roles attached to the cluster and its default IAM role
+can only be read through the AWS API, e.g. with the ` + "`aws_redshift_cluster`" + ` or ` + "`aws_redshiftserverless_namespace`" + ` data sources of the AWS provider.
+`,
 		ReadContext: ResourceFunc(dataSourceRedshiftNamespaceRead),
-		Schema:      map[string]*schema.Schema{},
+		Schema: map[string]*schema.Schema{
+			namespaceNamespaceAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The namespace (GUID) of the cluster, as returned by `CURRENT_NAMESPACE`. Same as the `id`.",
+			},
+			namespaceAdminUsernameAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the admin user created together with the cluster or the serverless namespace. Empty if the user was dropped.",
+			},
+		},
 	}
 }
 
@@ -17,6 +45,15 @@ func dataSourceRedshiftNamespaceRead(db *DBConnection, d *schema.ResourceData) e
 	if err := db.QueryRow("SELECT CURRENT_NAMESPACE").Scan(&namespace); err != nil {
 		return err
 	}
+
+	var adminUsername string
+	err := db.QueryRow("SELECT usename FROM pg_user WHERE usesysid = $1", redshiftAdminUserSysID).Scan(&adminUsername)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("could not read admin user: %w", err)
+	}
+
 	d.SetId(namespace)
+	d.Set(namespaceNamespaceAttr, namespace)
+	d.Set(namespaceAdminUsernameAttr, adminUsername)
 	return nil
 }
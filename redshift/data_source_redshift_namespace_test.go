@@ -18,7 +18,11 @@ data "redshift_namespace" "namespace" {
 		Steps: []resource.TestStep{
 			{
 				Config: config,
-				Check:  resource.TestMatchResourceAttr("data.redshift_namespace.namespace", "id", uuidRegex),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.redshift_namespace.namespace", "id", uuidRegex),
+					resource.TestCheckResourceAttrPair("data.redshift_namespace.namespace", "id", "data.redshift_namespace.namespace", namespaceNamespaceAttr),
+					resource.TestCheckResourceAttrSet("data.redshift_namespace.namespace", namespaceAdminUsernameAttr),
+				),
 			},
 		},
 	})
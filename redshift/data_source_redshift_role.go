@@ -0,0 +1,65 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	roleIdAttr        = "role_id"
+	roleRoleOwnerAttr = "role_owner"
+)
+
+func dataSourceRedshiftRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Fetches information about a Redshift role. Roles are named collections of privileges that can be granted to users, groups, or other roles.
+		`,
+		ReadContext: ResourceFunc(dataSourceRedshiftRoleRead),
+		Schema: map[string]*schema.Schema{
+			roleNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role. Role names are case-insensitive.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			roleIdAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the role.",
+			},
+			roleRoleOwnerAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the role owner.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	var roleId, roleName, roleOwner string
+
+	name := strings.ToLower(d.Get(roleNameAttr).(string))
+
+	query := `SELECT role_id, role_name, COALESCE(role_owner, '') FROM SVV_ROLES WHERE role_name = $1`
+	err := db.QueryRow(query, name).Scan(&roleId, &roleName, &roleOwner)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("role %q not found", name)
+	case err != nil:
+		return fmt.Errorf("could not read role %q: %w", name, err)
+	}
+
+	d.SetId(roleId)
+	d.Set(roleNameAttr, roleName)
+	d.Set(roleIdAttr, roleId)
+	d.Set(roleRoleOwnerAttr, roleOwner)
+	return nil
+}
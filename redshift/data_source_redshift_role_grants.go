@@ -0,0 +1,144 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	roleGrantsAttr = "grants"
+
+	roleGrantsGranteeTypeAttr = "grantee_type"
+	roleGrantsGranteeNameAttr = "grantee_name"
+	roleGrantsAdminOptionAttr = "admin_option"
+)
+
+func dataSourceRedshiftRoleGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Lists the grants of roles to users and other roles as listed in ` + "`svv_user_grants`" + ` and ` + "`svv_role_grants`" + `, to audit the hierarchy of roles. Grants of roles to groups are not listed, as no system view exposes them.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftRoleGrantsRead),
+		Schema: map[string]*schema.Schema{
+			roleGrantRoleNameAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the granted role to list the grants of. Lists the grants of all roles by default.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			roleGrantsAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The role grants, ordered by role, grantee type and grantee.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						roleGrantRoleNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the granted role.",
+						},
+						roleGrantsGranteeTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the grantee, either `user` or `role`, as used by `redshift_role_grant`.",
+						},
+						roleGrantsGranteeNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the user or role the role is granted to.",
+						},
+						roleGrantsAdminOptionAttr: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the grantee can grant the role to others. Only users can hold the admin option.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftRoleGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	roleName := strings.ToLower(d.Get(roleGrantRoleNameAttr).(string))
+
+	roleGrants, err := readRoleGrants(db, roleName)
+	if err != nil {
+		return err
+	}
+
+	grants := make([]map[string]interface{}, 0, len(roleGrants))
+	for _, grant := range roleGrants {
+		grants = append(grants, map[string]interface{}{
+			roleGrantRoleNameAttr:     grant.roleName,
+			roleGrantsGranteeTypeAttr: grant.granteeType,
+			roleGrantsGranteeNameAttr: grant.granteeName,
+			roleGrantsAdminOptionAttr: grant.adminOption,
+		})
+	}
+
+	if roleName == "" {
+		d.SetId("role_grants")
+	} else {
+		d.SetId(fmt.Sprintf("role_grants:%s", roleName))
+	}
+	d.Set(roleGrantsAttr, grants)
+
+	return nil
+}
+
+// roleGrant is a grant of a role to a user, group or role.
+type roleGrant struct {
+	roleName    string
+	granteeType string
+	granteeName string
+	adminOption bool
+}
+
+// readRoleGrants returns the grants of the role with the given lower case name, or of all roles when
+// the name is empty, ordered by role, grantee type and grantee.
+func readRoleGrants(db *DBConnection, roleName string) ([]roleGrant, error) {
+	// No system view lists the grants of roles to groups, so only users and roles are returned
+	query := `
+	SELECT role_name, grantee_type, grantee_name, admin_option FROM (
+		SELECT
+			TRIM(role_name) AS role_name,
+			'user' AS grantee_type,
+			TRIM(user_name) AS grantee_name,
+			COALESCE(admin_option, false) AS admin_option
+		FROM svv_user_grants
+		UNION ALL
+		SELECT
+			TRIM(granted_role_name),
+			'role',
+			TRIM(role_name),
+			false
+		FROM svv_role_grants
+	) grants
+	WHERE $1 = '' OR LOWER(role_name) = $1
+	ORDER BY role_name, grantee_type, grantee_name`
+	log.Printf("[DEBUG] %s, $1=%s\n", query, roleName)
+	rows, err := db.Query(query, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("could not list role grants: %w", err)
+	}
+	defer rows.Close()
+
+	grants := make([]roleGrant, 0)
+	for rows.Next() {
+		var grant roleGrant
+		if err := rows.Scan(&grant.roleName, &grant.granteeType, &grant.granteeName, &grant.adminOption); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
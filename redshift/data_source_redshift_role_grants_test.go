@@ -0,0 +1,83 @@
+package redshift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftRoleGrants_basic(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_data_role_grants")
+	childRoleName := generateRandomObjectName("tf_acc_data_role_grants")
+	userName := generateRandomObjectName("tf_acc_data_role_grants")
+	groupName := generateRandomObjectName("tf_acc_data_role_grants")
+
+	config := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_role" "child" {
+  name = %[2]q
+}
+
+resource "redshift_user" "user" {
+  name = %[3]q
+}
+
+resource "redshift_group" "group" {
+  name = %[4]q
+}
+
+resource "redshift_role_grant" "role" {
+  role_name     = redshift_role.role.name
+  grant_to_type = "role"
+  grant_to_name = redshift_role.child.name
+}
+
+resource "redshift_role_grant" "user" {
+  role_name         = redshift_role.role.name
+  grant_to_type     = "user"
+  grant_to_name     = redshift_user.user.name
+  with_admin_option = true
+}
+
+resource "redshift_role_grant" "group" {
+  role_name     = redshift_role.role.name
+  grant_to_type = "group"
+  grant_to_name = redshift_group.group.name
+}
+
+data "redshift_role_grants" "grants" {
+  role_name = redshift_role.role.name
+
+  depends_on = [
+    redshift_role_grant.role,
+    redshift_role_grant.user,
+    redshift_role_grant.group,
+  ]
+}
+`, roleName, childRoleName, userName, groupName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The grant to the group is not listed
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.0.role_name", roleName),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.0.grantee_type", "role"),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.0.grantee_name", childRoleName),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.0.admin_option", "false"),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.1.grantee_type", "user"),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.1.grantee_name", userName),
+					resource.TestCheckResourceAttr("data.redshift_role_grants.grants", "grants.1.admin_option", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftRole_basic(t *testing.T) {
+	roleName := generateRandomObjectName("TF_acc_data_basic")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRedshiftRoleConfigBasic(roleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_role.role", roleNameAttr, strings.ToLower(roleName)),
+					resource.TestCheckResourceAttrSet("data.redshift_role.role", roleIdAttr),
+					resource.TestCheckResourceAttrPair("data.redshift_role.role", roleRoleOwnerAttr, "redshift_role.role", roleOwnerAttr),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRedshiftRole_notFound(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_data_missing")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_role" "role" {
+	%[1]s = %[2]q
+}
+`, roleNameAttr, roleName),
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRedshiftRoleConfigBasic(roleName string) string {
+	return fmt.Sprintf(`
+resource "redshift_role" "role" {
+	%[1]s = %[2]q
+}
+
+data "redshift_role" "role" {
+	%[1]s = redshift_role.role.%[1]s
+}
+`, roleNameAttr, roleName)
+}
@@ -0,0 +1,253 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	schemaGrantsAttr = "grants"
+
+	schemaGrantGranteeTypeAttr         = "grantee_type"
+	schemaGrantGranteeAttr             = "grantee"
+	schemaGrantObjectTypeAttr          = "object_type"
+	schemaGrantObjectAttr              = "object"
+	schemaGrantPrivilegesAttr          = "privileges"
+	schemaGrantGrantablePrivilegesAttr = "grantable_privileges"
+	schemaGrantGrantorAttr             = "grantor"
+	schemaGrantImportIDAttr            = "import_id"
+)
+
+func dataSourceRedshiftSchemaGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Lists the privileges granted on a schema and on the tables, views, functions and procedures in it, one entry per grantee and object. The entries carry the ID to import them as ` + "`redshift_grant`" + ` resources, e.g. to drive ` + "`import`" + ` blocks when adopting an existing cluster. The privileges the owners hold on their own objects are not listed.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftSchemaGrantsRead),
+		Schema: map[string]*schema.Schema{
+			schemaNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schema.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			schemaGrantsAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The grants, ordered by object type, object, grantee type and grantee.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						schemaGrantGranteeTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the grantee, one of `user`, `group` or `role`. Privileges granted to PUBLIC are listed for the group `public`.",
+						},
+						schemaGrantGranteeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the user, group or role.",
+						},
+						schemaGrantObjectTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The object type as used by `redshift_grant`, one of `schema`, `table`, `function` or `procedure`. Views are listed as `table`.",
+						},
+						schemaGrantObjectAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the object, including the argument types of functions and procedures. Empty for the schema itself.",
+						},
+						schemaGrantPrivilegesAttr: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The sorted privileges granted on the object.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						schemaGrantGrantablePrivilegesAttr: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The sorted privileges the grantee can grant to others.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						schemaGrantGrantorAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user who granted the privileges.",
+						},
+						schemaGrantImportIDAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the grant as a `redshift_grant` resource. Empty for roles, whose grants can't be imported.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type schemaGrant struct {
+	granteeType         string
+	grantee             string
+	objectType          string
+	object              string
+	privileges          []string
+	grantablePrivileges []string
+	grantor             string
+}
+
+func dataSourceRedshiftSchemaGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(schemaNameAttr).(string)
+
+	var schemaOID string
+	if err := db.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", schemaName).Scan(&schemaOID); err != nil {
+		return fmt.Errorf("could not read schema %s: %w", schemaName, err)
+	}
+
+	// Every row is an object with its type, name, owner and access control list
+	query := `
+	SELECT 'schema', '', TRIM(COALESCE(pg_user_info.usename, '')), COALESCE(array_to_string(nsp.nspacl, '|'), '')
+	FROM pg_namespace nsp
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = nsp.nspowner
+	WHERE nsp.oid = $1
+	UNION ALL
+	SELECT 'table', cl.relname, TRIM(COALESCE(pg_user_info.usename, '')), COALESCE(array_to_string(cl.relacl, '|'), '')
+	FROM pg_class cl
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = cl.relowner
+	WHERE cl.relnamespace = $1 AND cl.relkind = ANY($2)
+	UNION ALL
+	SELECT
+		CASE WHEN pr.prokind = ANY($3) THEN 'procedure' ELSE 'function' END,
+		pr.proname || '(' || oidvectortypes(pr.proargtypes) || ')',
+		TRIM(COALESCE(pg_user_info.usename, '')),
+		COALESCE(array_to_string(pr.proacl, '|'), '')
+	FROM pg_proc_info pr
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = pr.proowner
+	WHERE pr.pronamespace = $1 AND pr.prokind = ANY($4)`
+	queryArgs := []interface{}{
+		schemaOID,
+		pq.Array(grantObjectTypesCodes["table"]),
+		pq.Array(grantObjectTypesCodes["procedure"]),
+		pq.Array([]string{grantObjectTypesCodes["function"][0], grantObjectTypesCodes["procedure"][0]}),
+	}
+	log.Printf("[DEBUG] %s, $1=%s\n", query, schemaOID)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("could not read grants of schema %s: %w", schemaName, err)
+	}
+	defer rows.Close()
+
+	var grants []schemaGrant
+	for rows.Next() {
+		var objectType, object, owner, rawAcl string
+		if err := rows.Scan(&objectType, &object, &owner, &rawAcl); err != nil {
+			return err
+		}
+		objectGrants, err := aclSchemaGrants(objectType, object, owner, rawAcl)
+		if err != nil {
+			return fmt.Errorf("could not parse the privileges of %s %s: %w", objectType, object, err)
+		}
+		grants = append(grants, objectGrants...)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	sortSchemaGrants(grants)
+
+	grantList := make([]map[string]interface{}, 0, len(grants))
+	for _, grant := range grants {
+		grantList = append(grantList, map[string]interface{}{
+			schemaGrantGranteeTypeAttr:         grant.granteeType,
+			schemaGrantGranteeAttr:             grant.grantee,
+			schemaGrantObjectTypeAttr:          grant.objectType,
+			schemaGrantObjectAttr:              grant.object,
+			schemaGrantPrivilegesAttr:          grant.privileges,
+			schemaGrantGrantablePrivilegesAttr: grant.grantablePrivileges,
+			schemaGrantGrantorAttr:             grant.grantor,
+			schemaGrantImportIDAttr:            schemaGrantImportID(schemaName, grant),
+		})
+	}
+
+	d.SetId(schemaOID)
+	d.Set(schemaNameAttr, schemaName)
+	d.Set(schemaGrantsAttr, grantList)
+
+	return nil
+}
+
+// aclSchemaGrants converts the access control list of an object into grants,
+// skipping the privileges the owner holds on the object.
+func aclSchemaGrants(objectType, object, owner, rawAcl string) ([]schemaGrant, error) {
+	entries, err := parseAcl(rawAcl)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []schemaGrant
+	for _, entry := range entries {
+		granteeType, grantee := grantGroupAttr, grantToPublicName
+		switch {
+		case entry.Grantee == "":
+			// Granted to PUBLIC
+		case strings.HasPrefix(entry.Grantee, "group "):
+			grantee = strings.TrimPrefix(entry.Grantee, "group ")
+		case strings.HasPrefix(entry.Grantee, "role "):
+			granteeType, grantee = grantRoleAttr, strings.TrimPrefix(entry.Grantee, "role ")
+		default:
+			granteeType, grantee = grantUserAttr, entry.Grantee
+			if grantee == owner {
+				continue
+			}
+		}
+
+		privileges, grantablePrivileges := aclEntryPrivileges(entry.Privileges)
+		if len(privileges) == 0 {
+			continue
+		}
+		grants = append(grants, schemaGrant{
+			granteeType:         granteeType,
+			grantee:             grantee,
+			objectType:          objectType,
+			object:              object,
+			privileges:          privileges,
+			grantablePrivileges: grantablePrivileges,
+			grantor:             entry.Grantor,
+		})
+	}
+	return grants, nil
+}
+
+func sortSchemaGrants(grants []schemaGrant) {
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.objectType != b.objectType {
+			return a.objectType < b.objectType
+		}
+		if a.object != b.object {
+			return a.object < b.object
+		}
+		if a.granteeType != b.granteeType {
+			return a.granteeType < b.granteeType
+		}
+		return a.grantee < b.grantee
+	})
+}
+
+// schemaGrantImportID returns the import ID of the grant in the format parsed by parseGrantImportID.
+func schemaGrantImportID(schemaName string, grant schemaGrant) string {
+	if grant.granteeType == grantRoleAttr {
+		return ""
+	}
+	id := fmt.Sprintf("%s:%s:%s:%s", grant.granteeType, grant.grantee, grant.objectType, schemaName)
+	if grant.object != "" {
+		id = fmt.Sprintf("%s:%s", id, grant.object)
+	}
+	return id
+}
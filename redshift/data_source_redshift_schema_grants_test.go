@@ -0,0 +1,230 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceRedshiftSchemaGrants_basic(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_data_schema_grants")
+	groupName := generateRandomObjectName("tf_acc_data_schema_grants")
+	schemaName := generateRandomObjectName("tf_acc_data_schema_grants")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[3]q
+}
+
+resource "redshift_table" "events" {
+  schema = redshift_schema.schema.name
+  name   = "events"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_view" "recent_events" {
+  schema = redshift_schema.schema.name
+  name   = "recent_events"
+  query  = "SELECT id FROM ${redshift_schema.schema.name}.${redshift_table.events.name}"
+}
+
+resource "redshift_function" "f_add" {
+  schema     = redshift_schema.schema.name
+  name       = "f_add"
+  returns    = "int"
+  volatility = "immutable"
+  body       = "SELECT $1 + $2"
+
+  argument {
+    type = "int"
+  }
+
+  argument {
+    type = "int"
+  }
+}
+
+resource "redshift_grant" "schema" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+
+resource "redshift_grant" "events" {
+  user                 = redshift_user.user.name
+  schema               = redshift_schema.schema.name
+  object_type          = "table"
+  objects              = [redshift_table.events.name]
+  privileges           = ["select", "insert"]
+  grantable_privileges = ["select"]
+}
+
+resource "redshift_grant" "recent_events" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_view.recent_events.name]
+  privileges  = ["select"]
+}
+
+resource "redshift_grant" "f_add" {
+  group       = "public"
+  schema      = redshift_schema.schema.name
+  object_type = "function"
+  objects     = ["f_add(integer, integer)"]
+  privileges  = ["execute"]
+
+  depends_on = [redshift_function.f_add]
+}
+`, userName, groupName, schemaName)
+
+	dataSourceConfig := config + `
+data "redshift_schema_grants" "grants" {
+  name = redshift_schema.schema.name
+
+  depends_on = [
+    redshift_grant.schema,
+    redshift_grant.events,
+    redshift_grant.recent_events,
+    redshift_grant.f_add,
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config: dataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.#", "4"),
+
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.object_type", "function"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.object", "f_add(integer, integer)"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.grantee_type", "group"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.grantee", "public"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.0.privileges.0", "execute"),
+
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.1.object_type", "schema"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.1.grantee", groupName),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.1.privileges.0", "usage"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.1.import_id", fmt.Sprintf("group:%s:schema:%s", groupName, schemaName)),
+
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.object", "events"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.grantee_type", "user"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.grantee", userName),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.privileges.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.privileges.0", "insert"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.privileges.1", "select"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.grantable_privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.grantable_privileges.0", "select"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.2.import_id", fmt.Sprintf("user:%s:table:%s:events", userName, schemaName)),
+
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.3.object", "recent_events"),
+					resource.TestCheckResourceAttr("data.redshift_schema_grants.grants", "grants.3.grantee", groupName),
+				),
+			},
+			{
+				ResourceName:      "redshift_grant.recent_events",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("group:%s:table:%s:recent_events", groupName, schemaName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAclSchemaGrants(t *testing.T) {
+	rawAcl := "admin=arwdRxtD/admin|john=r*a/admin|group analysts=r/admin|=r/admin|role auditors=r/admin"
+	expected := []schemaGrant{
+		{granteeType: grantUserAttr, grantee: "john", objectType: "table", object: "events", privileges: []string{"insert", "select"}, grantablePrivileges: []string{"select"}, grantor: "admin"},
+		{granteeType: grantGroupAttr, grantee: "analysts", objectType: "table", object: "events", privileges: []string{"select"}, grantablePrivileges: []string{}, grantor: "admin"},
+		{granteeType: grantGroupAttr, grantee: grantToPublicName, objectType: "table", object: "events", privileges: []string{"select"}, grantablePrivileges: []string{}, grantor: "admin"},
+		{granteeType: grantRoleAttr, grantee: "auditors", objectType: "table", object: "events", privileges: []string{"select"}, grantablePrivileges: []string{}, grantor: "admin"},
+	}
+
+	got, err := aclSchemaGrants("table", "events", "admin", rawAcl)
+	if err != nil {
+		t.Fatalf("aclSchemaGrants() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, got)
+	}
+
+	if _, err := aclSchemaGrants("table", "events", "admin", "invalid"); err == nil {
+		t.Error("Expected an error for an invalid ACL")
+	}
+}
+
+func TestSortSchemaGrants(t *testing.T) {
+	grants := []schemaGrant{
+		{granteeType: grantUserAttr, grantee: "john", objectType: "table", object: "orders"},
+		{granteeType: grantGroupAttr, grantee: "public", objectType: "table", object: "events"},
+		{granteeType: grantGroupAttr, grantee: "analysts", objectType: "schema"},
+		{granteeType: grantUserAttr, grantee: "anna", objectType: "table", object: "events"},
+		{granteeType: grantGroupAttr, grantee: "analysts", objectType: "table", object: "events"},
+	}
+	sortSchemaGrants(grants)
+
+	var got []string
+	for _, grant := range grants {
+		got = append(got, schemaGrantImportID("sales", grant))
+	}
+	expected := []string{
+		"group:analysts:schema:sales",
+		"group:analysts:table:sales:events",
+		"group:public:table:sales:events",
+		"user:anna:table:sales:events",
+		"user:john:table:sales:orders",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v but got %v", expected, got)
+	}
+}
+
+func TestSchemaGrantImportIDIsParsable(t *testing.T) {
+	grants := []schemaGrant{
+		{granteeType: grantGroupAttr, grantee: "analysts", objectType: "schema"},
+		{granteeType: grantUserAttr, grantee: "john", objectType: "function", object: "f_add(integer, integer)"},
+		{granteeType: grantGroupAttr, grantee: grantToPublicName, objectType: "procedure", object: "sp_refresh()"},
+	}
+	for _, grant := range grants {
+		id := schemaGrantImportID("sales", grant)
+		parsed, err := parseGrantImportID(id)
+		if err != nil {
+			t.Fatalf("parseGrantImportID(%q) error = %v", id, err)
+		}
+		if parsed.grantee != grant.grantee || parsed.objectType != grant.objectType || parsed.schema != "sales" {
+			t.Errorf("Unexpected %+v parsed from %q", parsed, id)
+		}
+		if grant.object != "" && !reflect.DeepEqual(parsed.objects, []string{grant.object}) {
+			t.Errorf("Expected objects [%s] but got %v", grant.object, parsed.objects)
+		}
+	}
+
+	if id := schemaGrantImportID("sales", schemaGrant{granteeType: grantRoleAttr, grantee: "auditors", objectType: "schema"}); id != "" {
+		t.Errorf("Expected no import ID for roles but got %q", id)
+	}
+}
@@ -0,0 +1,114 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	schemasDatabaseAttr = "database"
+	schemasPatternAttr  = "pattern"
+	schemasAttr         = "schemas"
+
+	schemasNameAttr  = "name"
+	schemasOwnerAttr = "owner"
+	schemasTypeAttr  = "type"
+)
+
+func dataSourceRedshiftSchemas() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Lists the schemas of a database, e.g. to generate grants for every schema. The system schemas, like ` + "`pg_catalog`" + ` and ` + "`information_schema`" + `, are not listed.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftSchemasRead),
+		Schema: map[string]*schema.Schema{
+			schemasDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the database to list the schemas of. Defaults to the database the provider is connected to.",
+			},
+			schemasPatternAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "%",
+				Description: "A `LIKE` pattern the schema names must match, e.g. `sales_%`. Lists all schemas by default.",
+			},
+			schemasAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The schemas, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						schemasNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the schema.",
+						},
+						schemasOwnerAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the schema owner.",
+						},
+						schemasTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the schema as listed in `svv_all_schemas`, e.g. `local`, `external` or `shared`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftSchemasRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get(schemasDatabaseAttr).(string)
+	if database == "" {
+		database = db.client.config.Database
+	}
+	pattern := d.Get(schemasPatternAttr).(string)
+
+	query := `
+	SELECT
+		TRIM(svv_all_schemas.schema_name),
+		TRIM(COALESCE(pg_user_info.usename, '')),
+		TRIM(svv_all_schemas.schema_type)
+	FROM svv_all_schemas
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = svv_all_schemas.schema_owner
+	WHERE svv_all_schemas.database_name = $1
+	AND svv_all_schemas.schema_name LIKE $2
+	AND LEFT(svv_all_schemas.schema_name, 3) <> 'pg_'
+	AND svv_all_schemas.schema_name NOT IN ('information_schema', 'catalog_history')
+	ORDER BY svv_all_schemas.schema_name`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, database, pattern)
+	rows, err := db.Query(query, database, pattern)
+	if err != nil {
+		return fmt.Errorf("could not list the schemas of database %s: %w", database, err)
+	}
+	defer rows.Close()
+
+	schemas := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		var name, owner, schemaType string
+		if err := rows.Scan(&name, &owner, &schemaType); err != nil {
+			return err
+		}
+		schemas = append(schemas, map[string]interface{}{
+			schemasNameAttr:  name,
+			schemasOwnerAttr: owner,
+			schemasTypeAttr:  schemaType,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", database, pattern))
+	d.Set(schemasDatabaseAttr, database)
+	d.Set(schemasAttr, schemas)
+
+	return nil
+}
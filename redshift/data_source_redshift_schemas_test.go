@@ -0,0 +1,66 @@
+package redshift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftSchemas_basic(t *testing.T) {
+	prefix := generateRandomObjectName("tf_acc_data_schemas")
+	userName := generateRandomObjectName("tf_acc_data_schemas")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "sales" {
+  name  = "%[1]s_sales"
+  owner = redshift_user.user.name
+}
+
+resource "redshift_schema" "marketing" {
+  name = "%[1]s_marketing"
+}
+
+data "redshift_schemas" "schemas" {
+  pattern = "%[1]s%%"
+
+  depends_on = [
+    redshift_schema.sales,
+    redshift_schema.marketing,
+  ]
+}
+
+data "redshift_schemas" "all" {
+  depends_on = [
+    redshift_schema.sales,
+    redshift_schema.marketing,
+  ]
+}
+`, prefix, userName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.redshift_schemas.schemas", schemasDatabaseAttr),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.0.name", fmt.Sprintf("%s_marketing", prefix)),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.0.type", "local"),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.1.name", fmt.Sprintf("%s_sales", prefix)),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.1.owner", userName),
+					resource.TestCheckResourceAttr("data.redshift_schemas.schemas", "schemas.1.type", "local"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.redshift_schemas.all", "schemas.*", map[string]string{
+						"name": "public",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package redshift
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	tablesSchemaAttr   = "schema"
+	tablesDatabaseAttr = "database"
+	tablesFilterAttr   = "filter"
+	tablesAttr         = "tables"
+
+	tablesNameAttr  = "name"
+	tablesTypeAttr  = "type"
+	tablesOwnerAttr = "owner"
+)
+
+func dataSourceRedshiftTables() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Lists the tables and views of a schema as listed in ` + "`svv_all_tables`" + `, e.g. to generate ` + "`redshift_grant`" + ` resources without hardcoding table lists that drift as the schema evolves.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftTablesRead),
+		Schema: map[string]*schema.Schema{
+			tablesSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schema.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tablesDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the database of the schema. Defaults to the database the provider is connected to.",
+			},
+			tablesFilterAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A regular expression the table names must match, e.g. `^fact_`. Lists all tables by default.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			tablesAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tables and views, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tablesNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the table.",
+						},
+						tablesTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the table, one of `table`, `view` or `external`.",
+						},
+						tablesOwnerAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the table owner. Empty for external tables and tables of other databases.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftTablesRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(tablesSchemaAttr).(string)
+	database := d.Get(tablesDatabaseAttr).(string)
+	if database == "" {
+		database = db.client.config.Database
+	}
+	filter, err := regexp.Compile(d.Get(tablesFilterAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	// The owners are only known for the tables of the connected database
+	query := `
+	SELECT
+		TRIM(svv_all_tables.table_name),
+		TRIM(svv_all_tables.table_type),
+		TRIM(COALESCE(pg_user_info.usename, ''))
+	FROM svv_all_tables
+	LEFT JOIN pg_namespace ON (svv_all_tables.database_name = current_database() AND pg_namespace.nspname = svv_all_tables.schema_name)
+	LEFT JOIN pg_class ON (pg_class.relnamespace = pg_namespace.oid AND pg_class.relname = svv_all_tables.table_name)
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = pg_class.relowner
+	WHERE svv_all_tables.database_name = $1
+	AND svv_all_tables.schema_name = $2
+	ORDER BY svv_all_tables.table_name`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, database, schemaName)
+	rows, err := db.Query(query, database, schemaName)
+	if err != nil {
+		return fmt.Errorf("could not list the tables of schema %s.%s: %w", database, schemaName, err)
+	}
+	defer rows.Close()
+
+	tables := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		var name, tableType, owner string
+		if err := rows.Scan(&name, &tableType, &owner); err != nil {
+			return err
+		}
+		if !filter.MatchString(name) {
+			continue
+		}
+		tables = append(tables, map[string]interface{}{
+			tablesNameAttr:  name,
+			tablesTypeAttr:  svvAllTablesType(tableType),
+			tablesOwnerAttr: owner,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", database, schemaName))
+	d.Set(tablesDatabaseAttr, database)
+	d.Set(tablesAttr, tables)
+
+	return nil
+}
+
+// svvAllTablesType maps the table types of `svv_all_tables`, e.g. `TABLE` or `EXTERNAL TABLE`,
+// to the type attribute of the tables.
+func svvAllTablesType(tableType string) string {
+	tableType = strings.ToLower(strings.TrimSpace(tableType))
+	if strings.HasPrefix(tableType, "external") {
+		return "external"
+	}
+	return tableType
+}
@@ -0,0 +1,104 @@
+package redshift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftTables_basic(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_data_tables")
+
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "fact_sales" {
+  schema = redshift_schema.schema.name
+  name   = "fact_sales"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_table" "dim_customer" {
+  schema = redshift_schema.schema.name
+  name   = "dim_customer"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_view" "fact_recent_sales" {
+  schema = redshift_schema.schema.name
+  name   = "fact_recent_sales"
+  query  = "SELECT id FROM ${redshift_schema.schema.name}.${redshift_table.fact_sales.name}"
+}
+
+data "redshift_tables" "all" {
+  schema = redshift_schema.schema.name
+
+  depends_on = [
+    redshift_table.fact_sales,
+    redshift_table.dim_customer,
+    redshift_view.fact_recent_sales,
+  ]
+}
+
+data "redshift_tables" "facts" {
+  schema = redshift_schema.schema.name
+  filter = "^fact_"
+
+  depends_on = [
+    redshift_table.fact_sales,
+    redshift_table.dim_customer,
+    redshift_view.fact_recent_sales,
+  ]
+}
+`, schemaName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.redshift_tables.all", tablesDatabaseAttr),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.#", "3"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.0.name", "dim_customer"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.0.type", "table"),
+					resource.TestCheckResourceAttrSet("data.redshift_tables.all", "tables.0.owner"),
+
+					resource.TestCheckResourceAttr("data.redshift_tables.facts", "tables.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_tables.facts", "tables.0.name", "fact_recent_sales"),
+					resource.TestCheckResourceAttr("data.redshift_tables.facts", "tables.0.type", "view"),
+					resource.TestCheckResourceAttr("data.redshift_tables.facts", "tables.1.name", "fact_sales"),
+					resource.TestCheckResourceAttr("data.redshift_tables.facts", "tables.1.type", "table"),
+				),
+			},
+		},
+	})
+}
+
+func TestSvvAllTablesType(t *testing.T) {
+	tests := map[string]string{
+		"TABLE":          "table",
+		"VIEW":           "view",
+		"EXTERNAL TABLE": "external",
+	}
+
+	for tableType, expected := range tests {
+		t.Run(tableType, func(t *testing.T) {
+			if got := svvAllTablesType(tableType); got != expected {
+				t.Errorf("Expected %q but got %q", expected, got)
+			}
+		})
+	}
+}
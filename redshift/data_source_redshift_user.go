@@ -96,6 +96,11 @@ func dataSourceRedshiftUserRead(db *DBConnection, d *schema.ResourceData) error
 		return err
 	}
 
+	userValidUntil, err = validateAndAdjustValidUntil(userValidUntil)
+	if err != nil {
+		return err
+	}
+
 	userConnLimitNumber := -1
 	if userConnLimit != "UNLIMITED" {
 		if userConnLimitNumber, err = strconv.Atoi(userConnLimit); err != nil {
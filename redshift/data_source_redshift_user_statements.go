@@ -0,0 +1,34 @@
+package redshift
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedshiftUserStatements() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source renders the SQL statements a ` + "`redshift_user`" + ` resource with the same arguments executes on creation, without executing them. The password is redacted.
+`,
+		ReadContext: ResourceFunc(dataSourceRedshiftUserStatementsRead),
+		Schema:      statementsDataSourceSchema(redshiftUser().Schema),
+	}
+}
+
+func dataSourceRedshiftUserStatementsRead(db *DBConnection, d *schema.ResourceData) error {
+	userName := d.Get(userNameAttr).(string)
+	statements := []string{createUserQuery(d, true)}
+	if _, ok := d.GetOk(userPasswordSecretArnAttr); ok {
+		statements = append(statements, userPasswordQuery(userName, redactedValue))
+	}
+	if searchPath := getUserSearchPath(d); len(searchPath) > 0 {
+		statements = append(statements, userSearchPathQuery(userName, searchPath))
+	}
+	if queryGroup, ok := d.GetOk(userQueryGroupAttr); ok {
+		statements = append(statements, userQueryGroupQuery(userName, queryGroup.(string)))
+	}
+
+	d.SetId(userName)
+	d.Set(statementsAttr, statements)
+
+	return nil
+}
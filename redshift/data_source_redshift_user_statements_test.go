@@ -0,0 +1,96 @@
+package redshift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceRedshiftUserStatements_basic(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_data_user_statements")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_user_statements" "user" {
+  name             = %[1]q
+  password         = "Foobarbaz1"
+  connection_limit = 10
+  query_group      = "etl_queue"
+}
+`, userName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_user_statements.user", "statements.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_user_statements.user", "statements.0",
+						fmt.Sprintf(`CREATE USER "%s" WITH PASSWORD '%s' VALID UNTIL 'infinity' SYSLOG ACCESS RESTRICTED CONNECTION LIMIT 10 NOCREATEUSER NOCREATEDB`, userName, redactedValue)),
+					resource.TestCheckResourceAttr("data.redshift_user_statements.user", "statements.1",
+						fmt.Sprintf(`ALTER USER "%s" SET query_group TO 'etl_queue'`, userName)),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateUserQueryRedactsPassword(t *testing.T) {
+	raw := map[string]interface{}{
+		userNameAttr:      "alice",
+		userPasswordAttr:  "Foobarbaz1",
+		userConnLimitAttr: 10,
+	}
+	resourceData := schema.TestResourceDataRaw(t, redshiftUser().Schema, raw)
+	dataSourceData := schema.TestResourceDataRaw(t, dataSourceRedshiftUserStatements().Schema, raw)
+
+	query := createUserQuery(resourceData, false)
+	if !strings.Contains(query, "PASSWORD 'Foobarbaz1'") {
+		t.Fatalf("Expected the password in %q", query)
+	}
+
+	expected := strings.Replace(query, "Foobarbaz1", redactedValue, 1)
+	if got := createUserQuery(dataSourceData, true); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestUserStatementsWithPasswordSecret(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceRedshiftUserStatements().Schema, map[string]interface{}{
+		userNameAttr:              "etl",
+		userPasswordSecretArnAttr: "arn:aws:secretsmanager:eu-central-1:123456789012:secret:redshift/etl-AbCdEf",
+	})
+	if err := dataSourceRedshiftUserStatementsRead(nil, d); err != nil {
+		t.Fatalf("dataSourceRedshiftUserStatementsRead() error = %v", err)
+	}
+
+	statements := d.Get(statementsAttr).([]interface{})
+	expected := fmt.Sprintf(`ALTER USER "etl" PASSWORD '%s'`, redactedValue)
+	if len(statements) != 2 || statements[1] != expected {
+		t.Errorf("Expected the password to be set from the secret with %q but got %v", expected, statements)
+	}
+	if !strings.Contains(statements[0].(string), "PASSWORD DISABLE") {
+		t.Errorf("Expected the user to be created without password but got %q", statements[0])
+	}
+}
+
+func TestUserStatementsWithSessionSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceRedshiftUserStatements().Schema, map[string]interface{}{
+		userNameAttr:       "etl",
+		userSearchPathAttr: []interface{}{"staging", "public"},
+		userQueryGroupAttr: "etl_queue",
+	})
+	if err := dataSourceRedshiftUserStatementsRead(nil, d); err != nil {
+		t.Fatalf("dataSourceRedshiftUserStatementsRead() error = %v", err)
+	}
+
+	statements := d.Get(statementsAttr).([]interface{})
+	expected := []string{
+		userSearchPathQuery("etl", []string{"staging", "public"}),
+		`ALTER USER "etl" SET query_group TO 'etl_queue'`,
+	}
+	if len(statements) != 3 || statements[1] != expected[0] || statements[2] != expected[1] {
+		t.Errorf("Expected the statements to end with %q but got %v", expected, statements)
+	}
+}
@@ -7,33 +7,65 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
 )
 
 const (
+	resourceDatabaseAttr = "database"
+
 	pqErrorCodeConcurrent        = "XX000"
 	pqErrorCodeInvalidSchemaName = "3F000"
 	pqErrorCodeDeadlock          = "40P01"
+	pqErrorCodeSerialization     = "40001"
 	pqErrorCodeFailedTransaction = "25P02"
 	pqErrorCodeDuplicateSchema   = "42P06"
+	pqErrorCodeSyntaxError       = "42601"
+	pqErrorCodeUndefinedObject   = "42704"
+	pqErrorCodeInvalidCatalog    = "3D000"
 
 	pgErrorCodeInsufficientPrivileges = "42501"
 )
 
-// startTransaction starts a new DB transaction using the provided client.
-func startTransaction(client *Client) (*sql.Tx, error) {
-	db, err := client.Connect()
+// defaultRetryableErrorCodes are the codes of errors caused by concurrent operations, which are retried
+// unless the provider configures other ones with retryable_error_codes. Serializable isolation violations
+// are retried in any case.
+var defaultRetryableErrorCodes = []string{
+	pqErrorCodeConcurrent,
+	pqErrorCodeInvalidSchemaName,
+	pqErrorCodeDeadlock,
+	pqErrorCodeFailedTransaction,
+	pqErrorCodeSerialization,
+}
+
+// pqErrorRetryInterval is the time waited before the first retry of a failed operation,
+// it grows linearly with every further retry.
+var pqErrorRetryInterval = time.Second
+
+// startTransaction starts a transaction on the database of the connection, which is cancelled
+// together with the context of the connection.
+func startTransaction(db *DBConnection) (*sql.Tx, error) {
+	conn, err := db.client.Connect()
 	if err != nil {
 		return nil, err
 	}
 
-	txn, err := db.Begin()
+	// The settings cached per connection string are read before the transaction holds a connection of
+	// the pool, reading them from its statements would wait for another one, e.g. with max_connections = 1
+	conn = conn.withContext(db.context())
+	conn.client.config.CaseSensitiveIdentifiers(conn)
+	if _, err := conn.ServerVersion(); err != nil {
+		log.Printf("[WARN] %v", err)
+	}
+
+	txn, err := conn.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("could not start transaction: %w", err)
 	}
@@ -41,6 +73,62 @@ func startTransaction(client *Client) (*sql.Tx, error) {
 	return txn, nil
 }
 
+// startCreateTransaction starts a transaction running the statements creating database objects,
+// like schemas, tables, views and functions. If create_as_role is configured, the session authorization
+// is set to it so that the created objects are owned by it.
+func startCreateTransaction(db *DBConnection) (*sql.Tx, error) {
+	txn, err := startTransaction(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if role := db.client.config.CreateAsRole; role != "" {
+		// SET LOCAL only lasts until the end of the transaction, so the session authorization
+		// is reset by the commit as well as by deferredRollback when a statement fails,
+		// and pooled connections never keep it.
+		query := sessionAuthorizationQuery(role)
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := txn.Exec(query); err != nil {
+			deferredRollback(txn)
+			return nil, fmt.Errorf("could not set session authorization to %q: %w", role, err)
+		}
+	}
+
+	return txn, nil
+}
+
+// sessionAuthorizationQuery returns the statement running the rest of the transaction as the given user,
+// so that the created objects are owned by it.
+func sessionAuthorizationQuery(role string) string {
+	return fmt.Sprintf("SET LOCAL SESSION AUTHORIZATION '%s'", pqQuoteLiteral(role))
+}
+
+// execWithoutTransaction runs the given statements one by one in autocommit mode.
+// Some statements, like VACUUM, can't be run inside a transaction block.
+func execWithoutTransaction(db *DBConnection, queries ...string) error {
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("could not execute %q: %w", query, err)
+		}
+	}
+	return nil
+}
+
+// tableMaintenanceQueries returns the ANALYZE and/or VACUUM statements
+// to run on a table after a structural change, depending on the given flags.
+func tableMaintenanceQueries(schemaName, tableName string, analyze, vacuum bool) []string {
+	var queries []string
+	table := tableIdentifier(schemaName, tableName)
+	if vacuum {
+		queries = append(queries, fmt.Sprintf("VACUUM %s", table))
+	}
+	if analyze {
+		queries = append(queries, fmt.Sprintf("ANALYZE %s", table))
+	}
+	return queries
+}
+
 // deferredRollback can be used to rollback a transaction in a defer.
 // It will log an error if it fails
 func deferredRollback(txn *sql.Tx) {
@@ -64,6 +152,35 @@ func pqQuoteLiteral(in string) string {
 	return in
 }
 
+// normalizeIdentifier returns the identifier as it is stored in the catalog of the database of the connection.
+// Redshift folds identifiers to lower case, quoted ones included, unless enable_case_sensitive_identifier is on.
+func normalizeIdentifier(db *DBConnection, name string) string {
+	if db.client.config.CaseSensitiveIdentifiers(db) {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// suppressIdentifierCaseDiff suppresses diffs between names only differing in case, as the names are stored
+// in lower case unless enable_case_sensitive_identifier is on. The connection isn't available when diffing,
+// so an object can't be renamed by only changing the case of its name.
+func suppressIdentifierCaseDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(oldValue, newValue)
+}
+
+// ResourceNormalizeIdentifiers normalizes the names of users, groups, roles or schemas held by the given attributes
+// before calling fn, so they are looked up in the catalog and stored in the state the way the database stores them.
+func ResourceNormalizeIdentifiers(attrs []string, fn func(*DBConnection, *schema.ResourceData) error) func(*DBConnection, *schema.ResourceData) error {
+	return func(db *DBConnection, d *schema.ResourceData) error {
+		for _, attr := range attrs {
+			if name, ok := d.GetOk(attr); ok {
+				d.Set(attr, normalizeIdentifier(db, name.(string)))
+			}
+		}
+		return fn(db, d)
+	}
+}
+
 func getGroupIDFromName(tx *sql.Tx, group string) (groupID int, err error) {
 	err = tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", group).Scan(&groupID)
 	return
@@ -79,52 +196,288 @@ func getSchemaIDFromName(tx *sql.Tx, schema string) (schemaID int, err error) {
 	return
 }
 
+// privilegeCheckQuery returns the query checking a privilege of a user on an object of the given type.
+// The query takes the user name, the object name and the privilege as parameters.
+func privilegeCheckQuery(objectType string) (string, error) {
+	switch strings.ToLower(objectType) {
+	case "database":
+		return "SELECT has_database_privilege($1, $2, $3)", nil
+	case "schema":
+		return "SELECT has_schema_privilege($1, $2, $3)", nil
+	case "table":
+		return "SELECT has_table_privilege($1, $2, $3)", nil
+	}
+	return "", fmt.Errorf("privilege checks are not supported for object type %q", objectType)
+}
+
+// checkPrivilege verifies that the connected user has the given privilege on an object
+// when privilege checks are enabled in the provider, to fail early with a precise error
+// instead of a raw permission denied error from Redshift.
+func checkPrivilege(db *DBConnection, objectType, objectName, privilege string) error {
+	if !db.client.config.CheckPrivileges {
+		return nil
+	}
+
+	granted, err := db.client.config.HasPrivilege(db, objectType, objectName, privilege)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		username, _ := db.client.config.GetUsername(db)
+		return fmt.Errorf("insufficient privilege for %s on %s %q: user %q is missing the privilege", strings.ToUpper(privilege), strings.ToLower(objectType), objectName, username)
+	}
+	return nil
+}
+
+// principalTypes are the kinds of principals privileges and roles can be granted to.
+var principalTypes = []string{"user", "group", "role"}
+
+var principalCatalogQueries = map[string]string{
+	"user":  "SELECT COUNT(*) FROM pg_user WHERE usename = $1",
+	"group": "SELECT COUNT(*) FROM pg_group WHERE groname = $1",
+	"role":  "SELECT COUNT(*) FROM svv_roles WHERE role_name = $1",
+}
+
+// roleGrantsToGroupsWarning is the warning shown while planning grants of roles to groups.
+const roleGrantsToGroupsWarning = "Redshift doesn't list the grants of roles to groups, so they can't be read back: " +
+	"the grant is kept in the state as long as the role and the group exist, and revoking the role from the group outside of Terraform is not detected"
+
+// warnRoleGrantToGroup is the ValidateFunc of the groups a role is granted to, showing roleGrantsToGroupsWarning.
+func warnRoleGrantToGroup(_ interface{}, key string) ([]string, []error) {
+	return []string{fmt.Sprintf("%s: %s", key, roleGrantsToGroupsWarning)}, nil
+}
+
+// existingPrincipals returns the given principals of the type which exist. It doesn't check
+// whether anything is granted to them.
+func existingPrincipals(db *DBConnection, principalType string, names []string) ([]string, error) {
+	existing := make([]string, 0, len(names))
+	for _, name := range names {
+		var count int
+		if err := db.QueryRow(principalCatalogQueries[principalType], normalizeIdentifier(db, name)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("could not check whether %s %q exists: %w", principalType, name, err)
+		}
+		if count > 0 {
+			existing = append(existing, name)
+		}
+	}
+	return existing, nil
+}
+
+// checkPrincipalType verifies that a principal declared as a user, group or role isn't
+// a principal of another type, e.g. a role which was declared as a group. Principals
+// which don't exist at all are left to Redshift to report.
+func checkPrincipalType(db *DBConnection, principalType, name string) error {
+	found := make(map[string]bool, len(principalTypes))
+	for _, t := range principalTypes {
+		var count int
+		if err := db.QueryRow(principalCatalogQueries[t], name).Scan(&count); err != nil {
+			return fmt.Errorf("could not check whether %q is a %s: %w", name, t, err)
+		}
+		found[t] = count > 0
+	}
+	return principalTypeMismatch(principalType, name, found)
+}
+
+func principalTypeMismatch(principalType, name string, found map[string]bool) error {
+	if found[principalType] {
+		return nil
+	}
+	var actualTypes []string
+	for _, t := range principalTypes {
+		if found[t] {
+			actualTypes = append(actualTypes, t)
+		}
+	}
+	if len(actualTypes) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s %q does not exist, but a %s with that name does: declare it as a %s instead", principalType, name, strings.Join(actualTypes, " and a "), actualTypes[0])
+}
+
 func ResourceFunc(fn func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
-	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 		client := meta.(*Client)
 
 		db, err := client.Connect()
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(err)
+		}
+
+		return errorDiagnostics(fn(db.withContext(ctx), d))
+	}
+}
+
+// ResourceFuncInDatabase is like ResourceFunc, but connects to the database returned by database instead
+// of the database of the provider, unless it is empty.
+func ResourceFuncInDatabase(database func(*schema.ResourceData) string, fn func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := meta.(*Client).ForDatabase(database(d))
+		if err != nil {
+			return errorDiagnostics(err)
+		}
+
+		db, err := client.Connect()
+		if err != nil {
+			return errorDiagnostics(err)
 		}
 
-		return diag.FromErr(fn(db, d))
+		return errorDiagnostics(fn(db.withContext(ctx), d))
+	}
+}
+
+// errorDiagnostics converts the error into diagnostics. The message of the error is kept as summary, the
+// SQLSTATE code, detail and hint of Redshift errors are added as detail as they are often needed to make
+// sense of errors like "permission denied".
+func errorDiagnostics(err error) diag.Diagnostics {
+	diags := diag.FromErr(err)
+
+	var pqErr *pq.Error
+	if len(diags) > 0 && errors.As(err, &pqErr) {
+		diags[0].Detail = pqErrorDetail(pqErr)
+	}
+	return diags
+}
+
+// pqErrorDetail returns the SQLSTATE code, detail and hint of the error, one per line.
+func pqErrorDetail(err *pq.Error) string {
+	code := string(err.Code)
+	if name := err.Code.Name(); name != "" {
+		code = fmt.Sprintf("%s (%s)", code, name)
+	}
+
+	lines := []string{"SQLSTATE: " + code}
+	if err.Detail != "" {
+		lines = append(lines, "Detail: "+err.Detail)
+	}
+	if err.Hint != "" {
+		lines = append(lines, "Hint: "+err.Hint)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resourceDatabase returns the database set in the database attribute of the resource.
+func resourceDatabase(d *schema.ResourceData) string {
+	return d.Get(resourceDatabaseAttr).(string)
+}
+
+// importStateInDatabase imports resources whose ID is prefixed with the database of the object if it isn't
+// the database of the provider, e.g. `analytics:public.events`.
+func importStateInDatabase(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if database, id, found := strings.Cut(d.Id(), ":"); found {
+		if database == "" || id == "" {
+			return nil, fmt.Errorf("invalid ID %q, expected <database>:<id> or <id>", d.Id())
+		}
+		d.Set(resourceDatabaseAttr, database)
+		d.SetId(id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceDatabaseSchema returns the schema of the database attribute of resources managing objects
+// in another database than the one of the provider.
+func resourceDatabaseSchema(objects string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Description: fmt.Sprintf("The name of the database to manage the %s in, the provider connects to it with its own credentials. "+
+			"Defaults to the database the provider is connected to.", objects),
+		ValidateFunc: validation.StringIsNotWhiteSpace,
 	}
 }
 
 func ResourceRetryOnPQErrors(fn func(*DBConnection, *schema.ResourceData) error) func(*DBConnection, *schema.ResourceData) error {
 	return func(db *DBConnection, d *schema.ResourceData) error {
-		for i := 0; i < 10; i++ {
+		for attempt := 0; ; attempt++ {
 			err := fn(db, d)
 			if err == nil {
 				return nil
 			}
 
-			var pqErr *pq.Error
-			if !errors.As(err, &pqErr) || !isRetryablePQError(string(pqErr.Code)) {
+			if !isRetryableError(err, db.client.config.RetryableErrorCodes) || attempt >= db.client.config.MaxRetries || db.context().Err() != nil {
 				return err
 			}
 
-			time.Sleep(time.Duration(i+1) * time.Second)
+			log.Printf("[WARN] retrying after error (attempt %d of %d): %v", attempt+1, db.client.config.MaxRetries, err)
+			time.Sleep(time.Duration(attempt+1) * pqErrorRetryInterval)
 		}
+	}
+}
+
+// waitUntilVisible checks with the query whether a created object is visible before it is read back.
+// Redshift Serverless does not always show an object committed on one connection right away on the
+// other connections of the pool, so the check is repeated there. Provisioned clusters are not checked.
+func waitUntilVisible(db *DBConnection, object string, query string, args ...interface{}) error {
+	isServerless, err := db.client.config.IsServerless(db)
+	if err != nil {
+		return err
+	}
+	if !isServerless {
 		return nil
 	}
+
+	return pollUntilVisible(db.client.config.VisibilityRetries, db.client.config.VisibilityRetryInterval, object, func() (bool, error) {
+		var visible bool
+		err := db.QueryRow(query, args...).Scan(&visible)
+		return visible, err
+	})
+}
+
+// pollUntilVisible calls visible until it returns true, retrying at most retries times with the given interval.
+func pollUntilVisible(retries int, interval time.Duration, object string, visible func() (bool, error)) error {
+	for attempt := 1; ; attempt++ {
+		ok, err := visible()
+		if err != nil {
+			return fmt.Errorf("could not check whether %s is visible: %w", object, err)
+		}
+		if ok {
+			return nil
+		}
+		if attempt > retries {
+			return fmt.Errorf("%s was created but is still not visible after %d retries", object, retries)
+		}
+
+		log.Printf("[DEBUG] %s is not visible yet (attempt %d of %d), retrying in %s\n", object, attempt, retries+1, interval)
+		time.Sleep(interval)
+	}
 }
 
-func isRetryablePQError(code string) bool {
-	retryable := map[string]bool{
-		pqErrorCodeConcurrent:        true,
-		pqErrorCodeInvalidSchemaName: true,
-		pqErrorCodeDeadlock:          true,
-		pqErrorCodeFailedTransaction: true,
+// isRetryableError returns whether the whole operation can be retried after the error. Serializable isolation
+// violations are always retried, Redshift reports most of them as internal errors with the code 1023 in the message.
+func isRetryableError(err error, retryableCodes []string) bool {
+	if isSerializationFailure(err) {
+		return true
 	}
 
-	_, ok := retryable[code]
-	return ok
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && isRetryablePQError(string(pqErr.Code), retryableCodes)
+}
+
+func isSerializationFailure(err error) bool {
+	if isPqErrorWithCode(err, pqErrorCodeSerialization) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "1023: serializable isolation violation")
+}
+
+// isRetryablePQError returns whether the error code is one of the retryable codes, falling back
+// to defaultRetryableErrorCodes if none are given.
+func isRetryablePQError(code string, retryableCodes []string) bool {
+	if len(retryableCodes) == 0 {
+		retryableCodes = defaultRetryableErrorCodes
+	}
+
+	for _, retryable := range retryableCodes {
+		if strings.EqualFold(retryable, code) {
+			return true
+		}
+	}
+	return false
 }
 
 func isPqErrorWithCode(err error, code string) bool {
-	return string(err.(*pq.Error).Code) == code
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == code
 }
 
 func splitCsvAndTrim(raw string) ([]string, error) {
@@ -146,10 +499,91 @@ func splitCsvAndTrim(raw string) ([]string, error) {
 	return result, nil
 }
 
+// allPrivileges is the authoritative expansion of the `ALL` privilege per object type, i.e. the
+// privileges Redshift reports after `GRANT ALL`. On databases `ALL` also includes USAGE, which only
+// exists on databases created from datashares, so it isn't required to reconcile `ALL`.
+var allPrivileges = map[string][]string{
+	"table":     {"select", "insert", "update", "delete", "drop", "references"},
+	"schema":    {"create", "usage"},
+	"database":  {"create", "temporary"},
+	"function":  {"execute"},
+	"procedure": {"execute"},
+	"language":  {"usage"},
+}
+
+// datasharePrivileges are the privileges on datashares granted to users, groups and roles of the producer,
+// Redshift has no `ALL` for datashares.
+var datasharePrivileges = []string{"alter", "share"}
+
+// isAllPrivileges returns true if the privileges consist of `ALL` only.
+func isAllPrivileges(privileges []string) bool {
+	return len(privileges) == 1 && strings.EqualFold(privileges[0], "all")
+}
+
+// expandAllPrivileges replaces `ALL` by the privileges it expands to for the object type.
+func expandAllPrivileges(privileges *schema.Set, objectType string) *schema.Set {
+	if !isAllPrivileges(setToStringList(privileges)) {
+		return privileges
+	}
+	expanded := schema.NewSet(schema.HashString, nil)
+	for _, p := range allPrivileges[strings.ToLower(objectType)] {
+		expanded.Add(p)
+	}
+	return expanded
+}
+
+// reconcileAllPrivileges returns `all` instead of the granted privileges if `all` was configured
+// and every privilege of its expansion is granted, so `ALL` doesn't cause a perpetual diff.
+func reconcileAllPrivileges(configured, granted *schema.Set, objectType string) *schema.Set {
+	if !isAllPrivileges(setToStringList(configured)) || granted.Len() == 0 {
+		return granted
+	}
+	for _, p := range allPrivileges[strings.ToLower(objectType)] {
+		if !granted.Contains(p) {
+			return granted
+		}
+	}
+	return schema.NewSet(schema.HashString, []interface{}{"all"})
+}
+
+// privilegeAliases are the short forms Redshift accepts for privileges, mapped to the name they are read back as.
+var privilegeAliases = map[string]string{
+	"temp": "temporary",
+}
+
+// reconcilePrivilegeAliases returns the granted privileges with the configured alias instead of the privilege
+// it stands for, e.g. `temp` instead of `temporary`, so aliases don't cause a perpetual diff.
+func reconcilePrivilegeAliases(configured, granted *schema.Set) *schema.Set {
+	reconciled := schema.NewSet(schema.HashString, granted.List())
+	for alias, privilege := range privilegeAliases {
+		if configured.Contains(alias) && reconciled.Contains(privilege) {
+			reconciled.Remove(privilege)
+			reconciled.Add(alias)
+		}
+	}
+	return reconciled
+}
+
+// knownPrivileges are the privileges accepted by validatePrivileges for at least one object type.
+var knownPrivileges = []string{"all", "alter", "create", "delete", "drop", "execute", "insert", "references", "rule", "select", "share", "temp", "temporary", "trigger", "update", "usage"}
+
+// validatePrivilegeName validates a single privilege while the configuration is validated, so that typos don't
+// fail the apply. Whether the privilege is supported by the object type is checked by validatePrivilegesForObjectType.
+func validatePrivilegeName(val interface{}, key string) ([]string, []error) {
+	if strings.EqualFold(val.(string), "connect") {
+		return nil, []error{fmt.Errorf("%s: there is no CONNECT privilege on databases in Redshift, every user can connect to every database", key)}
+	}
+	return validation.StringInSlice(knownPrivileges, true)(val, key)
+}
+
 func validatePrivileges(privileges []string, objectType string) bool {
 	if objectType == "language" && len(privileges) == 0 {
 		return false
 	}
+	if isAllPrivileges(privileges) {
+		_, ok := allPrivileges[strings.ToLower(objectType)]
+		return ok
+	}
 	for _, p := range privileges {
 		switch strings.ToUpper(objectType) {
 		case "SCHEMA":
@@ -169,7 +603,7 @@ func validatePrivileges(privileges []string, objectType string) bool {
 		case "DATABASE":
 			switch strings.ToUpper(p) {
 			// USAGE is only available from databases created from datashares
-			case "CREATE", "TEMPORARY", "USAGE":
+			case "CREATE", "TEMP", "TEMPORARY", "USAGE":
 				continue
 			default:
 				return false
@@ -188,6 +622,13 @@ func validatePrivileges(privileges []string, objectType string) bool {
 			default:
 				return false
 			}
+		case "DATASHARE":
+			switch strings.ToUpper(p) {
+			case "ALTER", "SHARE":
+				continue
+			default:
+				return false
+			}
 		default:
 			return false
 		}
@@ -196,12 +637,130 @@ func validatePrivileges(privileges []string, objectType string) bool {
 	return true
 }
 
+// aclEntry represents a single entry of a Redshift access control list,
+// e.g. `group analysts=rw/admin`.
+type aclEntry struct {
+	// Grantee is empty for PUBLIC and prefixed with "group " for groups.
+	Grantee    string
+	Privileges string
+	Grantor    string
+}
+
+// parseAclEntry parses a single ACL entry in the `grantee=privileges/grantor` format.
+func parseAclEntry(raw string) (aclEntry, error) {
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), `"`, "")
+	eqIdx := strings.LastIndex(raw, "=")
+	if eqIdx == -1 {
+		return aclEntry{}, fmt.Errorf("invalid ACL entry %q: missing '='", raw)
+	}
+	privilegesAndGrantor := raw[eqIdx+1:]
+	slashIdx := strings.Index(privilegesAndGrantor, "/")
+	if slashIdx == -1 {
+		return aclEntry{}, fmt.Errorf("invalid ACL entry %q: missing '/'", raw)
+	}
+
+	return aclEntry{
+		Grantee:    raw[:eqIdx],
+		Privileges: privilegesAndGrantor[:slashIdx],
+		Grantor:    privilegesAndGrantor[slashIdx+1:],
+	}, nil
+}
+
+// parseAcl parses an ACL which was serialized using array_to_string(acl, '|').
+func parseAcl(raw string) ([]aclEntry, error) {
+	var entries []aclEntry
+	if raw == "" {
+		return entries, nil
+	}
+	for _, rawEntry := range strings.Split(raw, "|") {
+		entry, err := parseAclEntry(rawEntry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// aclHasGrantOption returns true if every privilege of an ACL entry can be granted further,
+// i.e. each privilege letter is followed by a `*`.
+func aclHasGrantOption(privileges string) bool {
+	if privileges == "" {
+		return false
+	}
+	for i := 0; i < len(privileges); i++ {
+		if privileges[i] == '*' {
+			continue
+		}
+		if i+1 >= len(privileges) || privileges[i+1] != '*' {
+			return false
+		}
+	}
+	return true
+}
+
+// aclPrivilegeCodes maps the privileges to the letters representing them in an ACL.
+var aclPrivilegeCodes = map[string]byte{
+	"select":     'r',
+	"insert":     'a',
+	"update":     'w',
+	"delete":     'd',
+	"drop":       'D',
+	"references": 'x',
+	"rule":       'R',
+	"trigger":    't',
+	"execute":    'X',
+	"usage":      'U',
+	"create":     'C',
+	"temporary":  'T',
+	"temp":       'T',
+}
+
+// aclPrivilegeHasGrantOption returns true if the privilege is part of the privileges of an
+// ACL entry and can be granted further.
+func aclPrivilegeHasGrantOption(privileges, privilege string) bool {
+	code, ok := aclPrivilegeCodes[strings.ToLower(privilege)]
+	if !ok {
+		return false
+	}
+	idx := strings.IndexByte(privileges, code)
+	return idx != -1 && idx+1 < len(privileges) && privileges[idx+1] == '*'
+}
+
+// aclEntryPrivileges returns the sorted names of the privileges of an ACL entry and the subset
+// of them which can be granted further.
+func aclEntryPrivileges(privileges string) ([]string, []string) {
+	granted := []string{}
+	grantable := []string{}
+	for privilege, code := range aclPrivilegeCodes {
+		if strings.IndexByte(privileges, code) == -1 {
+			continue
+		}
+		granted = append(granted, privilege)
+		if aclPrivilegeHasGrantOption(privileges, privilege) {
+			grantable = append(grantable, privilege)
+		}
+	}
+	sort.Strings(granted)
+	sort.Strings(grantable)
+	return granted, grantable
+}
+
 func appendIfTrue(condition bool, item string, list *[]string) {
 	if condition {
 		*list = append(*list, item)
 	}
 }
 
+// setToStringList returns the elements of a set of strings.
+func setToStringList(set *schema.Set) []string {
+	list := make([]string, 0, set.Len())
+	for _, elem := range set.List() {
+		list = append(list, elem.(string))
+	}
+	return list
+}
+
 func setToPgIdentList(identifiers *schema.Set, prefix string) string {
 	quoted := make([]string, identifiers.Len())
 	for i, identifier := range identifiers.List() {
@@ -229,14 +788,102 @@ func setToPgIdentListNotQuoted(identifiers *schema.Set, prefix string) string {
 	return strings.Join(quoted, ",")
 }
 
-func stripArgumentsFromCallablesDefinitions(defs *schema.Set) []string {
-	parser := func(name string) string {
-		return strings.Split(name, "(")[0]
+// callableSignature normalizes the definition of a function or procedure, e.g. `F_Add(int, float)`
+// becomes `f_add(integer,double precision)`, so it can be compared with the signature in the catalog.
+// Definitions without argument types are returned as the lowercase name.
+func callableSignature(def string) string {
+	openIdx := strings.Index(def, "(")
+	if openIdx == -1 {
+		return strings.ToLower(strings.TrimSpace(def))
 	}
+	name := strings.ToLower(strings.TrimSpace(def[:openIdx]))
+	rawTypes := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(def[openIdx+1:]), ")"))
+	if rawTypes == "" {
+		return name + "()"
+	}
+	return fmt.Sprintf("%s(%s)", name, functionSignature(splitGrantObjects(rawTypes)))
+}
 
-	names := make([]string, defs.Len())
+// callableSignatures returns the normalized signatures of the callable definitions.
+func callableSignatures(defs *schema.Set) *schema.Set {
+	signatures := schema.NewSet(schema.HashString, nil)
 	for _, def := range defs.List() {
-		names = append(names, parser(def.(string)))
+		signatures.Add(callableSignature(def.(string)))
+	}
+	return signatures
+}
+
+// matchesCallable reports whether the callable with the signature from the catalog is one of the
+// signatures, either as the specific overload or by name for definitions without argument types.
+func matchesCallable(signatures *schema.Set, signature string) bool {
+	signature = callableSignature(signature)
+	return signatures.Contains(signature) || signatures.Contains(strings.Split(signature, "(")[0])
+}
+
+// redactedValue replaces sensitive values, e.g. passwords, in rendered SQL statements.
+const redactedValue = "<redacted>"
+
+// statementsDataSourceSchema derives the schema of a data source rendering the SQL statements
+// of a resource from the resource schema, so the resource's query builders can be reused as is.
+// Attributes only computed by the resource are dropped.
+func statementsDataSourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	dataSourceSchema := make(map[string]*schema.Schema, len(resourceSchema)+1)
+	for name, s := range resourceSchema {
+		if s.Computed && !s.Optional {
+			continue
+		}
+		attr := *s
+		attr.ForceNew = false
+		dataSourceSchema[name] = &attr
+	}
+	dataSourceSchema[statementsAttr] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The SQL statements the resource executes on creation, in order of execution. Sensitive values are redacted.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	return dataSourceSchema
+}
+
+// commentQuery returns the COMMENT ON statement for an object, an empty comment removes it.
+func commentQuery(objectType, objectName, comment string) string {
+	value := "NULL"
+	if comment != "" {
+		value = fmt.Sprintf("'%s'", pqQuoteLiteral(comment))
+	}
+	return fmt.Sprintf("COMMENT ON %s %s IS %s", objectType, pq.QuoteIdentifier(objectName), value)
+}
+
+// setComment issues the COMMENT ON statement when the comment attribute was set or changed.
+func setComment(tx *sql.Tx, d *schema.ResourceData, commentAttr, objectType, objectName string) error {
+	if !d.HasChange(commentAttr) {
+		return nil
+	}
+
+	query := commentQuery(objectType, objectName, d.Get(commentAttr).(string))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not set comment on %s %q: %w", strings.ToLower(objectType), objectName, err)
+	}
+	return nil
+}
+
+// readComment reads the comment of the object with the resource ID from the given catalog.
+// Comments are opt-in: they're only read when the attribute is set, so comments managed
+// outside of Terraform don't cause a diff.
+func readComment(db *DBConnection, d *schema.ResourceData, commentAttr, catalog string) error {
+	if _, ok := d.GetOk(commentAttr); !ok {
+		return nil
+	}
+
+	var comment string
+	query := "SELECT COALESCE(obj_description($1::oid, $2), '')"
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, d.Id(), catalog)
+	if err := db.QueryRow(query, d.Id(), catalog).Scan(&comment); err != nil {
+		return fmt.Errorf("could not read comment: %w", err)
 	}
-	return names
+	d.Set(commentAttr, comment)
+	return nil
 }
@@ -1,7 +1,17 @@
 package redshift
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestValidatePrivileges(t *testing.T) {
@@ -100,6 +110,21 @@ func TestValidatePrivileges(t *testing.T) {
 			objectType: "language",
 			expected:   false,
 		},
+		"all for table": {
+			privileges: []string{"ALL"},
+			objectType: "table",
+			expected:   true,
+		},
+		"all with other privileges": {
+			privileges: []string{"all", "select"},
+			objectType: "table",
+			expected:   false,
+		},
+		"all for unknown type": {
+			privileges: []string{"all"},
+			objectType: "foo",
+			expected:   false,
+		},
 	}
 
 	for name, tt := range tests {
@@ -112,3 +137,823 @@ func TestValidatePrivileges(t *testing.T) {
 		})
 	}
 }
+
+func TestTableMaintenanceQueries(t *testing.T) {
+	tests := map[string]struct {
+		analyze  bool
+		vacuum   bool
+		expected []string
+	}{
+		"no flags": {
+			expected: nil,
+		},
+		"analyze only": {
+			analyze:  true,
+			expected: []string{`ANALYZE "public"."my_table"`},
+		},
+		"vacuum only": {
+			vacuum:   true,
+			expected: []string{`VACUUM "public"."my_table"`},
+		},
+		"vacuum and analyze": {
+			analyze:  true,
+			vacuum:   true,
+			expected: []string{`VACUUM "public"."my_table"`, `ANALYZE "public"."my_table"`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tableMaintenanceQueries("public", "my_table", tt.analyze, tt.vacuum)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected queries to be %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseAclEntry(t *testing.T) {
+	tests := map[string]struct {
+		raw      string
+		expected aclEntry
+		wantErr  bool
+	}{
+		"user": {
+			raw:      "bob=arwdRxt/admin",
+			expected: aclEntry{Grantee: "bob", Privileges: "arwdRxt", Grantor: "admin"},
+		},
+		"group": {
+			raw:      "group analysts=r/admin",
+			expected: aclEntry{Grantee: "group analysts", Privileges: "r", Grantor: "admin"},
+		},
+		"public": {
+			raw:      "=UC/rdsdb",
+			expected: aclEntry{Grantee: "", Privileges: "UC", Grantor: "rdsdb"},
+		},
+		"quoted names": {
+			raw:      `"group my-group"=r/"john.doe@example.com"`,
+			expected: aclEntry{Grantee: "group my-group", Privileges: "r", Grantor: "john.doe@example.com"},
+		},
+		"other grantor": {
+			raw:      "bob=r/alice",
+			expected: aclEntry{Grantee: "bob", Privileges: "r", Grantor: "alice"},
+		},
+		"missing grantor": {
+			raw:     "bob=r",
+			wantErr: true,
+		},
+		"missing privileges": {
+			raw:     "bob",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := parseAclEntry(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAclEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("Expected result to be %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseAcl(t *testing.T) {
+	result, err := parseAcl("rdsdb=arwdRxt/rdsdb|group analysts=r/rdsdb|bob=r/alice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []aclEntry{
+		{Grantee: "rdsdb", Privileges: "arwdRxt", Grantor: "rdsdb"},
+		{Grantee: "group analysts", Privileges: "r", Grantor: "rdsdb"},
+		{Grantee: "bob", Privileges: "r", Grantor: "alice"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result to be %+v but got %+v", expected, result)
+	}
+
+	result, err = parseAcl("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("Expected empty result but got %+v", result)
+	}
+}
+
+func TestAclHasGrantOption(t *testing.T) {
+	tests := map[string]struct {
+		privileges string
+		expected   bool
+	}{
+		"all with grant option": {privileges: "r*w*", expected: true},
+		"single with option":    {privileges: "r*", expected: true},
+		"without grant option":  {privileges: "rw", expected: false},
+		"partial grant option":  {privileges: "r*w", expected: false},
+		"empty":                 {privileges: "", expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := aclHasGrantOption(tt.privileges); result != tt.expected {
+				t.Errorf("Expected result to be %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAclPrivilegeHasGrantOption(t *testing.T) {
+	tests := map[string]struct {
+		privileges string
+		privilege  string
+		expected   bool
+	}{
+		"grantable":      {privileges: "r*aw", privilege: "select", expected: true},
+		"not grantable":  {privileges: "r*aw", privilege: "insert", expected: false},
+		"last privilege": {privileges: "r*aw", privilege: "update", expected: false},
+		"not granted":    {privileges: "r*", privilege: "delete", expected: false},
+		"upper case":     {privileges: "UC*", privilege: "CREATE", expected: true},
+		"unknown":        {privileges: "r*", privilege: "unknown", expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := aclPrivilegeHasGrantOption(tt.privileges, tt.privilege); result != tt.expected {
+				t.Errorf("Expected result to be %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPrivilegeCheckQuery(t *testing.T) {
+	tests := map[string]struct {
+		objectType string
+		expected   string
+		wantErr    bool
+	}{
+		"database": {objectType: "database", expected: "SELECT has_database_privilege($1, $2, $3)"},
+		"schema":   {objectType: "SCHEMA", expected: "SELECT has_schema_privilege($1, $2, $3)"},
+		"table":    {objectType: "table", expected: "SELECT has_table_privilege($1, $2, $3)"},
+		"function": {objectType: "function", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := privilegeCheckQuery(tt.objectType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("privilegeCheckQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if query != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestCommentQuery(t *testing.T) {
+	tests := map[string]struct {
+		comment  string
+		expected string
+	}{
+		"comment":       {comment: "Sales data", expected: `COMMENT ON SCHEMA "sales" IS 'Sales data'`},
+		"quoted":        {comment: "Owner's data", expected: `COMMENT ON SCHEMA "sales" IS 'Owner''s data'`},
+		"empty comment": {comment: "", expected: `COMMENT ON SCHEMA "sales" IS NULL`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := commentQuery("SCHEMA", "sales", tt.comment); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSessionAuthorizationQuery(t *testing.T) {
+	expected := `SET LOCAL SESSION AUTHORIZATION 'team''s user'`
+	if got := sessionAuthorizationQuery("team's user"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestNormalizeIdentifier(t *testing.T) {
+	db := testCaseSensitivityConnection(t, "normalize-identifier", false)
+	for name, expected := range map[string]string{
+		"analysts":         "analysts",
+		"TF_Acc_Group":     "tf_acc_group",
+		"user@Example.COM": "user@example.com",
+		"\"Quoted_Name\"":  "\"quoted_name\"",
+	} {
+		if got := normalizeIdentifier(db, name); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+
+	caseSensitiveDB := testCaseSensitivityConnection(t, "normalize-identifier-case-sensitive", true)
+	if got := normalizeIdentifier(caseSensitiveDB, "TF_Acc_Group"); got != "TF_Acc_Group" {
+		t.Errorf("Expected the case to be kept with case sensitive identifiers but got %q", got)
+	}
+}
+
+// testCaseSensitivityConnection returns a connection whose case sensitivity of identifiers is already cached,
+// so normalizeIdentifier doesn't connect to the missing host.
+func testCaseSensitivityConnection(t *testing.T, host string, caseSensitive bool) *DBConnection {
+	config := NewConfig(proxyDriverName, fmt.Sprintf("host=%s", host), "db", 1)
+	if _, err := getCaseSensitivityCheck(config.ConnStr).get(func() (bool, error) { return caseSensitive, nil }); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return &DBConnection{client: config.NewClient()}
+}
+
+func TestSuppressIdentifierCaseDiff(t *testing.T) {
+	if !suppressIdentifierCaseDiff("user", "TF_Acc_User", "tf_acc_user", nil) {
+		t.Error("Expected the diff between names only differing in case to be suppressed")
+	}
+	if suppressIdentifierCaseDiff("user", "tf_acc_user", "tf_acc_other_user", nil) {
+		t.Error("Expected the diff between different names not to be suppressed")
+	}
+}
+
+func TestAccStartTransaction_CreateAsRole(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_create_as_role")
+	tableName := generateRandomObjectName("tf_acc_create_as_role")
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+	name = %[1]q
+}
+`, userName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckCreateAsRoleOwnsObjects(userName, tableName),
+			},
+		},
+	})
+}
+
+// TestAccRedshiftProvider_CreateAsRole runs the resources managing users, roles and grants with create_as_role set,
+// whose statements must not run with the session authorization of the user owning the created objects.
+func TestAccRedshiftProvider_CreateAsRole(t *testing.T) {
+	ownerName := generateRandomObjectName("tf_acc_create_as_role")
+	userName := generateRandomObjectName("tf_acc_create_as_role")
+	roleName := generateRandomObjectName("tf_acc_create_as_role")
+	schemaName := generateRandomObjectName("tf_acc_create_as_role")
+	config := fmt.Sprintf(`
+provider "redshift" {
+  create_as_role = %[1]q
+}
+
+resource "redshift_user" "owner" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_role" "role" {
+  name = %[3]q
+}
+
+resource "redshift_role_grant" "grant" {
+  role_name     = redshift_role.role.name
+  grant_to_type = "USER"
+  grant_to_name = redshift_user.user.name
+}
+
+resource "redshift_schema" "schema" {
+  name = %[4]q
+
+  depends_on = [redshift_user.owner]
+}
+
+resource "redshift_grant" "usage" {
+  role        = redshift_role.role.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+`, ownerName, userName, roleName, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					testAccCheckRedshiftRoleExists(roleName),
+					testAccCheckRedshiftRoleGrantees(roleName, []string{userName}),
+					resource.TestCheckResourceAttr("redshift_grant.usage", "privileges.#", "1"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCreateAsRoleOwnsObjects(userName, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Client).config
+		config.CreateAsRole = userName
+		tx, err := startCreateTransaction(&DBConnection{client: config.NewClient()})
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(tx)
+
+		if _, err := tx.Exec(fmt.Sprintf("CREATE TABLE public.%s (id INT)", tableName)); err != nil {
+			return fmt.Errorf("error creating table as %s: %w", userName, err)
+		}
+		var owner string
+		if err := tx.QueryRow("SELECT tableowner FROM pg_tables WHERE schemaname = 'public' AND tablename = $1", tableName).Scan(&owner); err != nil {
+			return err
+		}
+		if owner != userName {
+			return fmt.Errorf("expected table to be owned by %s but was owned by %s", userName, owner)
+		}
+		return nil
+	}
+}
+
+func testAccCheckRedshiftComment(resourceName, catalog, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var comment string
+		if err := db.QueryRow("SELECT COALESCE(obj_description($1::oid, $2), '')", rs.Primary.ID, catalog).Scan(&comment); err != nil {
+			return fmt.Errorf("error reading comment of %s: %w", resourceName, err)
+		}
+		if comment != expected {
+			return fmt.Errorf("expected comment of %s to be %q but was %q", resourceName, expected, comment)
+		}
+		return nil
+	}
+}
+
+func TestReconcileAllPrivileges(t *testing.T) {
+	tests := map[string]struct {
+		objectType string
+		configured []interface{}
+		granted    []interface{}
+		expected   []interface{}
+	}{
+		"table": {
+			objectType: "table",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"select", "insert", "update", "delete", "drop", "references"},
+			expected:   []interface{}{"all"},
+		},
+		"table with rule and trigger": {
+			objectType: "table",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"select", "insert", "update", "delete", "drop", "references", "rule", "trigger"},
+			expected:   []interface{}{"all"},
+		},
+		"table missing drop": {
+			objectType: "table",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"select", "insert", "update", "delete", "references"},
+			expected:   []interface{}{"select", "insert", "update", "delete", "references"},
+		},
+		"schema": {
+			objectType: "schema",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"create", "usage"},
+			expected:   []interface{}{"all"},
+		},
+		"schema missing create": {
+			objectType: "schema",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"usage"},
+			expected:   []interface{}{"usage"},
+		},
+		"database": {
+			objectType: "database",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"create", "temporary"},
+			expected:   []interface{}{"all"},
+		},
+		"datashare database": {
+			objectType: "database",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"create", "temporary", "usage"},
+			expected:   []interface{}{"all"},
+		},
+		"function": {
+			objectType: "function",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"execute"},
+			expected:   []interface{}{"all"},
+		},
+		"procedure": {
+			objectType: "procedure",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"execute"},
+			expected:   []interface{}{"all"},
+		},
+		"language": {
+			objectType: "language",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{"usage"},
+			expected:   []interface{}{"all"},
+		},
+		"nothing granted": {
+			objectType: "schema",
+			configured: []interface{}{"all"},
+			granted:    []interface{}{},
+			expected:   []interface{}{},
+		},
+		"all not configured": {
+			objectType: "schema",
+			configured: []interface{}{"create", "usage"},
+			granted:    []interface{}{"create", "usage"},
+			expected:   []interface{}{"create", "usage"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			configured := schema.NewSet(schema.HashString, tt.configured)
+			granted := schema.NewSet(schema.HashString, tt.granted)
+			expected := schema.NewSet(schema.HashString, tt.expected)
+			if result := reconcileAllPrivileges(configured, granted, tt.objectType); !result.Equal(expected) {
+				t.Errorf("Expected %v but got %v", expected.List(), result.List())
+			}
+		})
+	}
+}
+
+func TestExpandAllPrivileges(t *testing.T) {
+	for objectType, expansion := range allPrivileges {
+		t.Run(objectType, func(t *testing.T) {
+			expanded := expandAllPrivileges(schema.NewSet(schema.HashString, []interface{}{"ALL"}), objectType)
+			if expanded.Len() != len(expansion) {
+				t.Errorf("Expected %v but got %v", expansion, expanded.List())
+			}
+			// Reconciling the expansion must yield ALL again
+			reconciled := reconcileAllPrivileges(schema.NewSet(schema.HashString, []interface{}{"all"}), expanded, objectType)
+			if !reconciled.Contains("all") {
+				t.Errorf("Expected the expansion %v to reconcile to all", expanded.List())
+			}
+		})
+	}
+}
+
+func TestPrincipalTypeMismatch(t *testing.T) {
+	tests := map[string]struct {
+		principalType string
+		found         map[string]bool
+		expected      string
+	}{
+		"declared type exists": {
+			principalType: "group",
+			found:         map[string]bool{"group": true},
+		},
+		"does not exist at all": {
+			principalType: "group",
+			found:         map[string]bool{},
+		},
+		"group declared but role exists": {
+			principalType: "group",
+			found:         map[string]bool{"role": true},
+			expected:      `group "analysts" does not exist, but a role with that name does: declare it as a role instead`,
+		},
+		"role declared but group exists": {
+			principalType: "role",
+			found:         map[string]bool{"group": true},
+			expected:      `role "analysts" does not exist, but a group with that name does: declare it as a group instead`,
+		},
+		"user declared but group exists": {
+			principalType: "user",
+			found:         map[string]bool{"group": true},
+			expected:      `user "analysts" does not exist, but a group with that name does: declare it as a group instead`,
+		},
+		"group declared but user and role exist": {
+			principalType: "group",
+			found:         map[string]bool{"user": true, "role": true},
+			expected:      `group "analysts" does not exist, but a user and a role with that name does: declare it as a user instead`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := principalTypeMismatch(tt.principalType, "analysts", tt.found)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q but got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCallableSignature(t *testing.T) {
+	tests := map[string]string{
+		"f_now":                         "f_now",
+		"F_Now()":                       "f_now()",
+		"f_add(int, int)":               "f_add(integer,integer)",
+		"f_add(integer, integer)":       "f_add(integer,integer)",
+		"f_round(numeric(10,2), float)": "f_round(numeric,double precision)",
+		"f_label(varchar(64))":          "f_label(character varying)",
+	}
+	for def, expected := range tests {
+		if got := callableSignature(def); got != expected {
+			t.Errorf("callableSignature(%q) = %q, expected %q", def, got, expected)
+		}
+	}
+}
+
+func TestMatchesCallable(t *testing.T) {
+	signatures := callableSignatures(schema.NewSet(schema.HashString, []interface{}{"f_add(int, int)", "f_now"}))
+
+	tests := map[string]bool{
+		"f_add(integer, integer)":                     true,
+		"f_add(character varying, character varying)": false,
+		"f_now()":   true,
+		"f_other()": false,
+	}
+	for signature, expected := range tests {
+		if got := matchesCallable(signatures, signature); got != expected {
+			t.Errorf("matchesCallable(%q) = %t, expected %t", signature, got, expected)
+		}
+	}
+}
+
+func TestResourceRetryOnPQErrors(t *testing.T) {
+	defer func(interval time.Duration) { pqErrorRetryInterval = interval }(pqErrorRetryInterval)
+	pqErrorRetryInterval = time.Millisecond
+
+	serializationErr := fmt.Errorf("could not commit transaction: %w", &pq.Error{Code: "40001", Message: "1023: Serializable isolation violation on table"})
+	internalSerializationErr := &pq.Error{Code: "XX000", Message: "1023: Serializable isolation violation on table - 100123, transactions forming the cycle are: 1234, 1235"}
+	deadlockErr := &pq.Error{Code: "40P01", Message: "deadlock detected"}
+	syntaxErr := &pq.Error{Code: "42601", Message: "syntax error"}
+
+	tests := map[string]struct {
+		retryableCodes   []string
+		maxRetries       int
+		errs             []error
+		expectedAttempts int
+		wantErr          bool
+	}{
+		"serializable isolation violation succeeds on retry": {
+			maxRetries:       defaultProviderMaxRetries,
+			errs:             []error{serializationErr},
+			expectedAttempts: 2,
+		},
+		"serializable isolation violation reported as internal error": {
+			maxRetries:       defaultProviderMaxRetries,
+			retryableCodes:   []string{"42601"},
+			errs:             []error{internalSerializationErr, internalSerializationErr},
+			expectedAttempts: 3,
+		},
+		"non retryable error": {
+			maxRetries:       defaultProviderMaxRetries,
+			errs:             []error{syntaxErr},
+			expectedAttempts: 1,
+			wantErr:          true,
+		},
+		"configured retryable codes": {
+			maxRetries:       defaultProviderMaxRetries,
+			retryableCodes:   []string{"42601"},
+			errs:             []error{syntaxErr, syntaxErr},
+			expectedAttempts: 3,
+		},
+		"configured codes replace the defaults": {
+			maxRetries:       defaultProviderMaxRetries,
+			retryableCodes:   []string{"42601"},
+			errs:             []error{deadlockErr},
+			expectedAttempts: 1,
+			wantErr:          true,
+		},
+		"retries exhausted": {
+			maxRetries:       2,
+			errs:             []error{serializationErr, serializationErr, serializationErr},
+			expectedAttempts: 3,
+			wantErr:          true,
+		},
+		"retries disabled": {
+			maxRetries:       0,
+			errs:             []error{serializationErr},
+			expectedAttempts: 1,
+			wantErr:          true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := &DBConnection{client: &Client{config: Config{RetryableErrorCodes: tt.retryableCodes, MaxRetries: tt.maxRetries}}}
+			attempts := 0
+			fn := ResourceRetryOnPQErrors(func(*DBConnection, *schema.ResourceData) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			err := fn(db, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %t but got %v", tt.wantErr, err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts but got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestValidatePrivilegeName(t *testing.T) {
+	for _, privilege := range []string{"select", "EXECUTE", "temporary", "temp", "all"} {
+		if _, errs := validatePrivilegeName(privilege, "privileges.0"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid but got %v", privilege, errs)
+		}
+	}
+	for _, privilege := range []string{"selct", "connect", "tmp", ""} {
+		if _, errs := validatePrivilegeName(privilege, "privileges.0"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", privilege)
+		}
+	}
+}
+
+func TestImportStateInDatabase(t *testing.T) {
+	r := redshiftSchema()
+	for id, expected := range map[string][2]string{
+		"analytics:12345": {"analytics", "12345"},
+		"12345":           {"", "12345"},
+	} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		if _, err := importStateInDatabase(context.Background(), d, nil); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if database, got := d.Get(resourceDatabaseAttr).(string), d.Id(); database != expected[0] || got != expected[1] {
+			t.Errorf("Expected database %q and ID %q but got %q and %q", expected[0], expected[1], database, got)
+		}
+	}
+
+	for _, id := range []string{":12345", "analytics:"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		if _, err := importStateInDatabase(context.Background(), d, nil); err == nil {
+			t.Errorf("Expected an error for ID %q", id)
+		}
+	}
+}
+
+func TestPollUntilVisible(t *testing.T) {
+	cases := map[string]struct {
+		retries        int
+		visibleAfter   int
+		checkErr       error
+		expectError    bool
+		expectedChecks int
+	}{
+		"visible right away": {
+			retries:        5,
+			visibleAfter:   1,
+			expectedChecks: 1,
+		},
+		"visible after delay": {
+			retries:        5,
+			visibleAfter:   3,
+			expectedChecks: 3,
+		},
+		"retries exhausted": {
+			retries:        2,
+			visibleAfter:   10,
+			expectError:    true,
+			expectedChecks: 3,
+		},
+		"retries disabled": {
+			retries:        0,
+			visibleAfter:   2,
+			expectError:    true,
+			expectedChecks: 1,
+		},
+		"check fails": {
+			retries:        5,
+			visibleAfter:   3,
+			checkErr:       errors.New("connection reset"),
+			expectError:    true,
+			expectedChecks: 1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			checks := 0
+			err := pollUntilVisible(c.retries, time.Millisecond, `role "test"`, func() (bool, error) {
+				checks++
+				return checks >= c.visibleAfter, c.checkErr
+			})
+			if c.expectError != (err != nil) {
+				t.Errorf("Expected error %t but got %v", c.expectError, err)
+			}
+			if checks != c.expectedChecks {
+				t.Errorf("Expected %d checks but got %d", c.expectedChecks, checks)
+			}
+		})
+	}
+}
+
+func TestErrorDiagnostics(t *testing.T) {
+	if diags := errorDiagnostics(nil); diags != nil {
+		t.Errorf("Expected no diagnostics but got %v", diags)
+	}
+
+	diags := errorDiagnostics(errors.New("could not connect"))
+	if len(diags) != 1 || diags[0].Summary != "could not connect" || diags[0].Detail != "" {
+		t.Errorf("Expected the error without detail but got %+v", diags)
+	}
+
+	pqErr := &pq.Error{
+		Code:    "42501",
+		Message: "permission denied for schema test",
+		Detail:  "The user is not the owner of the schema.",
+		Hint:    "Grant usage on the schema to the user.",
+	}
+	diags = errorDiagnostics(fmt.Errorf("could not grant role: %w", pqErr))
+	if len(diags) != 1 {
+		t.Fatalf("Expected one diagnostic but got %+v", diags)
+	}
+	if expected := "could not grant role: pq: permission denied for schema test"; diags[0].Summary != expected {
+		t.Errorf("Expected summary %q but got %q", expected, diags[0].Summary)
+	}
+	expected := "SQLSTATE: 42501 (insufficient_privilege)\nDetail: The user is not the owner of the schema.\nHint: Grant usage on the schema to the user."
+	if diags[0].Detail != expected {
+		t.Errorf("Expected detail %q but got %q", expected, diags[0].Detail)
+	}
+
+	diags = errorDiagnostics(&pq.Error{Code: "XX999", Message: "unknown"})
+	if expected := "SQLSTATE: XX999"; diags[0].Detail != expected {
+		t.Errorf("Expected detail %q but got %q", expected, diags[0].Detail)
+	}
+}
+
+func TestReconcilePrivilegeAliases(t *testing.T) {
+	tests := map[string]struct {
+		configured []interface{}
+		granted    []interface{}
+		expected   []interface{}
+	}{
+		"alias configured": {
+			configured: []interface{}{"create", "temp"},
+			granted:    []interface{}{"create", "temporary"},
+			expected:   []interface{}{"create", "temp"},
+		},
+		"alias not granted": {
+			configured: []interface{}{"create", "temp"},
+			granted:    []interface{}{"create"},
+			expected:   []interface{}{"create"},
+		},
+		"full name configured": {
+			configured: []interface{}{"temporary"},
+			granted:    []interface{}{"temporary"},
+			expected:   []interface{}{"temporary"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			configured := schema.NewSet(schema.HashString, tt.configured)
+			granted := schema.NewSet(schema.HashString, tt.granted)
+			expected := schema.NewSet(schema.HashString, tt.expected)
+			if result := reconcilePrivilegeAliases(configured, granted); !result.Equal(expected) {
+				t.Errorf("Expected %v but got %v", expected.List(), result.List())
+			}
+			if !granted.Equal(schema.NewSet(schema.HashString, tt.granted)) {
+				t.Errorf("Expected the granted privileges to be left unchanged but got %v", granted.List())
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"log"
 	"regexp"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,7 +15,22 @@ import (
 
 const (
 	defaultProviderMaxOpenConnections                      = 20
+	defaultProviderConnectTimeout                          = 180
+	defaultProviderConnectRetryInterval                    = 5
 	defaultTemporaryCredentialsAssumeRoleDurationInSeconds = 900
+	defaultProviderApplicationName                         = "terraform-provider-redshift"
+	defaultProviderKeepalivesIdle                          = 60
+	defaultProviderKeepalivesInterval                      = 15
+	defaultProviderKeepalivesCount                         = 9
+	defaultDataApiPollingInterval                          = 1
+	defaultDataApiTimeout                                  = 300
+	defaultProviderMaxRetries                              = 9
+	defaultProviderVisibilityRetries                       = 5
+	defaultProviderVisibilityRetryInterval                 = 500
+
+	clusterTypeAuto        = "auto"
+	clusterTypeServerless  = "serverless"
+	clusterTypeProvisioned = "provisioned"
 )
 
 func Provider() *schema.Provider {
@@ -60,12 +77,151 @@ func Provider() *schema.Provider {
 					"verify-full",
 				}, false),
 			},
+			"sslcert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the client certificate file, for servers requiring TLS client certificate authentication. Requires `sslkey`. Not used with the Data API.",
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_SSLCERT", ""),
+			},
+			"sslkey": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The path of the private key file of the client certificate. The file must not be accessible by other users than the owner. Requires `sslcert`. Not used with the Data API.",
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_SSLKEY", ""),
+			},
+			"sslrootcert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the file of the certificate authorities the server certificate is verified against, e.g. the Amazon Redshift certificate bundle. Required with `sslmode` `verify-ca` or `verify-full`, unless `sslrootcert_pem` or the `PGSSLROOTCERT` environment variable is set. Not used with the Data API.",
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_SSLROOTCERT", ""),
+			},
+			"sslrootcert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The PEM encoded certificate authorities the server certificate is verified against, instead of the file of `sslrootcert`. Not used with the Data API.",
+			},
+			"sslinline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether `sslcert`, `sslkey` and `sslrootcert` are the PEM encoded certificates and key themselves instead of file paths, e.g. read from a secret. Not used with the Data API.",
+			},
 			"database": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "The name of the database to connect to. The default is `redshift`.",
 				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_DATABASE", "redshift"),
 			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The session time zone pinned on connect, so that timestamps are read consistently. The default is `UTC`. Not used with the Data API, which always returns timestamps in UTC. Timestamp attributes are always stored in UTC.",
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_TIMEZONE", "UTC"),
+			},
+			"application_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The application name reported to the server, to identify the provider's sessions in system tables like `stl_connection_log`. The default is `terraform-provider-redshift`. Not used with the Data API.",
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_APPLICATION_NAME", defaultProviderApplicationName),
+			},
+			"connect_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time in seconds to wait while connecting. Zero means to wait indefinitely. The default is `180`. Not used with the Data API.",
+				DefaultFunc:  schema.EnvDefaultFunc("REDSHIFT_CONNECT_TIMEOUT", defaultProviderConnectTimeout),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"connect_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of times to retry connecting on transient errors, i.e. timeouts and refused or reset connections, e.g. while a paused cluster is resuming. Authentication failures and hosts which can't be resolved are not retried.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"connect_retry_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderConnectRetryInterval,
+				Description:  "Time in seconds to wait before the first connection retry. The interval doubles with every further retry. The default is `5`.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderMaxRetries,
+				Description:  "Number of times to retry creating, updating or dropping grants, roles and schemas after errors caused by concurrent operations, e.g. serializable isolation violations during parallel applies. The wait before a retry grows by one second with every attempt. Zero disables the retries. The default is `9`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"cluster_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     clusterTypeAuto,
+				Description: "Whether the provider connects to a `serverless` workgroup or a `provisioned` cluster. With the default `auto` the provider queries the system views of Redshift Serverless to find out, which requires access to them.",
+				ValidateFunc: validation.StringInSlice([]string{
+					clusterTypeAuto,
+					clusterTypeServerless,
+					clusterTypeProvisioned,
+				}, false),
+			},
+			"serverless_visibility_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderVisibilityRetries,
+				Description:  "Number of times to check again whether a created role, group or schema is visible before reading it back, as objects created on Redshift Serverless are not always visible right away on every connection. Only used with Redshift Serverless. Zero disables the checks. The default is `5`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"serverless_visibility_retry_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderVisibilityRetryInterval,
+				Description:  "Time in milliseconds to wait between the visibility checks of created objects on Redshift Serverless. The default is `500`.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"retryable_error_codes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(sqlStateRegexp, "must be a five character SQLSTATE code"),
+				},
+				Set:         schema.HashString,
+				Description: "SQLSTATE codes of the errors which are retried when creating or dropping objects that are often modified concurrently, e.g. schemas, grants and roles during parallel destroys. Defaults to `XX000` (internal error, e.g. concurrent transactions), `3F000`, `40P01` (deadlock), `25P02` and `40001`. Serializable isolation violations are retried in any case.",
+			},
+			"keepalives": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to send TCP keepalives, so that idle connections aren't dropped by NAT gateways or load balancers. Enabled by default. Not used with the Data API.",
+			},
+			"keepalives_idle": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderKeepalivesIdle,
+				Description:  "Time in seconds a connection must be idle before the first keepalive is sent. Zero falls back to 15 seconds. The default is `60`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"keepalives_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderKeepalivesInterval,
+				Description:  "Time in seconds between keepalives that are not acknowledged. Zero falls back to 15 seconds. The default is `15`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"keepalives_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderKeepalivesCount,
+				Description:  "Number of unacknowledged keepalives after which the connection is considered dead. Zero falls back to 9. The default is `9`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"statement_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time in milliseconds a statement may run before it is aborted. Zero (the default) means no timeout. Not used with the Data API.",
+				DefaultFunc:  schema.EnvDefaultFunc("REDSHIFT_STATEMENT_TIMEOUT", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"max_connections": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -73,10 +229,36 @@ func Provider() *schema.Provider {
 				Description:  "Maximum number of connections to establish to the database. Zero means unlimited.",
 				ValidateFunc: validation.IntAtLeast(-1),
 			},
+			"max_idle_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of idle connections kept open for reuse, which speeds up large plans. Idle connections to a database are closed before a `redshift_database` resource drops it. The default is `0`, connections are never reused.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"check_privileges": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check the privileges of the connected user before creating schemas, tables and views, to fail with a precise error when a privilege is missing. This runs additional catalog queries and is disabled by default.",
+			},
+			"default_schema_owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the user owning schemas created by `redshift_schema` when they don't specify an `owner`. Defaults to the connected user.",
+			},
+			"create_as_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the user assumed with `SET SESSION AUTHORIZATION` in the transactions creating schemas, external schemas, tables, views and functions, so that these objects are owned by a shared team user instead of the connected user. The session authorization only lasts until the end of each transaction. Requires the connected user to be a superuser. Not used with the Data API.",
+				ConflictsWith: []string{
+					"data_api",
+				},
+			},
 			"data_api": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Configuration for using the Redshift Data API. This can only be used for serverless Redshift clusters.",
+				Description: "Configuration for using the Redshift Data API, with a Redshift Serverless workgroup or a provisioned cluster. The statements are run one by one without transactions, so a failed operation may be applied partially.",
 				MaxItems:    1,
 				ConflictsWith: []string{
 					"host",
@@ -85,10 +267,11 @@ func Provider() *schema.Provider {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"workgroup_name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the Redshift Serverless workgroup to connect to.",
-							DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_DATA_API_SERVERLESS_WORKGROUP_NAME", nil),
+							Type:          schema.TypeString,
+							Optional:      true,
+							Description:   "The name of the Redshift Serverless workgroup to connect to. Either `workgroup_name` or `cluster_identifier` must be set.",
+							DefaultFunc:   schema.EnvDefaultFunc("REDSHIFT_DATA_API_SERVERLESS_WORKGROUP_NAME", nil),
+							ConflictsWith: []string{"data_api.0.cluster_identifier"},
 							// https://docs.aws.amazon.com/redshift-serverless/latest/APIReference/API_Workgroup.html#:~:text=Required%3A%20No-,workgroupName,-The%20name%20of
 							ValidateFunc: validation.All(
 								validation.StringLenBetween(3, 64),
@@ -101,13 +284,40 @@ func Provider() *schema.Provider {
 							Description: "The AWS region where the Redshift Serverless workgroup is located. If not specified, the region will be determined from the AWS SDK configuration.",
 							DefaultFunc: schema.MultiEnvDefaultFunc([]string{"AWS_REGION", "AWS_DEFAULT_REGION"}, nil),
 						},
+						"cluster_identifier": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							Description:   "The identifier of the provisioned Redshift cluster to connect to.",
+							ConflictsWith: []string{"data_api.0.workgroup_name"},
+							RequiredWith:  []string{"data_api.0.db_user"},
+						},
+						"db_user": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The database user to connect to the provisioned cluster as, using temporary credentials obtained by the Data API. Requires `cluster_identifier`.",
+							RequiredWith: []string{"data_api.0.cluster_identifier"},
+						},
+						"polling_interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultDataApiPollingInterval,
+							Description:  "Time in seconds between two checks of the status of a running statement. The default is `1`.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultDataApiTimeout,
+							Description:  "Maximum time in seconds to wait for a statement to finish, e.g. long running `CREATE EXTERNAL SCHEMA` or large grants. The default is `300`.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
 					},
 				},
 			},
 			"temporary_credentials": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Configuration for obtaining a temporary password using redshift:GetClusterCredentials",
+				Description: "Configuration for obtaining a temporary password using redshift:GetClusterCredentials, redshift:GetClusterCredentialsWithIAM or redshift-serverless:GetCredentials",
 				MaxItems:    1,
 				ConflictsWith: []string{
 					"password",
@@ -117,27 +327,44 @@ func Provider() *schema.Provider {
 					Schema: map[string]*schema.Schema{
 						"cluster_identifier": {
 							Type:         schema.TypeString,
-							Required:     true,
-							Description:  "The unique identifier of the cluster that contains the database for which you are requesting credentials. This parameter is case sensitive.",
+							Optional:     true,
+							Description:  "The unique identifier of the cluster that contains the database for which you are requesting credentials. This parameter is case sensitive. Either `cluster_identifier` or `workgroup_name` must be set.",
 							ValidateFunc: validation.StringLenBetween(1, 2147483647),
+							ExactlyOneOf: []string{"temporary_credentials.0.cluster_identifier", "temporary_credentials.0.workgroup_name"},
+						},
+						"workgroup_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The name of the Redshift Serverless workgroup for which you are requesting credentials using redshift-serverless:GetCredentials. The database user is derived from the IAM identity. Either `cluster_identifier` or `workgroup_name` must be set.",
+							ValidateFunc: validation.StringLenBetween(3, 64),
+							ExactlyOneOf: []string{"temporary_credentials.0.cluster_identifier", "temporary_credentials.0.workgroup_name"},
 						},
 						"region": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The AWS region where the Redshift cluster is located.",
+							Description: "The AWS region where the Redshift cluster or the Redshift Serverless workgroup is located.",
+						},
+						"use_iam_identity": {
+							Type:          schema.TypeBool,
+							Optional:      true,
+							Description:   "Obtain the temporary password using redshift:GetClusterCredentialsWithIAM, which maps the IAM identity of the caller to a database user. The `username`, `auto_create_user` and `db_groups` are then derived from the IAM identity.",
+							Default:       false,
+							ConflictsWith: []string{"temporary_credentials.0.workgroup_name"},
 						},
 						"auto_create_user": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Description: "Create a database user with the name specified for the user if one does not exist.",
-							Default:     false,
+							Type:          schema.TypeBool,
+							Optional:      true,
+							Description:   "Create a database user with the name specified for the user if one does not exist.",
+							Default:       false,
+							ConflictsWith: []string{"temporary_credentials.0.use_iam_identity", "temporary_credentials.0.workgroup_name"},
 						},
 						"db_groups": {
-							Type:        schema.TypeSet,
-							Set:         schema.HashString,
-							Optional:    true,
-							Description: "A list of the names of existing database groups that the user will join for the current session, in addition to any group memberships for an existing user. If not specified, a new user is added only to PUBLIC.",
-							MaxItems:    2147483647,
+							Type:          schema.TypeSet,
+							Set:           schema.HashString,
+							Optional:      true,
+							ConflictsWith: []string{"temporary_credentials.0.use_iam_identity", "temporary_credentials.0.workgroup_name"},
+							Description:   "A list of the names of existing database groups that the user will join for the current session, in addition to any group memberships for an existing user. If not specified, a new user is added only to PUBLIC.",
+							MaxItems:      2147483647,
 							Elem: &schema.Schema{
 								Type:         schema.TypeString,
 								ValidateFunc: dbGroupValidate,
@@ -160,19 +387,38 @@ func Provider() *schema.Provider {
 			"redshift_group_membership":    redshiftGroupMembership(),
 			"redshift_role":                redshiftRole(),
 			"redshift_role_grant":          redshiftRoleGrant(),
+			"redshift_role_membership":     redshiftRoleMembership(),
 			"redshift_schema":              redshiftSchema(),
+			"redshift_external_schema":     redshiftExternalSchema(),
 			"redshift_default_privileges":  redshiftDefaultPrivileges(),
 			"redshift_grant":               redshiftGrant(),
+			"redshift_grants":              redshiftGrants(),
+			"redshift_assumerole_grant":    redshiftAssumeroleGrant(),
 			"redshift_database":            redshiftDatabase(),
+			"redshift_database_parameter":  redshiftDatabaseParameter(),
+			"redshift_user_parameter":      redshiftUserParameter(),
 			"redshift_datashare":           redshiftDatashare(),
 			"redshift_datashare_privilege": redshiftDatasharePrivilege(),
+			"redshift_access_bundle":       redshiftAccessBundle(),
+			"redshift_table":               redshiftTable(),
+			"redshift_view":                redshiftView(),
+			"redshift_function":            redshiftFunction(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"redshift_user":      dataSourceRedshiftUser(),
-			"redshift_group":     dataSourceRedshiftGroup(),
-			"redshift_schema":    dataSourceRedshiftSchema(),
-			"redshift_database":  dataSourceRedshiftDatabase(),
-			"redshift_namespace": dataSourceRedshiftNamespace(),
+			"redshift_user":             dataSourceRedshiftUser(),
+			"redshift_group":            dataSourceRedshiftGroup(),
+			"redshift_role":             dataSourceRedshiftRole(),
+			"redshift_role_grants":      dataSourceRedshiftRoleGrants(),
+			"redshift_schema":           dataSourceRedshiftSchema(),
+			"redshift_schema_grants":    dataSourceRedshiftSchemaGrants(),
+			"redshift_schemas":          dataSourceRedshiftSchemas(),
+			"redshift_tables":           dataSourceRedshiftTables(),
+			"redshift_database":         dataSourceRedshiftDatabase(),
+			"redshift_datashare":        dataSourceRedshiftDatashare(),
+			"redshift_namespace":        dataSourceRedshiftNamespace(),
+			"redshift_grant_statements": dataSourceRedshiftGrantStatements(),
+			"redshift_user_statements":  dataSourceRedshiftUserStatements(),
+			"redshift_connection":       dataSourceRedshiftConnection(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -193,10 +439,31 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 func getConfigFromResourceData(d *schema.ResourceData, temporaryCredentialsResolver temporaryCredentialsResolverFunc) (*Config, error) {
 	database := d.Get("database").(string)
 	maxConnections := d.Get("max_connections").(int)
+	var cfg *Config
+	var err error
 	if _, useDataApi := d.GetOk("data_api"); useDataApi {
-		return getConfigFromDataApiResourceData(d, database)
+		cfg, err = getConfigFromDataApiResourceData(d, database)
+	} else {
+		cfg, err = getConfigFromPqResourceData(d, database, maxConnections, temporaryCredentialsResolver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg.CheckPrivileges = d.Get("check_privileges").(bool)
+	cfg.DefaultSchemaOwner = d.Get("default_schema_owner").(string)
+	cfg.CreateAsRole = d.Get("create_as_role").(string)
+	cfg.MaxIdleConns = d.Get("max_idle_connections").(int)
+	cfg.awsConfigLoader = func() (aws.Config, error) {
+		return temporaryCredentialsAwsConfig(d)
 	}
-	return getConfigFromPqResourceData(d, database, maxConnections, temporaryCredentialsResolver)
+	cfg.ConnectRetries = d.Get("connect_retries").(int)
+	cfg.ConnectRetryInterval = time.Duration(d.Get("connect_retry_interval").(int)) * time.Second
+	cfg.RetryableErrorCodes = setToStringList(d.Get("retryable_error_codes").(*schema.Set))
+	cfg.MaxRetries = d.Get("max_retries").(int)
+	cfg.ClusterType = d.Get("cluster_type").(string)
+	cfg.VisibilityRetries = d.Get("serverless_visibility_retries").(int)
+	cfg.VisibilityRetryInterval = time.Duration(d.Get("serverless_visibility_retry_interval").(int)) * time.Millisecond
+	return cfg, nil
 }
 
 func assumeRoleSchema() *schema.Schema {
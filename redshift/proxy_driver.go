@@ -4,7 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"fmt"
 	"net"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -13,21 +17,82 @@ import (
 
 const proxyDriverName = "postgresql-proxy"
 
-type proxyDriver struct{}
+// keepAliveParams are the libpq connection parameters configuring TCP keepalives.
+// lib/pq doesn't know them and would send them to the server as run-time parameters,
+// so they are removed from the connection string and applied when dialing instead.
+var keepAliveParams = []string{"keepalives", "keepalives_idle", "keepalives_interval", "keepalives_count"}
+
+type proxyDriver struct {
+	keepAlive net.KeepAliveConfig
+}
 
 func (d proxyDriver) Open(name string) (driver.Conn, error) {
-	return pq.DialOpen(d, name)
+	keepAlive, name, err := parseKeepAliveParams(name)
+	if err != nil {
+		return nil, err
+	}
+	return pq.DialOpen(proxyDriver{keepAlive: keepAlive}, name)
 }
 
 func (d proxyDriver) Dial(network, address string) (net.Conn, error) {
-	dialer := proxy.FromEnvironment()
+	dialer := proxy.FromEnvironmentUsing(d.netDialer())
 	return dialer.Dial(network, address)
 }
 
 func (d proxyDriver) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
 	defer cancel()
-	return proxy.Dial(ctx, network, address)
+	dialer := proxy.FromEnvironmentUsing(d.netDialer())
+	if contextDialer, ok := dialer.(proxy.ContextDialer); ok {
+		return contextDialer.DialContext(ctx, network, address)
+	}
+	return dialer.Dial(network, address)
+}
+
+func (d proxyDriver) netDialer() *net.Dialer {
+	dialer := &net.Dialer{KeepAliveConfig: d.keepAlive}
+	if !d.keepAlive.Enable {
+		dialer.KeepAlive = -1
+	}
+	return dialer
+}
+
+// parseKeepAliveParams extracts the keepalive parameters from the connection string,
+// returning the keepalive configuration and the connection string without them.
+// Keepalives are enabled with the Go defaults unless configured otherwise.
+func parseKeepAliveParams(connStr string) (net.KeepAliveConfig, string, error) {
+	keepAlive := net.KeepAliveConfig{Enable: true}
+	if !strings.HasPrefix(connStr, "postgres://") && !strings.HasPrefix(connStr, "postgresql://") {
+		return keepAlive, connStr, nil
+	}
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return keepAlive, connStr, nil
+	}
+
+	query := u.Query()
+	for _, param := range keepAliveParams {
+		if !query.Has(param) {
+			continue
+		}
+		value, err := strconv.Atoi(query.Get(param))
+		if err != nil {
+			return keepAlive, connStr, fmt.Errorf("invalid value for %s: %w", param, err)
+		}
+		switch param {
+		case "keepalives":
+			keepAlive.Enable = value != 0
+		case "keepalives_idle":
+			keepAlive.Idle = time.Duration(value) * time.Second
+		case "keepalives_interval":
+			keepAlive.Interval = time.Duration(value) * time.Second
+		case "keepalives_count":
+			keepAlive.Count = value
+		}
+		query.Del(param)
+	}
+	u.RawQuery = query.Encode()
+	return keepAlive, u.String(), nil
 }
 
 func init() {
@@ -0,0 +1,59 @@
+package redshift
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseKeepAliveParams(t *testing.T) {
+	tests := map[string]struct {
+		connStr           string
+		expectedKeepAlive net.KeepAliveConfig
+		expectedConnStr   string
+		expectedErr       bool
+	}{
+		"keepalives": {
+			connStr:           "postgres://user:pw@host:5439/db?connect_timeout=180&keepalives=1&keepalives_count=9&keepalives_idle=60&keepalives_interval=15&sslmode=require",
+			expectedKeepAlive: net.KeepAliveConfig{Enable: true, Idle: 60 * time.Second, Interval: 15 * time.Second, Count: 9},
+			expectedConnStr:   "postgres://user:pw@host:5439/db?connect_timeout=180&sslmode=require",
+		},
+		"keepalives disabled": {
+			connStr:           "postgres://user:pw@host:5439/db?keepalives=0&sslmode=require",
+			expectedKeepAlive: net.KeepAliveConfig{Enable: false},
+			expectedConnStr:   "postgres://user:pw@host:5439/db?sslmode=require",
+		},
+		"no keepalive params": {
+			connStr:           "postgres://user:pw@host:5439/db?sslmode=require",
+			expectedKeepAlive: net.KeepAliveConfig{Enable: true},
+			expectedConnStr:   "postgres://user:pw@host:5439/db?sslmode=require",
+		},
+		"key value connection string": {
+			connStr:           "host=host port=5439 dbname=db",
+			expectedKeepAlive: net.KeepAliveConfig{Enable: true},
+			expectedConnStr:   "host=host port=5439 dbname=db",
+		},
+		"invalid value": {
+			connStr:     "postgres://user:pw@host:5439/db?keepalives_idle=soon",
+			expectedErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			keepAlive, connStr, err := parseKeepAliveParams(tt.connStr)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if tt.expectedErr {
+				return
+			}
+			if keepAlive != tt.expectedKeepAlive {
+				t.Errorf("Expected %+v but got %+v", tt.expectedKeepAlive, keepAlive)
+			}
+			if connStr != tt.expectedConnStr {
+				t.Errorf("Expected %q but got %q", tt.expectedConnStr, connStr)
+			}
+		})
+	}
+}
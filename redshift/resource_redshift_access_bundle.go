@@ -0,0 +1,452 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	accessBundleRoleAttr                   = "role"
+	accessBundleSchemasAttr                = "schemas"
+	accessBundleTablePrivilegesAttr        = "table_privileges"
+	accessBundleDefaultPrivilegesOwnerAttr = "default_privileges_owner"
+	accessBundleGroupsAttr                 = "groups"
+	accessBundleUsersAttr                  = "users"
+)
+
+func redshiftAccessBundle() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Bootstraps a role based access pattern in a single resource: it creates a role, grants it usage on a list of schemas,
+grants privileges on all existing tables of these schemas, sets the default privileges for tables created in the future
+and grants the role to groups and users. All statements are applied in a single transaction.
+
+The role and the privileges are fully owned by this resource. Do not manage the same role with ` + "`redshift_role`" + `, or the
+same schemas, default privileges and role memberships of this role with ` + "`redshift_grant`" + `, ` + "`redshift_default_privileges`" + `
+or ` + "`redshift_role_grant`" + `, as the resources would revoke each other's privileges. Additional privileges can still be granted
+to the role with the granular resources by referencing its name.
+`,
+		CreateContext: ResourceFunc(resourceRedshiftAccessBundleCreate),
+		ReadContext:   ResourceFunc(resourceRedshiftAccessBundleRead),
+		UpdateContext: ResourceFunc(resourceRedshiftAccessBundleUpdate),
+		DeleteContext: ResourceFunc(
+			ResourceRetryOnPQErrors(resourceRedshiftAccessBundleDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			accessBundleRoleAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the role created by the bundle.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			accessBundleSchemasAttr: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:         schema.HashString,
+				Description: "The schemas the role is granted `USAGE` on.",
+			},
+			accessBundleTablePrivilegesAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:         schema.HashString,
+				Description: "The privileges granted to the role on all existing tables of the schemas and, as default privileges, on the tables created in the future, e.g. `select`. Only the privileges held on every table and granted as default privileges in all schemas are read back.",
+			},
+			accessBundleDefaultPrivilegesOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user creating the tables the default privileges apply to. By default, the user the provider is connected as.",
+			},
+			accessBundleGroupsAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+					ValidateFunc: warnRoleGrantToGroup,
+				},
+				Set:         schema.HashString,
+				Description: "The groups the role is granted to. As no system view lists the grants of roles to groups, they can't be read back: only groups which were dropped are removed from the state, revoking the role from a group outside of Terraform is not detected.",
+			},
+			accessBundleUsersAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:         schema.HashString,
+				Description: "The users the role is granted to.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftAccessBundleCreate(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(accessBundleRoleAttr).(string)
+	privileges := accessBundleTablePrivileges(d.Get(accessBundleTablePrivilegesAttr).(*schema.Set))
+	owner := d.Get(accessBundleDefaultPrivilegesOwnerAttr).(string)
+
+	if !validatePrivileges(privileges, "table") {
+		return fmt.Errorf("invalid privileges list %+v for object of type %q", privileges, "table")
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	queries := []string{fmt.Sprintf("CREATE ROLE %s", pq.QuoteIdentifier(roleName))}
+	for _, schemaName := range d.Get(accessBundleSchemasAttr).(*schema.Set).List() {
+		queries = append(queries, accessBundleGrantQueries(roleName, schemaName.(string), privileges, owner)...)
+	}
+	queries = append(queries, accessBundleMembershipQueries(roleName, d.Get(accessBundleGroupsAttr).(*schema.Set), d.Get(accessBundleUsersAttr).(*schema.Set), true)...)
+
+	if err := execAccessBundleQueries(tx, queries); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(strings.ToLower(roleName))
+
+	return resourceRedshiftAccessBundleRead(db, d)
+}
+
+func resourceRedshiftAccessBundleRead(db *DBConnection, d *schema.ResourceData) error {
+	var roleName string
+	query := "SELECT role_name FROM SVV_ROLES WHERE role_name = $1"
+	log.Printf("[DEBUG] %s, $1=%s\n", query, d.Id())
+	if err := db.QueryRow(query, d.Id()).Scan(&roleName); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Role of access bundle (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading role of access bundle: %w", err)
+	}
+
+	schemas, err := readAccessBundleNames(db, `
+  SELECT namespace_name
+  FROM SVV_SCHEMA_PRIVILEGES
+  WHERE identity_type = 'role' AND identity_name = $1 AND privilege_type = 'USAGE'
+`, roleName)
+	if err != nil {
+		return fmt.Errorf("error reading schemas of access bundle: %w", err)
+	}
+
+	// No system view lists the grants of roles to groups, so the grants can't be read back.
+	// Only the groups which were dropped are removed from the state.
+	groups, err := existingPrincipals(db, "group", parseUserNames(d.Get(accessBundleGroupsAttr)))
+	if err != nil {
+		return fmt.Errorf("error reading groups of access bundle: %w", err)
+	}
+
+	users, err := readAccessBundleNames(db, "SELECT user_name FROM SVV_USER_GRANTS WHERE LOWER(role_name) = LOWER($1)", roleName)
+	if err != nil {
+		return fmt.Errorf("error reading users of access bundle: %w", err)
+	}
+
+	privileges, err := readAccessBundleTablePrivileges(db, roleName, schemas, d.Get(accessBundleDefaultPrivilegesOwnerAttr).(string))
+	if err != nil {
+		return fmt.Errorf("error reading table privileges of access bundle: %w", err)
+	}
+
+	d.Set(accessBundleRoleAttr, roleName)
+	d.Set(accessBundleSchemasAttr, schemas)
+	d.Set(accessBundleTablePrivilegesAttr, reconcileAllPrivileges(d.Get(accessBundleTablePrivilegesAttr).(*schema.Set), privileges, "table"))
+	d.Set(accessBundleGroupsAttr, groups)
+	d.Set(accessBundleUsersAttr, users)
+
+	return nil
+}
+
+func readAccessBundleNames(db *DBConnection, query, roleName string) (*schema.Set, error) {
+	log.Printf("[DEBUG] %s, $1=%s\n", query, roleName)
+	rows, err := db.Query(query, roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := schema.NewSet(schema.HashString, nil)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names.Add(strings.ToLower(name))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// readAccessBundleTablePrivileges returns the privileges the role holds on all existing tables of the schemas
+// and as default privileges on the tables the owner creates in the future.
+func readAccessBundleTablePrivileges(db *DBConnection, roleName string, schemas *schema.Set, owner string) (*schema.Set, error) {
+	tx, err := startTransaction(db)
+	if err != nil {
+		return nil, err
+	}
+	defer deferredRollback(tx)
+
+	var ownerID int
+	if owner == "" {
+		err = tx.QueryRow("SELECT usesysid FROM pg_user WHERE usename = current_user").Scan(&ownerID)
+	} else {
+		ownerID, err = getUserIDFromName(tx, owner)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ID: %w", err)
+	}
+
+	grantee := fmt.Sprintf("role %s", roleName)
+	privileges := schema.NewSet(schema.HashString, nil)
+	for i, schemaName := range schemas.List() {
+		schemaID, err := getSchemaIDFromName(tx, schemaName.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema ID for schema '%s': %w", schemaName, err)
+		}
+
+		defaultPrivileges, err := queryAclDefaultPrivileges(tx, grantee, schemaID, ownerID, "table")
+		if err != nil {
+			return nil, err
+		}
+		granted := schema.NewSet(schema.HashString, nil)
+		for _, privilege := range defaultPrivileges {
+			granted.Add(privilege)
+		}
+
+		tablePrivileges, tables, err := readTableAclPrivileges(tx, schemaName.(string), grantee)
+		if err != nil {
+			return nil, err
+		}
+		if tables > 0 {
+			granted = granted.Intersection(tablePrivileges)
+		}
+
+		if i == 0 {
+			privileges = granted
+		} else {
+			privileges = privileges.Intersection(granted)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return privileges, nil
+}
+
+func resourceRedshiftAccessBundleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(accessBundleRoleAttr).(string)
+
+	oldSchemasRaw, newSchemasRaw := d.GetChange(accessBundleSchemasAttr)
+	oldSchemas := oldSchemasRaw.(*schema.Set)
+	newSchemas := newSchemasRaw.(*schema.Set)
+	oldPrivilegesRaw, newPrivilegesRaw := d.GetChange(accessBundleTablePrivilegesAttr)
+	oldPrivileges := accessBundleTablePrivileges(oldPrivilegesRaw.(*schema.Set))
+	newPrivileges := accessBundleTablePrivileges(newPrivilegesRaw.(*schema.Set))
+	oldOwnerRaw, newOwnerRaw := d.GetChange(accessBundleDefaultPrivilegesOwnerAttr)
+	oldOwner := oldOwnerRaw.(string)
+	newOwner := newOwnerRaw.(string)
+
+	if !validatePrivileges(newPrivileges, "table") {
+		return fmt.Errorf("invalid privileges list %+v for object of type %q", newPrivileges, "table")
+	}
+
+	// Schemas which are kept only need to be re-applied if the privileges or the owner changed
+	privilegesChanged := d.HasChanges(accessBundleTablePrivilegesAttr, accessBundleDefaultPrivilegesOwnerAttr)
+
+	var queries []string
+	for _, schemaName := range oldSchemas.List() {
+		if privilegesChanged || !newSchemas.Contains(schemaName) {
+			queries = append(queries, accessBundleRevokeQueries(roleName, schemaName.(string), oldPrivileges, oldOwner)...)
+		}
+	}
+	for _, schemaName := range newSchemas.List() {
+		if privilegesChanged || !oldSchemas.Contains(schemaName) {
+			queries = append(queries, accessBundleGrantQueries(roleName, schemaName.(string), newPrivileges, newOwner)...)
+		}
+	}
+
+	for _, attr := range []string{accessBundleGroupsAttr, accessBundleUsersAttr} {
+		if !d.HasChange(attr) {
+			continue
+		}
+		oldRaw, newRaw := d.GetChange(attr)
+		removed := oldRaw.(*schema.Set).Difference(newRaw.(*schema.Set))
+		added := newRaw.(*schema.Set).Difference(oldRaw.(*schema.Set))
+		empty := schema.NewSet(schema.HashString, nil)
+		if attr == accessBundleGroupsAttr {
+			queries = append(queries, accessBundleMembershipQueries(roleName, removed, empty, false)...)
+			queries = append(queries, accessBundleMembershipQueries(roleName, added, empty, true)...)
+		} else {
+			queries = append(queries, accessBundleMembershipQueries(roleName, empty, removed, false)...)
+			queries = append(queries, accessBundleMembershipQueries(roleName, empty, added, true)...)
+		}
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := execAccessBundleQueries(tx, queries); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftAccessBundleRead(db, d)
+}
+
+func resourceRedshiftAccessBundleDelete(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(accessBundleRoleAttr).(string)
+	privileges := accessBundleTablePrivileges(d.Get(accessBundleTablePrivilegesAttr).(*schema.Set))
+	owner := d.Get(accessBundleDefaultPrivilegesOwnerAttr).(string)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	var exists bool
+	query := "SELECT EXISTS(SELECT 1 FROM SVV_ROLES WHERE role_name = $1)"
+	if err := tx.QueryRow(query, d.Id()).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("[WARN] Role of access bundle %s does not exist.\n", d.Id())
+		return nil
+	}
+
+	queries := accessBundleMembershipQueries(roleName, d.Get(accessBundleGroupsAttr).(*schema.Set), d.Get(accessBundleUsersAttr).(*schema.Set), false)
+	for _, schemaName := range d.Get(accessBundleSchemasAttr).(*schema.Set).List() {
+		queries = append(queries, accessBundleRevokeQueries(roleName, schemaName.(string), privileges, owner)...)
+	}
+	queries = append(queries, fmt.Sprintf("DROP ROLE %s", pq.QuoteIdentifier(roleName)))
+
+	if err := execAccessBundleQueries(tx, queries); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func execAccessBundleQueries(tx *sql.Tx, queries []string) error {
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("error executing %q: %w", query, err)
+		}
+	}
+	return nil
+}
+
+func accessBundleTablePrivileges(privileges *schema.Set) []string {
+	result := make([]string, 0, privileges.Len())
+	for _, p := range privileges.List() {
+		result = append(result, strings.ToUpper(p.(string)))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func accessBundleDefaultPrivilegesPrefix(schemaName, owner string) string {
+	if owner == "" {
+		return fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s", pq.QuoteIdentifier(schemaName))
+	}
+	return fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR USER %s IN SCHEMA %s", pq.QuoteIdentifier(owner), pq.QuoteIdentifier(schemaName))
+}
+
+// accessBundleGrantQueries returns the statements granting the role access to a single schema.
+func accessBundleGrantQueries(roleName, schemaName string, privileges []string, owner string) []string {
+	role := pq.QuoteIdentifier(roleName)
+	queries := []string{
+		fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO ROLE %s", pq.QuoteIdentifier(schemaName), role),
+	}
+	if len(privileges) == 0 {
+		return queries
+	}
+
+	return append(queries,
+		fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA %s TO ROLE %s", strings.Join(privileges, ","), pq.QuoteIdentifier(schemaName), role),
+		fmt.Sprintf("%s GRANT %s ON TABLES TO ROLE %s", accessBundleDefaultPrivilegesPrefix(schemaName, owner), strings.Join(privileges, ","), role),
+	)
+}
+
+// accessBundleRevokeQueries returns the statements revoking the access of the role to a single schema,
+// in the reverse order of accessBundleGrantQueries.
+func accessBundleRevokeQueries(roleName, schemaName string, privileges []string, owner string) []string {
+	role := pq.QuoteIdentifier(roleName)
+	var queries []string
+	if len(privileges) > 0 {
+		queries = append(queries,
+			fmt.Sprintf("%s REVOKE ALL PRIVILEGES ON TABLES FROM ROLE %s", accessBundleDefaultPrivilegesPrefix(schemaName, owner), role),
+			fmt.Sprintf("REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA %s FROM ROLE %s", pq.QuoteIdentifier(schemaName), role),
+		)
+	}
+
+	return append(queries, fmt.Sprintf("REVOKE USAGE ON SCHEMA %s FROM ROLE %s", pq.QuoteIdentifier(schemaName), role))
+}
+
+// accessBundleMembershipQueries returns the statements granting (or revoking) the role to (or from) groups and users.
+func accessBundleMembershipQueries(roleName string, groups, users *schema.Set, grant bool) []string {
+	var queries []string
+	members := map[string]*schema.Set{"GROUP": groups, "USER": users}
+	for _, grantToType := range []string{"GROUP", "USER"} {
+		for _, name := range members[grantToType].List() {
+			if grant {
+				queries = append(queries, createRoleGrantQuery(roleName, grantToType, name.(string), false))
+			} else {
+				queries = append(queries, revokeRoleGrantQuery(roleName, grantToType, name.(string)))
+			}
+		}
+	}
+	return queries
+}
@@ -0,0 +1,174 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftAccessBundle_Lifecycle(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_bundle_role")
+	schemaNames := []string{
+		generateRandomObjectName("tf_acc_bundle_schema"),
+		generateRandomObjectName("tf_acc_bundle_schema"),
+	}
+	groupName := generateRandomObjectName("tf_acc_bundle_group")
+	userName := generateRandomObjectName("tf_acc_bundle_user")
+
+	configCreate := fmt.Sprintf(`
+resource "redshift_schema" "first" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "second" {
+  name = %[3]q
+}
+
+resource "redshift_group" "group" {
+  name = %[4]q
+}
+
+resource "redshift_user" "user" {
+  name = %[5]q
+}
+
+resource "redshift_access_bundle" "bundle" {
+  role             = %[1]q
+  schemas          = [redshift_schema.first.name]
+  table_privileges = ["select"]
+  groups           = [redshift_group.group.name]
+}
+`, roleName, schemaNames[0], schemaNames[1], groupName, userName)
+
+	configUpdate := fmt.Sprintf(`
+resource "redshift_schema" "first" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "second" {
+  name = %[3]q
+}
+
+resource "redshift_group" "group" {
+  name = %[4]q
+}
+
+resource "redshift_user" "user" {
+  name = %[5]q
+}
+
+resource "redshift_access_bundle" "bundle" {
+  role             = %[1]q
+  schemas          = [redshift_schema.second.name]
+  table_privileges = ["select", "insert"]
+  users            = [redshift_user.user.name]
+}
+`, roleName, schemaNames[0], schemaNames[1], groupName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "role", roleName),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "schemas.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_access_bundle.bundle", "schemas.*", schemaNames[0]),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_access_bundle.bundle", "groups.*", groupName),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "users.#", "0"),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "schemas.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_access_bundle.bundle", "schemas.*", schemaNames[1]),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "table_privileges.#", "2"),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "groups.#", "0"),
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_access_bundle.bundle", "users.*", userName),
+				),
+			},
+			{
+				// Privileges revoked outside of Terraform are detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					query := fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s REVOKE INSERT ON TABLES FROM ROLE %s", pq.QuoteIdentifier(schemaNames[1]), pq.QuoteIdentifier(roleName))
+					if _, err := db.Exec(query); err != nil {
+						t.Fatalf("could not revoke default privileges: %v", err)
+					}
+				},
+				Config:             configUpdate,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_access_bundle.bundle", "table_privileges.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "redshift_access_bundle.bundle",
+				ImportState:       true,
+				ImportStateId:     roleName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccessBundleQueries(t *testing.T) {
+	grants := accessBundleGrantQueries("analysts", "sales", []string{"INSERT", "SELECT"}, "etl")
+	expectedGrants := []string{
+		`GRANT USAGE ON SCHEMA "sales" TO ROLE "analysts"`,
+		`GRANT INSERT,SELECT ON ALL TABLES IN SCHEMA "sales" TO ROLE "analysts"`,
+		`ALTER DEFAULT PRIVILEGES FOR USER "etl" IN SCHEMA "sales" GRANT INSERT,SELECT ON TABLES TO ROLE "analysts"`,
+	}
+	if !reflect.DeepEqual(grants, expectedGrants) {
+		t.Errorf("Expected %v but got %v", expectedGrants, grants)
+	}
+
+	revokes := accessBundleRevokeQueries("analysts", "sales", []string{"SELECT"}, "")
+	expectedRevokes := []string{
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "sales" REVOKE ALL PRIVILEGES ON TABLES FROM ROLE "analysts"`,
+		`REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA "sales" FROM ROLE "analysts"`,
+		`REVOKE USAGE ON SCHEMA "sales" FROM ROLE "analysts"`,
+	}
+	if !reflect.DeepEqual(revokes, expectedRevokes) {
+		t.Errorf("Expected %v but got %v", expectedRevokes, revokes)
+	}
+
+	usageOnly := accessBundleGrantQueries("analysts", "sales", nil, "")
+	expectedUsageOnly := []string{`GRANT USAGE ON SCHEMA "sales" TO ROLE "analysts"`}
+	if !reflect.DeepEqual(usageOnly, expectedUsageOnly) {
+		t.Errorf("Expected %v but got %v", expectedUsageOnly, usageOnly)
+	}
+
+	memberships := accessBundleMembershipQueries(
+		"analysts",
+		schema.NewSet(schema.HashString, []interface{}{"bi"}),
+		schema.NewSet(schema.HashString, []interface{}{"bob"}),
+		false,
+	)
+	expectedMemberships := []string{
+		`REVOKE ROLE "analysts" FROM GROUP "bi"`,
+		`REVOKE ROLE "analysts" FROM "bob"`,
+	}
+	if !reflect.DeepEqual(memberships, expectedMemberships) {
+		t.Errorf("Expected %v but got %v", expectedMemberships, memberships)
+	}
+}
@@ -0,0 +1,322 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	assumeroleGrantIamRoleArnAttr = "iam_role_arn"
+	assumeroleGrantUserAttr       = "user"
+	assumeroleGrantGroupAttr      = "group"
+	assumeroleGrantRoleAttr       = "role"
+	assumeroleGrantCommandsAttr   = "for"
+)
+
+// The commands an IAM role can be assumed for, see https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html
+var assumeroleGrantAllowedCommands = []string{
+	"all",
+	"copy",
+	"unload",
+	"external function",
+	"create model",
+}
+
+var iamRoleArnRegexp = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/.+$`)
+
+func redshiftAssumeroleGrant() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Grants a user, group or role the permission to use an IAM role attached to the cluster in COPY, UNLOAD, EXTERNAL FUNCTION or CREATE MODEL commands.
+
+Once the ASSUMEROLE permission was granted to any principal, only the principals with the permission can use the IAM role.
+For more information, see [GRANT documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html).
+`,
+		CreateContext: ResourceFunc(resourceRedshiftAssumeroleGrantCreate),
+		ReadContext:   ResourceFunc(resourceRedshiftAssumeroleGrantRead),
+		UpdateContext: ResourceFunc(resourceRedshiftAssumeroleGrantUpdate),
+		DeleteContext: ResourceFunc(
+			ResourceRetryOnPQErrors(resourceRedshiftAssumeroleGrantDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftAssumeroleGrantImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			assumeroleGrantIamRoleArnAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ARN of the IAM role which may be assumed.",
+				ValidateFunc: validation.StringMatch(iamRoleArnRegexp, "must be the ARN of an IAM role"),
+			},
+			assumeroleGrantUserAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{assumeroleGrantUserAttr, assumeroleGrantGroupAttr, assumeroleGrantRoleAttr},
+				Description:  "The name of the user to grant the permission to. Exactly one of `user`, `group`, or `role` must be set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			assumeroleGrantGroupAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{assumeroleGrantUserAttr, assumeroleGrantGroupAttr, assumeroleGrantRoleAttr},
+				Description:  "The name of the group to grant the permission to. Exactly one of `user`, `group`, or `role` must be set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			assumeroleGrantRoleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{assumeroleGrantUserAttr, assumeroleGrantGroupAttr, assumeroleGrantRoleAttr},
+				Description:  "The name of the role to grant the permission to. Exactly one of `user`, `group`, or `role` must be set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			assumeroleGrantCommandsAttr: {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(assumeroleGrantAllowedCommands, true),
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:         schema.HashString,
+				Description: "The commands the IAM role may be assumed for (one of: " + strings.Join(assumeroleGrantAllowedCommands, ", ") + "). `all` can't be combined with other commands.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftAssumeroleGrantCreate(db *DBConnection, d *schema.ResourceData) error {
+	commands := setToStringList(d.Get(assumeroleGrantCommandsAttr).(*schema.Set))
+	if err := validateAssumeroleCommands(commands); err != nil {
+		return err
+	}
+
+	if err := execAssumeroleGrantQueries(db, buildAssumeroleGrantQuery("GRANT", d, commands)); err != nil {
+		return fmt.Errorf("could not grant assumerole: %w", err)
+	}
+
+	d.SetId(generateAssumeroleGrantID(d))
+
+	return resourceRedshiftAssumeroleGrantRead(db, d)
+}
+
+func resourceRedshiftAssumeroleGrantRead(db *DBConnection, d *schema.ResourceData) error {
+	identityType, identityName := assumeroleGrantee(d)
+
+	query := `
+SELECT LOWER(command_type)
+FROM svv_iam_privileges
+WHERE iam_arn = $1 AND identity_type = $2 AND identity_name = $3`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s, $3=%s\n", query, d.Get(assumeroleGrantIamRoleArnAttr).(string), identityType, identityName)
+	rows, err := db.Query(query, d.Get(assumeroleGrantIamRoleArnAttr).(string), identityType, identityName)
+	if err != nil {
+		return fmt.Errorf("error reading assumerole grant: %w", err)
+	}
+	defer rows.Close()
+
+	granted := schema.NewSet(schema.HashString, nil)
+	for rows.Next() {
+		var command string
+		if err := rows.Scan(&command); err != nil {
+			return err
+		}
+		granted.Add(command)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if granted.Len() == 0 {
+		log.Printf("[WARN] Assumerole grant of %s to %s %s not found", d.Get(assumeroleGrantIamRoleArnAttr).(string), identityType, identityName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(assumeroleGrantCommandsAttr, reconcileAllAssumeroleCommands(d.Get(assumeroleGrantCommandsAttr).(*schema.Set), granted))
+
+	return nil
+}
+
+func resourceRedshiftAssumeroleGrantUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(assumeroleGrantCommandsAttr) {
+		return resourceRedshiftAssumeroleGrantRead(db, d)
+	}
+
+	oldRaw, newRaw := d.GetChange(assumeroleGrantCommandsAttr)
+	newCommands := setToStringList(newRaw.(*schema.Set))
+	if err := validateAssumeroleCommands(newCommands); err != nil {
+		return err
+	}
+
+	// Revoke everything which was granted before, so switching from `all` to single commands works
+	if err := execAssumeroleGrantQueries(db,
+		buildAssumeroleGrantQuery("REVOKE", d, setToStringList(oldRaw.(*schema.Set))),
+		buildAssumeroleGrantQuery("GRANT", d, newCommands),
+	); err != nil {
+		return fmt.Errorf("could not update assumerole grant: %w", err)
+	}
+
+	return resourceRedshiftAssumeroleGrantRead(db, d)
+}
+
+func resourceRedshiftAssumeroleGrantDelete(db *DBConnection, d *schema.ResourceData) error {
+	commands := setToStringList(d.Get(assumeroleGrantCommandsAttr).(*schema.Set))
+	if err := execAssumeroleGrantQueries(db, buildAssumeroleGrantQuery("REVOKE", d, commands)); err != nil {
+		// The grant is gone together with the principal
+		if strings.Contains(err.Error(), "does not exist") {
+			log.Printf("[WARN] Grantee does not exist, assumerole grant already removed: %v", err)
+			return nil
+		}
+		return fmt.Errorf("could not revoke assumerole: %w", err)
+	}
+
+	return nil
+}
+
+func resourceRedshiftAssumeroleGrantImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	granteeAttr, grantee, iamRoleArn, err := parseAssumeroleGrantID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set(granteeAttr, grantee)
+	d.Set(assumeroleGrantIamRoleArnAttr, iamRoleArn)
+	// The commands are read from the catalog
+	d.Set(assumeroleGrantCommandsAttr, []string{})
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func execAssumeroleGrantQueries(db *DBConnection, queries ...string) error {
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildAssumeroleGrantQuery returns the GRANT or REVOKE ASSUMEROLE statement for the commands.
+func buildAssumeroleGrantQuery(action string, d *schema.ResourceData, commands []string) string {
+	sort.Strings(commands)
+	for i, command := range commands {
+		commands[i] = strings.ToUpper(command)
+	}
+
+	var grantee string
+	switch identityType, identityName := assumeroleGrantee(d); identityType {
+	case "user":
+		grantee = pq.QuoteIdentifier(identityName)
+	default:
+		grantee = fmt.Sprintf("%s %s", strings.ToUpper(identityType), pq.QuoteIdentifier(identityName))
+	}
+
+	preposition := "TO"
+	if action == "REVOKE" {
+		preposition = "FROM"
+	}
+
+	return fmt.Sprintf("%s ASSUMEROLE ON '%s' %s %s FOR %s",
+		action,
+		pqQuoteLiteral(d.Get(assumeroleGrantIamRoleArnAttr).(string)),
+		preposition,
+		grantee,
+		strings.Join(commands, ", "))
+}
+
+// assumeroleGrantee returns the identity type and name of the grantee, as listed in svv_iam_privileges.
+func assumeroleGrantee(d *schema.ResourceData) (string, string) {
+	if groupName, ok := d.GetOk(assumeroleGrantGroupAttr); ok {
+		return "group", strings.ToLower(groupName.(string))
+	}
+	if roleName, ok := d.GetOk(assumeroleGrantRoleAttr); ok {
+		return "role", strings.ToLower(roleName.(string))
+	}
+	return "user", strings.ToLower(d.Get(assumeroleGrantUserAttr).(string))
+}
+
+func validateAssumeroleCommands(commands []string) error {
+	if len(commands) > 1 {
+		for _, command := range commands {
+			if strings.EqualFold(command, "all") {
+				return fmt.Errorf("`all` can't be combined with other commands in %q", assumeroleGrantCommandsAttr)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileAllAssumeroleCommands keeps `all` in the state when it was configured and every
+// command is granted, as svv_iam_privileges may list the single commands instead.
+func reconcileAllAssumeroleCommands(configured, granted *schema.Set) *schema.Set {
+	if !configured.Contains("all") || granted.Contains("all") {
+		return granted
+	}
+	for _, command := range assumeroleGrantAllowedCommands[1:] {
+		if !granted.Contains(command) {
+			return granted
+		}
+	}
+	return schema.NewSet(schema.HashString, []interface{}{"all"})
+}
+
+func generateAssumeroleGrantID(d *schema.ResourceData) string {
+	identityType, identityName := assumeroleGrantee(d)
+	return fmt.Sprintf("%sn:%s_ar:%s", identityType[:1], identityName, d.Get(assumeroleGrantIamRoleArnAttr).(string))
+}
+
+// parseAssumeroleGrantID parses the ID `{un|gn|rn}:<name>_ar:<iam role arn>`.
+func parseAssumeroleGrantID(id string) (string, string, string, error) {
+	grantee, iamRoleArn, found := strings.Cut(id, "_ar:")
+	if !found || !iamRoleArnRegexp.MatchString(iamRoleArn) {
+		return "", "", "", fmt.Errorf("invalid assumerole grant ID %q, expected format is {un|gn|rn}:<name>_ar:<iam role arn>", id)
+	}
+
+	prefix, name, found := strings.Cut(grantee, ":")
+	if !found || name == "" {
+		return "", "", "", fmt.Errorf("invalid assumerole grant ID %q, expected format is {un|gn|rn}:<name>_ar:<iam role arn>", id)
+	}
+
+	switch prefix {
+	case "un":
+		return assumeroleGrantUserAttr, name, iamRoleArn, nil
+	case "gn":
+		return assumeroleGrantGroupAttr, name, iamRoleArn, nil
+	case "rn":
+		return assumeroleGrantRoleAttr, name, iamRoleArn, nil
+	}
+	return "", "", "", fmt.Errorf("invalid assumerole grant ID %q, expected format is {un|gn|rn}:<name>_ar:<iam role arn>", id)
+}
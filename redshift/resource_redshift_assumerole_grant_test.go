@@ -0,0 +1,156 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccRedshiftAssumeroleGrant_Basic(t *testing.T) {
+	iamRoleArn := getEnvOrSkip("REDSHIFT_ASSUMEROLE_IAM_ROLE_ARN", t)
+	userName := generateRandomObjectName("tf_acc_assumerole_user")
+	roleName := generateRandomObjectName("tf_acc_assumerole_role")
+
+	config := func(userCommands string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_role" "role" {
+  name = %[2]q
+}
+
+resource "redshift_assumerole_grant" "user" {
+  iam_role_arn = %[3]q
+  user         = redshift_user.user.name
+  for          = %[4]s
+}
+
+resource "redshift_assumerole_grant" "role" {
+  iam_role_arn = %[3]q
+  role         = redshift_role.role.name
+  for          = ["all"]
+}
+`, userName, roleName, iamRoleArn, userCommands)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["COPY"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_assumerole_grant.user", "for.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_assumerole_grant.user", "for.*", "copy"),
+					resource.TestCheckResourceAttr("redshift_assumerole_grant.role", "for.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_assumerole_grant.role", "for.*", "all"),
+				),
+			},
+			{
+				Config: config(`["copy", "unload"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_assumerole_grant.user", "for.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_assumerole_grant.user", "for.*", "copy"),
+					resource.TestCheckTypeSetElemAttr("redshift_assumerole_grant.user", "for.*", "unload"),
+				),
+			},
+			{
+				ResourceName:      "redshift_assumerole_grant.user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftAssumeroleGrant_AllWithOtherCommands(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "redshift_assumerole_grant" "user" {
+  iam_role_arn = "arn:aws:iam::123456789012:role/redshift-copy"
+  user         = "some_user"
+  for          = ["all", "copy"]
+}
+`,
+				ExpectError: regexp.MustCompile("`all` can't be combined with other commands"),
+			},
+		},
+	})
+}
+
+func TestBuildAssumeroleGrantQuery(t *testing.T) {
+	r := redshiftAssumeroleGrant()
+	for _, tc := range []struct {
+		raw      map[string]interface{}
+		action   string
+		commands []string
+		expected string
+	}{
+		{
+			map[string]interface{}{"iam_role_arn": "arn:aws:iam::123456789012:role/copy", "user": "etl"},
+			"GRANT", []string{"unload", "copy"},
+			`GRANT ASSUMEROLE ON 'arn:aws:iam::123456789012:role/copy' TO "etl" FOR COPY, UNLOAD`,
+		},
+		{
+			map[string]interface{}{"iam_role_arn": "arn:aws:iam::123456789012:role/copy", "group": "loaders"},
+			"REVOKE", []string{"all"},
+			`REVOKE ASSUMEROLE ON 'arn:aws:iam::123456789012:role/copy' FROM GROUP "loaders" FOR ALL`,
+		},
+		{
+			map[string]interface{}{"iam_role_arn": "arn:aws:iam::123456789012:role/ml", "role": "analysts"},
+			"GRANT", []string{"create model"},
+			`GRANT ASSUMEROLE ON 'arn:aws:iam::123456789012:role/ml' TO ROLE "analysts" FOR CREATE MODEL`,
+		},
+	} {
+		d := schema.TestResourceDataRaw(t, r.Schema, tc.raw)
+		if got := buildAssumeroleGrantQuery(tc.action, d, tc.commands); got != tc.expected {
+			t.Errorf("Expected %q but got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestReconcileAllAssumeroleCommands(t *testing.T) {
+	all := schema.NewSet(schema.HashString, []interface{}{"all"})
+	everyCommand := schema.NewSet(schema.HashString, []interface{}{"copy", "unload", "external function", "create model"})
+	someCommands := schema.NewSet(schema.HashString, []interface{}{"copy"})
+
+	if got := reconcileAllAssumeroleCommands(all, everyCommand); !got.Equal(all) {
+		t.Errorf("Expected %v but got %v", all.List(), got.List())
+	}
+	if got := reconcileAllAssumeroleCommands(all, someCommands); !got.Equal(someCommands) {
+		t.Errorf("Expected %v but got %v", someCommands.List(), got.List())
+	}
+	if got := reconcileAllAssumeroleCommands(someCommands, everyCommand); !got.Equal(everyCommand) {
+		t.Errorf("Expected %v but got %v", everyCommand.List(), got.List())
+	}
+}
+
+func TestParseAssumeroleGrantID(t *testing.T) {
+	granteeAttr, grantee, iamRoleArn, err := parseAssumeroleGrantID("gn:loaders_ar:arn:aws:iam::123456789012:role/copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if granteeAttr != assumeroleGrantGroupAttr || grantee != "loaders" || iamRoleArn != "arn:aws:iam::123456789012:role/copy" {
+		t.Errorf("Unexpected result %q, %q, %q", granteeAttr, grantee, iamRoleArn)
+	}
+
+	for _, id := range []string{
+		"loaders",
+		"gn:loaders_ar:not-an-arn",
+		"xn:loaders_ar:arn:aws:iam::123456789012:role/copy",
+		"gn:_ar:arn:aws:iam::123456789012:role/copy",
+	} {
+		if _, _, _, err := parseAssumeroleGrantID(id); err == nil {
+			t.Errorf("Expected an error for ID %q", id)
+		}
+	}
+}
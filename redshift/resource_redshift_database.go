@@ -2,11 +2,13 @@ package redshift
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
@@ -20,6 +22,23 @@ const databaseDatashareSourceShareNameAttr = "share_name"
 const databaseDatashareSourceNamespaceAttr = "namespace"
 const databaseDatashareSourceAccountAttr = "account_id"
 const databaseDatashareSourceWithPermissions = "with_permissions"
+const databaseSearchPathAttr = "search_path"
+const databaseTypeAttr = "database_type"
+const databaseIntegrationSourceAttr = "integration_source"
+const databaseIntegrationSourceIdAttr = "integration_id"
+const databaseIntegrationSourceDatabaseAttr = "source_database"
+const databaseCollationAttr = "collation"
+const databaseIsolationLevelAttr = "isolation_level"
+
+const (
+	databaseCollationCaseSensitive   = "case_sensitive"
+	databaseCollationCaseInsensitive = "case_insensitive"
+)
+
+const (
+	databaseIsolationLevelSerializable = "serializable"
+	databaseIsolationLevelSnapshot     = "snapshot"
+)
 
 func redshiftDatabase() *schema.Resource {
 	return &schema.Resource{
@@ -31,7 +50,10 @@ func redshiftDatabase() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: forceNewIfListSizeChanged(databaseDatashareSourceAttr),
+		CustomizeDiff: customdiff.All(
+			forceNewIfListSizeChanged(databaseDatashareSourceAttr),
+			forceNewIfListSizeChanged(databaseIntegrationSourceAttr),
+		),
 		Schema: map[string]*schema.Schema{
 			databaseNameAttr: {
 				Type:        schema.TypeString,
@@ -54,11 +76,49 @@ func redshiftDatabase() *schema.Resource {
 				Default:      -1,
 				ValidateFunc: validation.IntAtLeast(-1),
 			},
+			databaseSearchPathAttr: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Description: "The default schema search path of the database, in order of precedence. Use `$user` to refer to the schema with the same name as the current user. An empty list resets the search path to the cluster default.",
+			},
+			databaseCollationAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The collation of the database, either `case_sensitive` or `case_insensitive`. The collation can't be altered, changing it recreates the database. Databases created from a datashare or an integration use the collation of their source.",
+				ValidateFunc: validation.StringInSlice([]string{databaseCollationCaseSensitive, databaseCollationCaseInsensitive}, false),
+				ConflictsWith: []string{
+					databaseDatashareSourceAttr,
+					databaseIntegrationSourceAttr,
+				},
+			},
+			databaseIsolationLevelAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The isolation level of the database, either `serializable` or `snapshot`. Changing it recreates the database.",
+				ValidateFunc: validation.StringInSlice([]string{databaseIsolationLevelSerializable, databaseIsolationLevelSnapshot}, false),
+				ConflictsWith: []string{
+					databaseDatashareSourceAttr,
+					databaseIntegrationSourceAttr,
+				},
+			},
 			databaseDatashareSourceAttr: {
 				Type:        schema.TypeList,
 				Optional:    true,
 				MaxItems:    1,
-				Description: "Configuration for creating a database from a redshift datashare.",
+				Description: "Configuration for creating a database from an inbound redshift datashare, shared by another cluster or namespace in the same or another account. Datashares shared across regions are consumed the same way, the producer is identified by its namespace.",
+				ConflictsWith: []string{
+					databaseIntegrationSourceAttr,
+				},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						databaseDatashareSourceShareNameAttr: {
@@ -97,34 +157,59 @@ func redshiftDatabase() *schema.Resource {
 					},
 				},
 			},
+			databaseIntegrationSourceAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration for creating the target database of a zero-ETL integration. The integration must be active before the database can be created.",
+				ConflictsWith: []string{
+					databaseDatashareSourceAttr,
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						databaseIntegrationSourceIdAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The ID of the zero-ETL integration, as listed in `svv_integration`.",
+							ValidateFunc: validation.StringMatch(uuidRegex, "Integration ID must be a guid"),
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						databaseIntegrationSourceDatabaseAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The database of the integration source to replicate, required for sources with several databases like Aurora PostgreSQL. It isn't read back.",
+						},
+					},
+				},
+			},
+			databaseTypeAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the database as listed in `svv_redshift_databases`, e.g. `local` or `shared` for databases created from a datashare.",
+			},
 		},
 	}
 }
 
 func resourceRedshiftDatabaseCreate(db *DBConnection, d *schema.ResourceData) error {
 	if _, isDataShare := d.GetOk(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr)); isDataShare {
-		return resourceRedshiftDatabaseCreateFromDatashare(db, d)
+		return resourceRedshiftDatabaseCreateFromSource(db, d, createDatabaseFromDatashareQuery(d))
+	}
+	if _, isIntegration := d.GetOk(fmt.Sprintf("%s.0.%s", databaseIntegrationSourceAttr, databaseIntegrationSourceIdAttr)); isIntegration {
+		return resourceRedshiftDatabaseCreateFromSource(db, d, createDatabaseFromIntegrationQuery(d))
 	}
 	return resourceRedshiftDatabaseCreateInternal(db, d)
 }
 
-func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.ResourceData) error {
+// resourceRedshiftDatabaseCreateFromSource creates a database from a datashare or an integration,
+// whose CREATE DATABASE statements don't accept the other database options.
+func resourceRedshiftDatabaseCreateFromSource(db *DBConnection, d *schema.ResourceData, query string) error {
 	dbName := d.Get(databaseNameAttr).(string)
-	query := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(dbName))
-
-	if d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceWithPermissions)).(bool) {
-		query = fmt.Sprintf("%s WITH PERMISSIONS", query)
-	}
-
-	shareName := d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr)).(string)
-	query = fmt.Sprintf("%s FROM DATASHARE %s OF", query, pq.QuoteIdentifier(shareName))
-
-	if sourceAccount, ok := d.GetOk(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceAccountAttr)); ok {
-		query = fmt.Sprintf("%s ACCOUNT '%s'", query, pqQuoteLiteral(sourceAccount.(string)))
-	}
-	namespace := d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceNamespaceAttr))
-	query = fmt.Sprintf("%s NAMESPACE '%s'", query, pqQuoteLiteral(namespace.(string)))
-
+	log.Printf("[DEBUG] create database %s: %s\n", dbName, query)
 	if _, err := db.Exec(query); err != nil {
 		return err
 	}
@@ -140,13 +225,13 @@ func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.Res
 
 	// CREATE DATABASE isn't allowed to run inside a transaction, however ALTER DATABASE
 	// can be
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
-	// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify an owner in the create statement,
+	// CREATE DATABASE FROM DATASHARE|INTEGRATION... doesn't allow you to specify an owner in the create statement,
 	// so we need to set the owner after creation using ALTER DATABASE...
 	owner, ownerIsSet := d.GetOk(databaseOwnerAttr)
 	if ownerIsSet {
@@ -155,7 +240,7 @@ func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.Res
 		}
 	}
 
-	// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify the connection limit in the create statement,
+	// CREATE DATABASE FROM DATASHARE|INTEGRATION... doesn't allow you to specify the connection limit in the create statement,
 	// so we need to set the owner after creation using ALTER DATABASE...
 	connLimit, connLimitIsSet := d.GetOk(databaseConnLimitAttr)
 	if connLimitIsSet {
@@ -170,6 +255,37 @@ func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.Res
 	return resourceRedshiftDatabaseRead(db, d)
 }
 
+// createDatabaseFromDatashareQuery returns the statement creating the database from the inbound datashare.
+// The producer is identified by its namespace, and its account for datashares shared across accounts.
+func createDatabaseFromDatashareQuery(d *schema.ResourceData) string {
+	dbName := d.Get(databaseNameAttr).(string)
+	query := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(dbName))
+
+	if d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceWithPermissions)).(bool) {
+		query = fmt.Sprintf("%s WITH PERMISSIONS", query)
+	}
+
+	shareName := d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr)).(string)
+	query = fmt.Sprintf("%s FROM DATASHARE %s OF", query, pq.QuoteIdentifier(shareName))
+
+	if sourceAccount, ok := d.GetOk(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceAccountAttr)); ok {
+		query = fmt.Sprintf("%s ACCOUNT '%s'", query, pqQuoteLiteral(sourceAccount.(string)))
+	}
+	namespace := d.Get(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceNamespaceAttr))
+	return fmt.Sprintf("%s NAMESPACE '%s'", query, pqQuoteLiteral(namespace.(string)))
+}
+
+// createDatabaseFromIntegrationQuery returns the statement creating the target database of the zero-ETL integration.
+func createDatabaseFromIntegrationQuery(d *schema.ResourceData) string {
+	dbName := d.Get(databaseNameAttr).(string)
+	integrationId := d.Get(fmt.Sprintf("%s.0.%s", databaseIntegrationSourceAttr, databaseIntegrationSourceIdAttr)).(string)
+	query := fmt.Sprintf("CREATE DATABASE %s FROM INTEGRATION '%s'", pq.QuoteIdentifier(dbName), pqQuoteLiteral(integrationId))
+	if sourceDatabase, ok := d.GetOk(fmt.Sprintf("%s.0.%s", databaseIntegrationSourceAttr, databaseIntegrationSourceDatabaseAttr)); ok {
+		query = fmt.Sprintf("%s DATABASE %s", query, pq.QuoteIdentifier(sourceDatabase.(string)))
+	}
+	return query
+}
+
 func resourceRedshiftDatabaseCreateInternal(db *DBConnection, d *schema.ResourceData) error {
 	dbName := d.Get(databaseNameAttr).(string)
 	query := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(dbName))
@@ -180,6 +296,12 @@ func resourceRedshiftDatabaseCreateInternal(db *DBConnection, d *schema.Resource
 	if v, ok := d.GetOk(databaseConnLimitAttr); ok {
 		query = fmt.Sprintf("%s CONNECTION LIMIT %d", query, v.(int))
 	}
+	if v, ok := d.GetOk(databaseCollationAttr); ok {
+		query = fmt.Sprintf("%s COLLATE %s", query, strings.ToUpper(v.(string)))
+	}
+	if v, ok := d.GetOk(databaseIsolationLevelAttr); ok {
+		query = fmt.Sprintf("%s ISOLATION LEVEL %s", query, strings.ToUpper(v.(string)))
+	}
 	log.Printf("[DEBUG] create database %s: %s\n", dbName, query)
 	if _, err := db.Exec(query); err != nil {
 		return err
@@ -194,17 +316,27 @@ func resourceRedshiftDatabaseCreateInternal(db *DBConnection, d *schema.Resource
 
 	d.SetId(oid)
 
+	if searchPath := getDatabaseSearchPath(d); len(searchPath) > 0 {
+		query = buildDatabaseSearchPathQuery(dbName, searchPath)
+		log.Printf("[DEBUG] set database search_path: %s\n", query)
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+
 	return resourceRedshiftDatabaseRead(db, d)
 }
 
 func resourceRedshiftDatabaseRead(db *DBConnection, d *schema.ResourceData) error {
-	var name, owner, connLimit, databaseType, shareName, producerAccount, producerNamespace string
+	var name, owner, connLimit, databaseType, databaseOptions, isolationLevel, shareName, producerAccount, producerNamespace string
 
 	query := `SELECT
   TRIM(svv_redshift_databases.database_name),
   TRIM(pg_user_info.usename),
   COALESCE(pg_database_info.datconnlimit::text, 'UNLIMITED'),
 	svv_redshift_databases.database_type,
+  COALESCE(svv_redshift_databases.database_options, ''),
+  COALESCE(svv_redshift_databases.database_isolation_level, ''),
   TRIM(COALESCE(svv_datashares.share_name, '')),
   TRIM(COALESCE(svv_datashares.producer_account, '')),
   TRIM(COALESCE(svv_datashares.producer_namespace, ''))
@@ -219,7 +351,7 @@ LEFT JOIN svv_datashares
 WHERE pg_database_info.datid = $1
 `
 	log.Printf("[DEBUG] read database: %s\n", query)
-	err := db.QueryRow(query, d.Id()).Scan(&name, &owner, &connLimit, &databaseType, &shareName, &producerAccount, &producerNamespace)
+	err := db.QueryRow(query, d.Id()).Scan(&name, &owner, &connLimit, &databaseType, &databaseOptions, &isolationLevel, &shareName, &producerAccount, &producerNamespace)
 
 	if err != nil {
 		return err
@@ -235,6 +367,8 @@ WHERE pg_database_info.datid = $1
 	d.Set(databaseNameAttr, name)
 	d.Set(databaseOwnerAttr, owner)
 	d.Set(databaseConnLimitAttr, connLimitNumber)
+	d.Set(databaseCollationAttr, parseDatabaseCollation(databaseOptions))
+	d.Set(databaseIsolationLevelAttr, parseDatabaseIsolationLevel(isolationLevel))
 
 	dataShareConfiguration := make([]map[string]interface{}, 0, 1)
 	if databaseType == "shared" {
@@ -246,12 +380,117 @@ WHERE pg_database_info.datid = $1
 		dataShareConfiguration = append(dataShareConfiguration, config)
 	}
 	d.Set(databaseDatashareSourceAttr, dataShareConfiguration)
+	d.Set(databaseTypeAttr, databaseType)
+
+	if err := readDatabaseIntegrationSource(db, d, name, databaseType); err != nil {
+		return err
+	}
+
+	var rawConfig string
+	// Redshift has no pg_db_role_setting, the database settings are stored in pg_database.datconfig
+	query = "SELECT COALESCE(array_to_string(datconfig, '|'), '') FROM pg_database WHERE oid = $1"
+	log.Printf("[DEBUG] read database settings: %s\n", query)
+	err = db.QueryRow(query, d.Id()).Scan(&rawConfig)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	d.Set(databaseSearchPathAttr, parseSearchPathSetting(rawConfig))
 
 	return nil
 }
 
+// readDatabaseIntegrationSource reads the zero-ETL integration replicating into the database.
+// svv_integration is only queried for databases which aren't local or shared, or configured with an integration.
+func readDatabaseIntegrationSource(db *DBConnection, d *schema.ResourceData, databaseName, databaseType string) error {
+	_, hasIntegration := d.GetOk(databaseIntegrationSourceAttr)
+	if !hasIntegration && (databaseType == "local" || databaseType == "shared") {
+		d.Set(databaseIntegrationSourceAttr, nil)
+		return nil
+	}
+
+	var integrationId string
+	query := "SELECT TRIM(integration_id) FROM svv_integration WHERE target_database = $1"
+	log.Printf("[DEBUG] read database integration: %s\n", query)
+	err := db.QueryRow(query, databaseName).Scan(&integrationId)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		d.Set(databaseIntegrationSourceAttr, nil)
+		return nil
+	case err != nil:
+		return fmt.Errorf("error reading integration of database %s: %w", databaseName, err)
+	}
+
+	d.Set(databaseIntegrationSourceAttr, []map[string]interface{}{
+		{
+			databaseIntegrationSourceIdAttr:       strings.ToLower(integrationId),
+			databaseIntegrationSourceDatabaseAttr: d.Get(fmt.Sprintf("%s.0.%s", databaseIntegrationSourceAttr, databaseIntegrationSourceDatabaseAttr)).(string),
+		},
+	})
+	return nil
+}
+
+// parseDatabaseCollation extracts the collation from the database options listed in
+// `svv_redshift_databases`, which mention the collation only for case insensitive databases.
+func parseDatabaseCollation(databaseOptions string) string {
+	if strings.Contains(strings.ToLower(databaseOptions), databaseCollationCaseInsensitive) {
+		return databaseCollationCaseInsensitive
+	}
+	return databaseCollationCaseSensitive
+}
+
+// parseDatabaseIsolationLevel maps the isolation level listed in `svv_redshift_databases`,
+// e.g. `Snapshot Isolation` or `Serializable`, to the value of the isolation_level attribute.
+func parseDatabaseIsolationLevel(isolationLevel string) string {
+	if strings.Contains(strings.ToLower(isolationLevel), databaseIsolationLevelSnapshot) {
+		return databaseIsolationLevelSnapshot
+	}
+	return databaseIsolationLevelSerializable
+}
+
+func getDatabaseSearchPath(d *schema.ResourceData) []string {
+	var searchPath []string
+	for _, schemaName := range d.Get(databaseSearchPathAttr).([]interface{}) {
+		searchPath = append(searchPath, schemaName.(string))
+	}
+	return searchPath
+}
+
+func buildDatabaseSearchPathQuery(databaseName string, searchPath []string) string {
+	if len(searchPath) == 0 {
+		return fmt.Sprintf("ALTER DATABASE %s RESET search_path", pq.QuoteIdentifier(databaseName))
+	}
+
+	return fmt.Sprintf("ALTER DATABASE %s SET search_path TO %s", pq.QuoteIdentifier(databaseName), quoteSearchPath(searchPath))
+}
+
+// quoteSearchPath returns the search path as the comma separated list of quoted schema names.
+func quoteSearchPath(searchPath []string) string {
+	quoted := make([]string, len(searchPath))
+	for i, schemaName := range searchPath {
+		quoted[i] = pq.QuoteIdentifier(strings.ToLower(schemaName))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// parseSearchPathSetting extracts the search path from the database settings, which were
+// serialized using array_to_string(datconfig, '|'), e.g. `search_path="$user", public|datestyle=ISO`.
+func parseSearchPathSetting(rawConfig string) []string {
+	searchPath := make([]string, 0)
+	value, ok := parseParameterSettings(rawConfig)[databaseSearchPathAttr]
+	if !ok {
+		return searchPath
+	}
+	for _, schemaName := range strings.Split(value, ",") {
+		schemaName = strings.Trim(strings.TrimSpace(schemaName), `"`)
+		if schemaName != "" {
+			searchPath = append(searchPath, schemaName)
+		}
+	}
+	return searchPath
+}
+
 func resourceRedshiftDatabaseUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -269,6 +508,10 @@ func resourceRedshiftDatabaseUpdate(db *DBConnection, d *schema.ResourceData) er
 		return err
 	}
 
+	if err := setDatabaseSearchPath(tx, d); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -325,9 +568,22 @@ func setDatabaseConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 	return err
 }
 
+func setDatabaseSearchPath(tx *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(databaseSearchPathAttr) {
+		return nil
+	}
+
+	query := buildDatabaseSearchPathQuery(d.Get(databaseNameAttr).(string), getDatabaseSearchPath(d))
+	log.Printf("[DEBUG] changing database search_path: %s\n", query)
+	_, err := tx.Exec(query)
+	return err
+}
+
 func resourceRedshiftDatabaseDelete(db *DBConnection, d *schema.ResourceData) error {
 	databaseName := d.Get(databaseNameAttr).(string)
 
+	releaseConnections(databaseName)
+
 	query := fmt.Sprintf("DROP DATABASE %s", pqQuoteLiteral(databaseName))
 	log.Printf("[DEBUG] dropping database %s: %s\n", databaseName, query)
 	_, err := db.Exec(query)
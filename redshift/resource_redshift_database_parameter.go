@@ -0,0 +1,246 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	databaseParameterDatabaseAttr  = "database"
+	databaseParameterParameterAttr = "parameter"
+	databaseParameterValueAttr     = "value"
+)
+
+var parameterNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func redshiftDatabaseParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the default value of a configuration parameter for a database, set with ` + "`ALTER DATABASE ... SET`" + `.
+The value applies to new sessions connected to the database, unless it is overridden for the user or in the session.
+Removing the resource resets the parameter to the cluster default.
+
+Note: the ` + "`search_path`" + ` parameter conflicts with the ` + "`search_path`" + ` attribute of the ` + "`redshift_database`" + ` resource.
+`,
+		CreateContext: ResourceFunc(resourceRedshiftDatabaseParameterCreate),
+		ReadContext:   ResourceFunc(resourceRedshiftDatabaseParameterRead),
+		UpdateContext: ResourceFunc(resourceRedshiftDatabaseParameterUpdate),
+		DeleteContext: ResourceFunc(
+			ResourceRetryOnPQErrors(resourceRedshiftDatabaseParameterDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			databaseParameterDatabaseAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the database.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			databaseParameterParameterAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the configuration parameter, e.g. `search_path`, `datestyle` or `enable_case_sensitive_identifier`.",
+				ValidateFunc: validation.StringMatch(parameterNameRegexp, "must be a valid configuration parameter name"),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			databaseParameterValueAttr: {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The value of the configuration parameter. Parameters which take a list of values, like `search_path`, " +
+					"are given as a comma separated list, e.g. `$user, public`.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				StateFunc: func(val interface{}) string {
+					return normalizeParameterValue(val.(string))
+				},
+			},
+		},
+	}
+}
+
+func resourceRedshiftDatabaseParameterCreate(db *DBConnection, d *schema.ResourceData) error {
+	databaseName := d.Get(databaseParameterDatabaseAttr).(string)
+	parameter := strings.ToLower(d.Get(databaseParameterParameterAttr).(string))
+
+	if err := setDatabaseParameter(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(generateParameterID(databaseName, parameter))
+
+	return resourceRedshiftDatabaseParameterRead(db, d)
+}
+
+func resourceRedshiftDatabaseParameterRead(db *DBConnection, d *schema.ResourceData) error {
+	databaseName, parameter, err := parseParameterID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var rawConfig string
+	// Redshift has no pg_db_role_setting, the database settings are stored in pg_database.datconfig
+	query := "SELECT COALESCE(array_to_string(datconfig, '|'), '') FROM pg_database WHERE datname = $1"
+	log.Printf("[DEBUG] read database settings: %s\n", query)
+	if err := db.QueryRow(query, databaseName).Scan(&rawConfig); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Redshift database (%s) not found", databaseName)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading database settings: %w", err)
+	}
+
+	value, ok := parseParameterSettings(rawConfig)[parameter]
+	if !ok {
+		log.Printf("[WARN] Parameter %s is not set for Redshift database (%s)", parameter, databaseName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(databaseParameterDatabaseAttr, databaseName)
+	d.Set(databaseParameterParameterAttr, parameter)
+	d.Set(databaseParameterValueAttr, normalizeParameterValue(value))
+
+	return nil
+}
+
+func resourceRedshiftDatabaseParameterUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setDatabaseParameter(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftDatabaseParameterRead(db, d)
+}
+
+func resourceRedshiftDatabaseParameterDelete(db *DBConnection, d *schema.ResourceData) error {
+	databaseName, parameter, err := parseParameterID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := fmt.Sprintf("ALTER DATABASE %s RESET %s", pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(parameter))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		// The parameter is gone together with the database
+		if isPqErrorWithCode(err, pqErrorCodeInvalidCatalog) {
+			log.Printf("[WARN] Redshift database (%s) does not exist, parameter already removed: %v", databaseName, err)
+			return nil
+		}
+		return fmt.Errorf("could not reset database parameter: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func setDatabaseParameter(db *DBConnection, d *schema.ResourceData) error {
+	query := buildDatabaseParameterQuery(
+		d.Get(databaseParameterDatabaseAttr).(string),
+		strings.ToLower(d.Get(databaseParameterParameterAttr).(string)),
+		d.Get(databaseParameterValueAttr).(string),
+	)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not set database parameter: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildDatabaseParameterQuery returns the ALTER DATABASE statement setting the parameter.
+func buildDatabaseParameterQuery(databaseName, parameter, value string) string {
+	return fmt.Sprintf("ALTER DATABASE %s SET %s TO %s",
+		pq.QuoteIdentifier(databaseName),
+		pq.QuoteIdentifier(parameter),
+		quoteParameterValue(parameter, value))
+}
+
+// listParameters are the configuration parameters which take a list of values.
+var listParameters = map[string]bool{
+	"search_path": true,
+}
+
+// quoteParameterValue quotes the value as a literal. The comma separated elements of list parameters like
+// `search_path` are quoted as separate literals, so they are stored as a list and not as a single element.
+func quoteParameterValue(parameter, value string) string {
+	if !listParameters[strings.ToLower(parameter)] {
+		return fmt.Sprintf("'%s'", pqQuoteLiteral(value))
+	}
+	elements := strings.Split(value, ",")
+	for i, element := range elements {
+		elements[i] = fmt.Sprintf("'%s'", pqQuoteLiteral(strings.Trim(strings.TrimSpace(element), `"`)))
+	}
+	return strings.Join(elements, ", ")
+}
+
+// parseParameterSettings parses the settings of a database or a user, which were serialized using
+// array_to_string(datconfig, '|') or array_to_string(useconfig, '|'), e.g. `search_path="$user", public|datestyle=ISO, MDY`.
+func parseParameterSettings(rawConfig string) map[string]string {
+	settings := make(map[string]string)
+	for _, setting := range strings.Split(rawConfig, "|") {
+		name, value, found := strings.Cut(setting, "=")
+		if !found {
+			continue
+		}
+		settings[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+	return settings
+}
+
+// normalizeParameterValue removes the quotes Redshift adds to the elements of list
+// parameters and the whitespace around the elements, e.g. `"$user",public` becomes `$user, public`.
+func normalizeParameterValue(value string) string {
+	elements := strings.Split(value, ",")
+	for i, element := range elements {
+		elements[i] = strings.Trim(strings.TrimSpace(element), `"`)
+	}
+	return strings.Join(elements, ", ")
+}
+
+func generateParameterID(name, parameter string) string {
+	return fmt.Sprintf("%s.%s", name, strings.ToLower(parameter))
+}
+
+// parseParameterID splits the ID `<database>.<parameter>` or `<user>.<parameter>`. Parameter names can't
+// contain a dot, so the last dot separates the database or user name, which may contain dots when quoted.
+func parseParameterID(id string) (string, string, error) {
+	idx := strings.LastIndex(id, ".")
+	if idx <= 0 || idx == len(id)-1 {
+		return "", "", fmt.Errorf("invalid parameter ID %q, expected format is <name>.<parameter>", id)
+	}
+	return id[:idx], id[idx+1:], nil
+}
@@ -0,0 +1,120 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRedshiftDatabaseParameter_Basic(t *testing.T) {
+	dbName := generateRandomObjectName("tf_acc_resource_db_parameter")
+
+	config := func(searchPath, dateStyle string) string {
+		return fmt.Sprintf(`
+resource "redshift_database" "db" {
+  name = %[1]q
+}
+
+resource "redshift_database_parameter" "search_path" {
+  database  = redshift_database.db.name
+  parameter = "search_path"
+  value     = %[2]q
+}
+
+resource "redshift_database_parameter" "datestyle" {
+  database  = redshift_database.db.name
+  parameter = "DateStyle"
+  value     = %[3]q
+}
+`, dbName, searchPath, dateStyle)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("$user,public", "ISO, MDY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_database_parameter.search_path", "id", generateParameterID(dbName, "search_path")),
+					resource.TestCheckResourceAttr("redshift_database_parameter.search_path", databaseParameterValueAttr, "$user, public"),
+					resource.TestCheckResourceAttr("redshift_database_parameter.datestyle", databaseParameterParameterAttr, "datestyle"),
+					resource.TestCheckResourceAttr("redshift_database_parameter.datestyle", databaseParameterValueAttr, "ISO, MDY"),
+				),
+			},
+			{
+				Config: config("public", "ISO, DMY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_database_parameter.search_path", databaseParameterValueAttr, "public"),
+					resource.TestCheckResourceAttr("redshift_database_parameter.datestyle", databaseParameterValueAttr, "ISO, DMY"),
+				),
+			},
+			{
+				ResourceName:      "redshift_database_parameter.search_path",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBuildDatabaseParameterQuery(t *testing.T) {
+	for _, tc := range []struct {
+		parameter string
+		value     string
+		expected  string
+	}{
+		{"search_path", `"$user", public`, `ALTER DATABASE "db" SET "search_path" TO '$user', 'public'`},
+		{"enable_case_sensitive_identifier", "true", `ALTER DATABASE "db" SET "enable_case_sensitive_identifier" TO 'true'`},
+		{"datestyle", "ISO, MDY", `ALTER DATABASE "db" SET "datestyle" TO 'ISO, MDY'`},
+		{"search_path", "it's", `ALTER DATABASE "db" SET "search_path" TO 'it''s'`},
+	} {
+		if got := buildDatabaseParameterQuery("db", tc.parameter, tc.value); got != tc.expected {
+			t.Errorf("Expected %q but got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestParseParameterSettings(t *testing.T) {
+	expected := map[string]string{
+		"search_path": `"$user", public`,
+		"datestyle":   "ISO, MDY",
+	}
+	if got := parseParameterSettings(`search_path="$user", public|DateStyle=ISO, MDY`); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v but got %v", expected, got)
+	}
+	if got := parseParameterSettings(""); len(got) != 0 {
+		t.Errorf("Expected no settings but got %v", got)
+	}
+}
+
+func TestNormalizeParameterValue(t *testing.T) {
+	for value, expected := range map[string]string{
+		`"$user",public`: "$user, public",
+		"ISO, MDY":       "ISO, MDY",
+		"true":           "true",
+	} {
+		if got := normalizeParameterValue(value); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+}
+
+func TestParseParameterID(t *testing.T) {
+	databaseName, parameter, err := parseParameterID("my.db.search_path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if databaseName != "my.db" || parameter != "search_path" {
+		t.Errorf("Expected database %q and parameter %q but got %q and %q", "my.db", "search_path", databaseName, parameter)
+	}
+
+	for _, id := range []string{"db", ".search_path", "db."} {
+		if _, _, err := parseParameterID(id); err == nil {
+			t.Errorf("Expected an error for ID %q", id)
+		}
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -26,6 +29,9 @@ func TestAccResourceRedshiftDatabase_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("redshift_database.db", databaseNameAttr, dbName),
 					resource.TestCheckResourceAttrSet("redshift_database.db", databaseOwnerAttr),
 					resource.TestCheckResourceAttrSet("redshift_database.db", databaseConnLimitAttr),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseTypeAttr, "local"),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseCollationAttr, databaseCollationCaseSensitive),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseIsolationLevelAttr, databaseIsolationLevelSerializable),
 				),
 			},
 			{
@@ -151,3 +157,370 @@ func checkDatabaseExists(client *Client, database string) (bool, error) {
 
 	return true, nil
 }
+
+func TestAccResourceRedshiftDatabase_SearchPath(t *testing.T) {
+	dbName := generateRandomObjectName("tf_acc_resource_search_path")
+
+	configSet := fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+	%[3]s = ["$user", "Public"]
+}
+`, databaseNameAttr, dbName, databaseSearchPathAttr)
+
+	configReset := fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+}
+`, databaseNameAttr, dbName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configSet,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", fmt.Sprintf("%s.#", databaseSearchPathAttr), "2"),
+					resource.TestCheckResourceAttr("redshift_database.db", fmt.Sprintf("%s.0", databaseSearchPathAttr), "$user"),
+					resource.TestCheckResourceAttr("redshift_database.db", fmt.Sprintf("%s.1", databaseSearchPathAttr), "public"),
+				),
+			},
+			{
+				// The search path is read back from the database settings
+				ResourceName:      "redshift_database.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: configReset,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_database.db", fmt.Sprintf("%s.#", databaseSearchPathAttr), "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceRedshiftDatabase_Collation(t *testing.T) {
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_resource_collation"), "-", "_")
+	config := func(collation string) string {
+		return fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+	%[3]s = %[4]q
+}
+`, databaseNameAttr, dbName, databaseCollationAttr, collation)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(databaseCollationCaseInsensitive),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseCollationAttr, databaseCollationCaseInsensitive),
+				),
+			},
+			{
+				ResourceName:      "redshift_database.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// the collation can't be altered, so the database is recreated
+			{
+				Config: config(databaseCollationCaseSensitive),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseCollationAttr, databaseCollationCaseSensitive),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDatabaseCollation(t *testing.T) {
+	tests := map[string]struct {
+		databaseOptions string
+		expected        string
+	}{
+		"no options": {
+			databaseOptions: "",
+			expected:        databaseCollationCaseSensitive,
+		},
+		"case insensitive": {
+			databaseOptions: `{"collation":"CASE_INSENSITIVE"}`,
+			expected:        databaseCollationCaseInsensitive,
+		},
+		"case sensitive": {
+			databaseOptions: `{"collation":"CASE_SENSITIVE"}`,
+			expected:        databaseCollationCaseSensitive,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseDatabaseCollation(tt.databaseOptions); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccResourceRedshiftDatabase_IsolationLevel(t *testing.T) {
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_resource_isolation"), "-", "_")
+	config := func(isolationLevel string) string {
+		return fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+	%[3]s = %[4]q
+}
+`, databaseNameAttr, dbName, databaseIsolationLevelAttr, isolationLevel)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(databaseIsolationLevelSnapshot),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseIsolationLevelAttr, databaseIsolationLevelSnapshot),
+				),
+			},
+			{
+				ResourceName:      "redshift_database.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(databaseIsolationLevelSerializable),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseIsolationLevelAttr, databaseIsolationLevelSerializable),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDatabaseIsolationLevel(t *testing.T) {
+	tests := map[string]struct {
+		isolationLevel string
+		expected       string
+	}{
+		"snapshot": {
+			isolationLevel: "Snapshot Isolation",
+			expected:       databaseIsolationLevelSnapshot,
+		},
+		"serializable": {
+			isolationLevel: "Serializable",
+			expected:       databaseIsolationLevelSerializable,
+		},
+		"unknown": {
+			isolationLevel: "",
+			expected:       databaseIsolationLevelSerializable,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseDatabaseIsolationLevel(tt.isolationLevel); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildDatabaseSearchPathQuery(t *testing.T) {
+	tests := map[string]struct {
+		searchPath []string
+		expected   string
+	}{
+		"set": {
+			searchPath: []string{"$user", "Public", "my schema"},
+			expected:   `ALTER DATABASE "db" SET search_path TO "$user", "public", "my schema"`,
+		},
+		"reset": {
+			searchPath: nil,
+			expected:   `ALTER DATABASE "db" RESET search_path`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := buildDatabaseSearchPathQuery("db", tt.searchPath); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseSearchPathSetting(t *testing.T) {
+	tests := map[string]struct {
+		rawConfig string
+		expected  []string
+	}{
+		"no settings": {
+			rawConfig: "",
+			expected:  []string{},
+		},
+		"canonicalized search path": {
+			rawConfig: `search_path="$user", public`,
+			expected:  []string{"$user", "public"},
+		},
+		"quoted schema with other settings": {
+			rawConfig: `datestyle=ISO, MDY|search_path="my schema",analytics`,
+			expected:  []string{"my schema", "analytics"},
+		},
+		"other settings only": {
+			rawConfig: `datestyle=ISO, MDY`,
+			expected:  []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseSearchPathSetting(tt.rawConfig); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCreateDatabaseFromDatashareQuery(t *testing.T) {
+	tests := map[string]struct {
+		source   map[string]interface{}
+		expected string
+	}{
+		"same account": {
+			source: map[string]interface{}{
+				databaseDatashareSourceShareNameAttr: "share",
+				databaseDatashareSourceNamespaceAttr: "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f",
+			},
+			expected: `CREATE DATABASE "db" FROM DATASHARE "share" OF NAMESPACE 'd34dbe3f-d34d-b33f-d3ad-b33fd34db33f'`,
+		},
+		"other account with permissions": {
+			source: map[string]interface{}{
+				databaseDatashareSourceShareNameAttr:   "share",
+				databaseDatashareSourceNamespaceAttr:   "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f",
+				databaseDatashareSourceAccountAttr:     "123456789012",
+				databaseDatashareSourceWithPermissions: true,
+			},
+			expected: `CREATE DATABASE "db" WITH PERMISSIONS FROM DATASHARE "share" OF ACCOUNT '123456789012' NAMESPACE 'd34dbe3f-d34d-b33f-d3ad-b33fd34db33f'`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftDatabase().Schema, map[string]interface{}{
+				databaseNameAttr:            "db",
+				databaseDatashareSourceAttr: []interface{}{tt.source},
+			})
+			if got := createDatabaseFromDatashareQuery(d); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccResourceRedshiftDatabase_Integration(t *testing.T) {
+	integrationId := getEnvOrSkip("REDSHIFT_DATABASE_INTEGRATION_ID", t)
+	dbName := generateRandomObjectName("tf_acc_resource_integration")
+	config := fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+	%[3]s {
+		%[4]s = %[5]q
+	}
+}
+`, databaseNameAttr, dbName, databaseIntegrationSourceAttr, databaseIntegrationSourceIdAttr, integrationId)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDatabaseExists(dbName),
+					resource.TestCheckResourceAttr("redshift_database.db", fmt.Sprintf("%s.0.%s", databaseIntegrationSourceAttr, databaseIntegrationSourceIdAttr), strings.ToLower(integrationId)),
+					resource.TestCheckResourceAttrSet("redshift_database.db", databaseTypeAttr),
+				),
+			},
+			{
+				ResourceName:      "redshift_database.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceRedshiftDatabase_DatashareAndIntegrationConflict(t *testing.T) {
+	config := fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = "db"
+	%[2]s {
+		%[3]s = "share"
+		%[4]s = "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f"
+	}
+	%[5]s {
+		%[6]s = "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f"
+	}
+}
+`, databaseNameAttr, databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr, databaseDatashareSourceNamespaceAttr, databaseIntegrationSourceAttr, databaseIntegrationSourceIdAttr)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+		},
+	})
+}
+
+func TestCreateDatabaseFromIntegrationQuery(t *testing.T) {
+	tests := map[string]struct {
+		source   map[string]interface{}
+		expected string
+	}{
+		"integration": {
+			source: map[string]interface{}{
+				databaseIntegrationSourceIdAttr: "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f",
+			},
+			expected: `CREATE DATABASE "db" FROM INTEGRATION 'd34dbe3f-d34d-b33f-d3ad-b33fd34db33f'`,
+		},
+		"source database": {
+			source: map[string]interface{}{
+				databaseIntegrationSourceIdAttr:       "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f",
+				databaseIntegrationSourceDatabaseAttr: "orders",
+			},
+			expected: `CREATE DATABASE "db" FROM INTEGRATION 'd34dbe3f-d34d-b33f-d3ad-b33fd34db33f' DATABASE "orders"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftDatabase().Schema, map[string]interface{}{
+				databaseNameAttr:              "db",
+				databaseIntegrationSourceAttr: []interface{}{tt.source},
+			})
+			if got := createDatabaseFromIntegrationQuery(d); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
 )
 
@@ -19,8 +24,19 @@ const (
 	dataShareProducerNamespaceAttr = "producer_namespace"
 	dataShareCreatedAttr           = "created"
 	dataShareSchemasAttr           = "schemas"
+	dataShareManagedByAttr         = "managed_by"
+	dataShareTablesAttr            = "tables"
 )
 
+// Types of the objects in svv_datashare_objects which are shared with ALTER DATASHARE ... ADD TABLE.
+var dataShareTableObjectTypes = []string{"table", "view", "late binding view", "materialized view"}
+
+const dataShareManagedByADX = "ADX"
+
+var dataShareAllowedManagedBy = []string{
+	dataShareManagedByADX,
+}
+
 func redshiftDatashare() *schema.Resource {
 	return &schema.Resource{
 		Description: `
@@ -30,6 +46,11 @@ https://docs.aws.amazon.com/redshift/latest/dg/datashare-overview.html
 
 The redshift_datashare resource should be defined on the producer cluster.
 
+Datashares published on AWS Data Exchange must be created with ` + "`managed_by = \"ADX\"`" + `. The provider then authorizes
+AWS Data Exchange to access the datashare with the Redshift API, using the AWS configuration of the provider, which
+requires the ` + "`redshift:AuthorizeDataShare`" + ` permission. Their consumers are authorized by AWS Data Exchange when the
+datashare is added to a data set, so they can't be managed with the redshift_datashare_privilege resource.
+
 Note: Data sharing is only supported on certain Redshift instance families,
 such as RA3.
 `,
@@ -40,6 +61,12 @@ such as RA3.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if !d.NewValueKnown(dataShareSchemasAttr) || !d.NewValueKnown(dataShareTablesAttr) {
+				return nil
+			}
+			return validateDatashareTables(setToStringList(d.Get(dataShareSchemasAttr).(*schema.Set)), setToStringList(d.Get(dataShareTablesAttr).(*schema.Set)))
+		},
 		Schema: map[string]*schema.Schema{
 			dataShareNameAttr: {
 				Type:        schema.TypeString,
@@ -61,7 +88,7 @@ such as RA3.
 			},
 			dataSharePublicAccessibleAttr: {
 				Type:        schema.TypeBool,
-				Description: "Specifies whether the datashare can be shared to clusters that are publicly accessible. Default is `false`.",
+				Description: "Specifies whether the datashare can be shared to clusters that are publicly accessible. Default is `false`. Changing it updates the datashare in place.",
 				Optional:    true,
 				Default:     false,
 			},
@@ -80,10 +107,20 @@ such as RA3.
 				Description: "The date when datashare was created",
 				Computed:    true,
 			},
+			dataShareManagedByAttr: {
+				Type:         schema.TypeString,
+				Description:  "The service managing the datashare. Set to `ADX` to create a datashare which can be published on AWS Data Exchange.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(dataShareAllowedManagedBy, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
 			dataShareSchemasAttr: {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Defines which schemas are exposed to the data share.",
+				Description: "Defines which schemas are exposed to the data share. All tables, views and functions of the schemas are shared, including the ones created later.",
 				Set:         schema.HashString,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -92,12 +129,25 @@ such as RA3.
 					},
 				},
 			},
+			dataShareTablesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Defines which tables and views, as `schema.table`, are exposed to the data share, for sharing only some of the tables of a schema. Their schemas are shared as well, without the other tables. Tables of the schemas listed in `schemas` are already shared and can't be listed.",
+				Set:         schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^.]+\.[^.]+$`), "must be a table name qualified by its schema, e.g. `public.my_table`"),
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+			},
 		},
 	}
 }
 
 func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -106,6 +156,9 @@ func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) e
 	shareName := d.Get(dataShareNameAttr).(string)
 
 	query := fmt.Sprintf("CREATE DATASHARE %s SET PUBLICACCESSIBLE = %t", pq.QuoteIdentifier(shareName), d.Get(dataSharePublicAccessibleAttr).(bool))
+	if managedBy, ok := d.GetOk(dataShareManagedByAttr); ok {
+		query = fmt.Sprintf("%s MANAGEDBY %s", query, strings.ToUpper(managedBy.(string)))
+	}
 	log.Printf("[DEBUG] %s\n", query)
 	if _, err := tx.Exec(query); err != nil {
 		return err
@@ -136,18 +189,74 @@ func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) e
 		}
 	}
 
+	if err = addTablesToDatashare(tx, shareName, setToStringList(d.Get(dataShareTablesAttr).(*schema.Set)), make(map[string]bool)); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	if strings.EqualFold(d.Get(dataShareManagedByAttr).(string), dataShareManagedByADX) {
+		if err := authorizeDatashareForADX(db, d.Id(), shareName); err != nil {
+			return err
+		}
+	}
+
 	return resourceRedshiftDatashareRead(db, d)
 }
 
+// authorizeDatashareForADX authorizes AWS Data Exchange to access the datashare, so it can be added to a data set.
+func authorizeDatashareForADX(db *DBConnection, shareId, shareName string) error {
+	var producerAccount, producerNamespace string
+	query := "SELECT TRIM(producer_account), TRIM(producer_namespace) FROM SVV_DATASHARES WHERE share_type = 'OUTBOUND' AND share_id = $1"
+	log.Printf("[DEBUG] %s, $1=%s\n", query, shareId)
+	if err := db.QueryRow(query, shareId).Scan(&producerAccount, &producerNamespace); err != nil {
+		return fmt.Errorf("could not read producer of datashare %q: %w", shareName, err)
+	}
+
+	cfg, err := db.client.config.AwsConfig()
+	if err != nil {
+		return fmt.Errorf("could not load AWS configuration to authorize ADX: %w", err)
+	}
+	if cfg.Region == "" {
+		return fmt.Errorf("the AWS region is required to authorize ADX to access datashare %q", shareName)
+	}
+
+	arn := datashareArn(cfg.Region, producerAccount, producerNamespace, shareName)
+	log.Printf("[DEBUG] authorizing %s to access datashare %s\n", dataShareManagedByADX, arn)
+	_, err = redshift.NewFromConfig(cfg).AuthorizeDataShare(db.context(), &redshift.AuthorizeDataShareInput{
+		DataShareArn:       aws.String(arn),
+		ConsumerIdentifier: aws.String(dataShareManagedByADX),
+	})
+	if err != nil {
+		return fmt.Errorf("could not authorize ADX to access datashare %q: %w", shareName, err)
+	}
+	return nil
+}
+
+// datashareArn returns the ARN of a datashare of the producer namespace, in the partition of the region.
+func datashareArn(region, producerAccount, producerNamespace, shareName string) string {
+	partition := "aws"
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		partition = "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		partition = "aws-us-gov"
+	}
+	return fmt.Sprintf("arn:%s:redshift:%s:%s:datashare:%s/%s", partition, region, producerAccount, producerNamespace, shareName)
+}
+
 func addSchemaToDatashare(tx *sql.Tx, shareName string, schemaName string) error {
 	err := resourceRedshiftDatashareAddSchema(tx, shareName, schemaName)
 	if err != nil {
 		return err
 	}
+	query := fmt.Sprintf("ALTER DATASHARE %s SET INCLUDENEW = TRUE FOR SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err = tx.Exec(query); err != nil {
+		return err
+	}
 	err = resourceRedshiftDatashareAddAllTables(tx, shareName, schemaName)
 	if err != nil {
 		return err
@@ -173,10 +282,7 @@ func resourceRedshiftDatashareAddSchema(tx *sql.Tx, shareName string, schemaName
 			return err
 		}
 	}
-	query = fmt.Sprintf("ALTER DATASHARE %s SET INCLUDENEW = TRUE FOR SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
-	log.Printf("[DEBUG] %s\n", query)
-	_, err = tx.Exec(query)
-	return err
+	return nil
 }
 
 func resourceRedshiftDatashareAddAllFunctions(tx *sql.Tx, shareName string, schemaName string) error {
@@ -193,6 +299,59 @@ func resourceRedshiftDatashareAddAllTables(tx *sql.Tx, shareName string, schemaN
 	return err
 }
 
+// addTablesToDatashare shares single tables or views, along with their schemas unless already shared.
+func addTablesToDatashare(tx *sql.Tx, shareName string, tableNames []string, sharedSchemas map[string]bool) error {
+	for _, tableName := range tableNames {
+		schemaName, _ := splitDatashareTable(tableName)
+		if !sharedSchemas[schemaName] {
+			if err := resourceRedshiftDatashareAddSchema(tx, shareName, schemaName); err != nil {
+				return err
+			}
+			sharedSchemas[schemaName] = true
+		}
+
+		query := fmt.Sprintf("ALTER DATASHARE %s ADD TABLE %s", pq.QuoteIdentifier(shareName), quoteDatashareTable(tableName))
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeTableFromDatashare(tx *sql.Tx, shareName string, tableName string) error {
+	query := fmt.Sprintf("ALTER DATASHARE %s REMOVE TABLE %s", pq.QuoteIdentifier(shareName), quoteDatashareTable(tableName))
+	log.Printf("[DEBUG] %s\n", query)
+	_, err := tx.Exec(query)
+	return err
+}
+
+// splitDatashareTable splits a table name qualified by its schema, as listed in svv_datashare_objects.
+func splitDatashareTable(tableName string) (string, string) {
+	schemaName, name, _ := strings.Cut(tableName, ".")
+	return schemaName, name
+}
+
+func quoteDatashareTable(tableName string) string {
+	schemaName, name := splitDatashareTable(tableName)
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(name))
+}
+
+// validateDatashareTables checks that no table belongs to a schema which is shared with all its tables.
+func validateDatashareTables(schemas []string, tables []string) error {
+	sharedSchemas := make(map[string]bool, len(schemas))
+	for _, schemaName := range schemas {
+		sharedSchemas[strings.ToLower(schemaName)] = true
+	}
+	for _, tableName := range tables {
+		schemaName, _ := splitDatashareTable(strings.ToLower(tableName))
+		if sharedSchemas[schemaName] {
+			return fmt.Errorf("table %q is already shared with all tables of schema %q, remove it from %s", tableName, schemaName, dataShareTablesAttr)
+		}
+	}
+	return nil
+}
+
 func removeSchemaFromDatashare(tx *sql.Tx, shareName string, schemaName string) error {
 	err := resourceRedshiftDatashareRemoveAllFunctions(tx, shareName, schemaName)
 	if err != nil {
@@ -239,10 +398,10 @@ func resourceRedshiftDatashareRemoveSchema(tx *sql.Tx, shareName string, schemaN
 }
 
 func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) error {
-	var shareName, owner, producerAccount, producerNamespace, created string
+	var shareName, owner, producerAccount, producerNamespace, created, managedBy string
 	var publicAccessible bool
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -255,13 +414,14 @@ func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) err
 		svv_datashares.is_publicaccessible,
 		TRIM(COALESCE(svv_datashares.producer_account, '')),
 		TRIM(COALESCE(svv_datashares.producer_namespace, '')),
-		REPLACE(TO_CHAR(svv_datashares.createdate, 'YYYY-MM-DD HH24:MI:SS'), ' ', 'T') || 'Z'
+		REPLACE(TO_CHAR(svv_datashares.createdate, 'YYYY-MM-DD HH24:MI:SS'), ' ', 'T') || 'Z',
+		TRIM(COALESCE(svv_datashares.managed_by, ''))
 	FROM svv_datashares
 	LEFT JOIN pg_user ON svv_datashares.share_owner = pg_user.usesysid
 	WHERE share_type = 'OUTBOUND'
 	AND share_id = $1`
 	log.Printf("[DEBUG] %s, $1=%s\n", query, d.Id())
-	err = tx.QueryRow(query, d.Id()).Scan(&shareName, &owner, &publicAccessible, &producerAccount, &producerNamespace, &created)
+	err = tx.QueryRow(query, d.Id()).Scan(&shareName, &owner, &publicAccessible, &producerAccount, &producerNamespace, &created, &managedBy)
 	if err != nil {
 		return err
 	}
@@ -272,11 +432,16 @@ func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) err
 	d.Set(dataShareProducerAccountAttr, producerAccount)
 	d.Set(dataShareProducerNamespaceAttr, producerNamespace)
 	d.Set(dataShareCreatedAttr, created)
+	d.Set(dataShareManagedByAttr, strings.ToUpper(managedBy))
 
 	if err = readDatashareSchemas(tx, shareName, d); err != nil {
 		return err
 	}
 
+	if err = readDatashareTables(tx, shareName, d); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
@@ -300,20 +465,70 @@ func readDatashareSchemas(tx *sql.Tx, shareName string, d *schema.ResourceData)
 	}
 	defer rows.Close()
 
+	// Schemas which are only shared for some of their tables are tracked by the tables
+	tableSchemas := make(map[string]bool)
+	for _, tableName := range setToStringList(d.Get(dataShareTablesAttr).(*schema.Set)) {
+		schemaName, _ := splitDatashareTable(tableName)
+		tableSchemas[schemaName] = true
+	}
+	configuredSchemas := d.Get(dataShareSchemasAttr).(*schema.Set)
+
 	schemas := schema.NewSet(schema.HashString, nil)
 	for rows.Next() {
 		var schemaName string
 		if err = rows.Scan(&schemaName); err != nil {
 			return err
 		}
+		if tableSchemas[schemaName] && !configuredSchemas.Contains(schemaName) {
+			continue
+		}
 		schemas.Add(schemaName)
 	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
 	d.Set(dataShareSchemasAttr, schemas)
 	return nil
 }
 
+// readDatashareTables reads the tables and views shared individually, i.e. outside the schemas shared with all their tables.
+func readDatashareTables(tx *sql.Tx, shareName string, d *schema.ResourceData) error {
+	query := `
+	SELECT
+		object_name
+	FROM svv_datashare_objects
+	WHERE share_type = 'OUTBOUND'
+	AND object_type = ANY($2)
+	AND share_name = $1
+`
+	log.Printf("[DEBUG] %s, $1=%s\n", query, shareName)
+	rows, err := tx.Query(query, shareName, pq.Array(dataShareTableObjectTypes))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	schemas := d.Get(dataShareSchemasAttr).(*schema.Set)
+	tables := schema.NewSet(schema.HashString, nil)
+	for rows.Next() {
+		var tableName string
+		if err = rows.Scan(&tableName); err != nil {
+			return err
+		}
+		if schemaName, _ := splitDatashareTable(tableName); schemas.Contains(schemaName) {
+			continue
+		}
+		tables.Add(tableName)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	d.Set(dataShareTablesAttr, tables)
+	return nil
+}
+
 func resourceRedshiftDatashareUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -331,6 +546,10 @@ func resourceRedshiftDatashareUpdate(db *DBConnection, d *schema.ResourceData) e
 		return err
 	}
 
+	if err := setDatashareTables(tx, d); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -404,8 +623,54 @@ func setDatashareSchemas(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+func setDatashareTables(tx *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(dataShareTablesAttr) {
+		return nil
+	}
+	before, after := d.GetChange(dataShareTablesAttr)
+	add := after.(*schema.Set).Difference(before.(*schema.Set))
+	remove := before.(*schema.Set).Difference(after.(*schema.Set))
+
+	shareName := d.Get(dataShareNameAttr).(string)
+	schemas := d.Get(dataShareSchemasAttr).(*schema.Set)
+	remainingSchemas := make(map[string]bool)
+	for _, tableName := range setToStringList(after.(*schema.Set)) {
+		schemaName, _ := splitDatashareTable(tableName)
+		remainingSchemas[schemaName] = true
+	}
+
+	removedSchemas := make(map[string]bool)
+	for _, tableName := range setToStringList(remove) {
+		schemaName, _ := splitDatashareTable(tableName)
+		// The table stays shared with all tables of its schema
+		if schemas.Contains(schemaName) {
+			continue
+		}
+		if err := removeTableFromDatashare(tx, shareName, tableName); err != nil {
+			return err
+		}
+		if !remainingSchemas[schemaName] {
+			removedSchemas[schemaName] = true
+		}
+	}
+	for schemaName := range removedSchemas {
+		if err := resourceRedshiftDatashareRemoveSchema(tx, shareName, schemaName); err != nil {
+			return err
+		}
+	}
+
+	// The schemas of the tables which stay shared are already part of the datashare
+	sharedSchemas := make(map[string]bool)
+	for _, tableName := range setToStringList(before.(*schema.Set)) {
+		if schemaName, _ := splitDatashareTable(tableName); !removedSchemas[schemaName] {
+			sharedSchemas[schemaName] = true
+		}
+	}
+	return addTablesToDatashare(tx, shareName, setToStringList(add), sharedSchemas)
+}
+
 func resourceRedshiftDatashareDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,8 @@ package redshift
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -12,10 +14,11 @@ import (
 )
 
 const (
-	datasharePrivilegeShareNameAttr = "share_name"
-	datasharePrivilegeNamespaceAttr = "namespace"
-	datasharePrivilegeAccountAttr   = "account"
-	datasharePrivilegeShareDateAttr = "share_date"
+	datasharePrivilegeShareNameAttr   = "share_name"
+	datasharePrivilegeNamespaceAttr   = "namespace"
+	datasharePrivilegeAccountAttr     = "account"
+	datasharePrivilegeShareDateAttr   = "share_date"
+	datasharePrivilegeDataCatalogAttr = "via_data_catalog"
 )
 
 func redshiftDatasharePrivilege() *schema.Resource {
@@ -27,7 +30,12 @@ func redshiftDatasharePrivilege() *schema.Resource {
 			"When managing data share permissions across AWS accounts, set the `%[2]s` to the consumer's AWS account ID, and omit the `%[1]s`.\n"+
 			"After creating the privilege through terraform, you will also need to [authorize the cross-account datashare through the AWS console](https://docs.aws.amazon.com/redshift/latest/dg/across-account.html) before consumer clusters can access it.\n"+
 			"\n"+
-			"Note: Data sharing is only supported on certain instance families, such as RA3.", datasharePrivilegeNamespaceAttr, datasharePrivilegeAccountAttr),
+			"\n"+
+			"Set `%[3]s` to the `name` attribute of the `redshift_datashare` resource instead of a literal value, so that terraform grants the permission after the datashare is created and revokes it before the datashare is dropped.\n"+
+			"\n"+
+			"This resource grants `USAGE` on the datashare to consumers. The `ALTER` and `SHARE` privileges of users, groups and roles of the producer on a datashare are managed with `redshift_grant` and `object_type` `datashare`.\n"+
+			"\n"+
+			"Note: Data sharing is only supported on certain instance families, such as RA3.", datasharePrivilegeNamespaceAttr, datasharePrivilegeAccountAttr, datasharePrivilegeShareNameAttr),
 		CreateContext: ResourceFunc(resourceRedshiftDatasharePrivilegeCreate),
 		ReadContext:   ResourceFunc(resourceRedshiftDatasharePrivilegeRead),
 		DeleteContext: ResourceFunc(resourceRedshiftDatasharePrivilegeDelete),
@@ -79,6 +87,14 @@ func redshiftDatasharePrivilege() *schema.Resource {
 				},
 				ValidateFunc: validation.StringMatch(awsAccountIdRegexp, "AWS account id must be a 12-digit number"),
 			},
+			datasharePrivilegeDataCatalogAttr: {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      false,
+				Description:  "Shares the datashare with the AWS Glue Data Catalog of the consumer account, for access controlled by AWS Lake Formation. Requires `account`. Redshift doesn't expose this option in a system view, so it isn't read back.",
+				RequiredWith: []string{datasharePrivilegeAccountAttr},
+			},
 			datasharePrivilegeShareDateAttr: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -106,11 +122,28 @@ func resourceRedshiftDatasharePrivilegeCreate(db *DBConnection, d *schema.Resour
 	shareName := d.Get(datasharePrivilegeShareNameAttr).(string)
 	consumerNamespaceRaw, consumerNamespaceSet := d.GetOk(datasharePrivilegeNamespaceAttr)
 	consumerAccountRaw, consumerAccountSet := d.GetOk(datasharePrivilegeAccountAttr)
+
+	shareExists, err := checkOutboundDatashareExists(db, shareName)
+	if err != nil {
+		return err
+	}
+	if !shareExists {
+		return fmt.Errorf("datashare %q does not exist, make sure it is created before granting permissions on it, e.g. by referencing the name of the redshift_datashare resource", shareName)
+	}
+
+	managedBy, err := getDatashareManagedBy(db, shareName)
+	if err != nil {
+		return err
+	}
+	if managedBy != "" {
+		return fmt.Errorf("datashare %q is managed by %s, its consumers can't be managed directly", shareName, managedBy)
+	}
+
 	query := fmt.Sprintf("GRANT USAGE ON DATASHARE %s TO ", pq.QuoteIdentifier(shareName))
 	if consumerNamespaceSet {
 		query = fmt.Sprintf("%s NAMESPACE '%s'", query, pqQuoteLiteral(consumerNamespaceRaw.(string)))
 	} else if consumerAccountSet {
-		query = fmt.Sprintf("%s ACCOUNT '%s'", query, pqQuoteLiteral(consumerAccountRaw.(string)))
+		query = fmt.Sprintf("%s ACCOUNT '%s'%s", query, pqQuoteLiteral(consumerAccountRaw.(string)), datasharePrivilegeDataCatalogClause(d))
 	} else {
 		return fmt.Errorf("either %s or %s is required", datasharePrivilegeNamespaceAttr, datasharePrivilegeAccountAttr)
 	}
@@ -150,6 +183,11 @@ AND
 
 	log.Printf("[DEBUG] %s\n", query)
 	err := db.QueryRow(query, shareName, consumerNamespace).Scan(&shareDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[WARN] Datashare %s is not shared with %s anymore, removing from state", shareName, consumerNamespace)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -172,6 +210,11 @@ AND
 
 	log.Printf("[DEBUG] %s\n", query)
 	err := db.QueryRow(query, shareName, consumerAccount).Scan(&shareDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[WARN] Datashare %s is not shared with %s anymore, removing from state", shareName, consumerAccount)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -185,14 +228,57 @@ func resourceRedshiftDatasharePrivilegeDelete(db *DBConnection, d *schema.Resour
 	shareName := d.Get(datasharePrivilegeShareNameAttr).(string)
 	consumerNamespaceRaw, consumerNamespaceSet := d.GetOk(datasharePrivilegeNamespaceAttr)
 	consumerAccountRaw, consumerAccountSet := d.GetOk(datasharePrivilegeAccountAttr)
+
+	// Dropping a datashare also removes all its consumers, nothing is left to revoke
+	shareExists, err := checkOutboundDatashareExists(db, shareName)
+	if err != nil {
+		return err
+	}
+	if !shareExists {
+		log.Printf("[WARN] Datashare %s does not exist anymore, nothing to revoke", shareName)
+		return nil
+	}
+
 	query := fmt.Sprintf("REVOKE USAGE ON DATASHARE %s FROM", pq.QuoteIdentifier(shareName))
 	if consumerNamespaceSet {
 		query = fmt.Sprintf("%s NAMESPACE '%s'", query, pqQuoteLiteral(consumerNamespaceRaw.(string)))
 	} else if consumerAccountSet {
-		query = fmt.Sprintf("%s ACCOUNT '%s'", query, consumerAccountRaw.(string))
+		query = fmt.Sprintf("%s ACCOUNT '%s'%s", query, pqQuoteLiteral(consumerAccountRaw.(string)), datasharePrivilegeDataCatalogClause(d))
 	}
 	log.Printf("[DEBUG] %s\n", query)
 
-	_, err := db.Exec(query)
+	_, err = db.Exec(query)
 	return err
 }
+
+// datasharePrivilegeDataCatalogClause returns the clause sharing the datashare with the data catalog of the consumer account.
+func datasharePrivilegeDataCatalogClause(d *schema.ResourceData) string {
+	if d.Get(datasharePrivilegeDataCatalogAttr).(bool) {
+		return " VIA DATA CATALOG"
+	}
+	return ""
+}
+
+func getDatashareManagedBy(db *DBConnection, shareName string) (string, error) {
+	var managedBy string
+	query := "SELECT TRIM(COALESCE(managed_by, '')) FROM svv_datashares WHERE share_type = 'OUTBOUND' AND share_name = $1"
+	log.Printf("[DEBUG] %s, $1=%s\n", query, strings.ToLower(shareName))
+	if err := db.QueryRow(query, strings.ToLower(shareName)).Scan(&managedBy); err != nil {
+		return "", fmt.Errorf("error reading datashare %s: %w", shareName, err)
+	}
+	return strings.ToUpper(managedBy), nil
+}
+
+func checkOutboundDatashareExists(db *DBConnection, shareName string) (bool, error) {
+	var _rez int
+	query := "SELECT 1 FROM svv_datashares WHERE share_type = 'OUTBOUND' AND share_name = $1"
+	log.Printf("[DEBUG] %s, $1=%s\n", query, strings.ToLower(shareName))
+	err := db.QueryRow(query, strings.ToLower(shareName)).Scan(&_rez)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading datashare %s: %w", shareName, err)
+	}
+	return true, nil
+}
@@ -4,12 +4,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftDatasharePrivilege_Namespace(t *testing.T) {
@@ -74,6 +76,147 @@ resource "redshift_datashare_privilege" "consumer_account" {
 	})
 }
 
+func TestAccRedshiftDatasharePrivilege_Ordering(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	consumerNamespace := getEnvOrSkip("REDSHIFT_DATASHARE_CONSUMER_NAMESPACE", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_privilege_ordering"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare" "share" {
+	%[1]s = %[2]q
+}
+
+resource "redshift_datashare_privilege" "consumer_namespace" {
+	%[3]s = redshift_datashare.share.%[1]s
+	%[4]s = %[5]q
+}
+`, dataShareNameAttr, shareName, datasharePrivilegeShareNameAttr, datasharePrivilegeNamespaceAttr, consumerNamespace)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatasharePrivilegeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftDatashareNamespacePrivilegeExists(shareName, consumerNamespace),
+			},
+			{
+				// Drop the datashare outside of terraform, which also removes its consumers.
+				// The next apply must recreate both resources in the right order.
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("DROP DATASHARE %s", pq.QuoteIdentifier(shareName))); err != nil {
+						t.Fatalf("could not drop datashare: %v", err)
+					}
+				},
+				Config: config,
+				Check:  testAccCheckRedshiftDatashareNamespacePrivilegeExists(shareName, consumerNamespace),
+			},
+			{
+				// Remove only the datashare from the configuration while the privilege still exists in the state.
+				// The privilege must be revoked before the datashare is dropped.
+				Config: fmt.Sprintf(`
+resource "redshift_datashare" "share" {
+	%[1]s = %[2]q
+}
+`, dataShareNameAttr, shareName),
+				Check: func(s *terraform.State) error {
+					exists, err := checkDatasharePrivilegeNamespaceExists(testAccProvider.Meta().(*Client), shareName, consumerNamespace)
+					if err != nil {
+						return err
+					}
+					if exists {
+						return fmt.Errorf("datashare privilege still exists after being removed from the configuration")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDatasharePrivilege_MissingDatashare(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	consumerNamespace := getEnvOrSkip("REDSHIFT_DATASHARE_CONSUMER_NAMESPACE", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_privilege_missing"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare_privilege" "consumer_namespace" {
+	%[1]s = %[2]q
+	%[3]s = %[4]q
+}
+`, datasharePrivilegeShareNameAttr, shareName, datasharePrivilegeNamespaceAttr, consumerNamespace)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatasharePrivilegeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("does not exist"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDatasharePrivilege_AccountViaDataCatalog(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	consumerAccount := getEnvOrSkip("REDSHIFT_DATASHARE_DATA_CATALOG_CONSUMER_ACCOUNT", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_privilege_data_catalog"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare" "share" {
+	%[1]s = %[2]q
+}
+
+resource "redshift_datashare_privilege" "consumer_account" {
+	%[3]s = redshift_datashare.share.%[1]s
+	%[4]s = %[5]q
+	%[6]s = true
+}
+`, dataShareNameAttr, shareName, datasharePrivilegeShareNameAttr, datasharePrivilegeAccountAttr, consumerAccount, datasharePrivilegeDataCatalogAttr)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatasharePrivilegeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftDatashareAccountPrivilegeExists(shareName, consumerAccount),
+					resource.TestCheckResourceAttr("redshift_datashare_privilege.consumer_account", datasharePrivilegeDataCatalogAttr, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDatasharePrivilege_NamespaceViaDataCatalog(t *testing.T) {
+	config := fmt.Sprintf(`
+resource "redshift_datashare_privilege" "consumer_namespace" {
+	%[1]s = "share"
+	%[2]s = "d34dbe3f-d34d-b33f-d3ad-b33fd34db33f"
+	%[3]s = true
+}
+`, datasharePrivilegeShareNameAttr, datasharePrivilegeNamespaceAttr, datasharePrivilegeDataCatalogAttr)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`all of .account,via_data_catalog. must be specified`),
+			},
+		},
+	})
+}
+
 func testAccCheckRedshiftDatasharePrivilegeDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 
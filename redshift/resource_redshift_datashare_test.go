@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftDatashare_Basic(t *testing.T) {
@@ -108,6 +110,48 @@ resource "redshift_datashare" "basic" {
 	})
 }
 
+func TestAccRedshiftDatashare_ManagedByADX(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_ADX_SUPPORTED", t)
+	consumerAccount := getEnvOrSkip("REDSHIFT_DATASHARE_CONSUMER_ACCOUNT", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_adx"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare" "adx" {
+	%[1]s = %[2]q
+	%[3]s = "adx"
+	%[4]s = true
+}
+`, dataShareNameAttr, shareName, dataShareManagedByAttr, dataSharePublicAccessibleAttr)
+
+	configWithPrivilege := fmt.Sprintf(`
+%[1]s
+
+resource "redshift_datashare_privilege" "consumer" {
+	%[2]s = redshift_datashare.adx.%[3]s
+	%[4]s = %[5]q
+}
+`, config, datasharePrivilegeShareNameAttr, dataShareNameAttr, datasharePrivilegeAccountAttr, consumerAccount)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatashareDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftDatashareExists(shareName),
+					resource.TestCheckResourceAttr("redshift_datashare.adx", dataShareManagedByAttr, "ADX"),
+				),
+			},
+			{
+				Config:      configWithPrivilege,
+				ExpectError: regexp.MustCompile("is managed by ADX"),
+			},
+		},
+	})
+}
+
 func testAccCheckRedshiftDatashareExists(shareName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*Client)
@@ -165,3 +209,145 @@ func testAccCheckRedshiftDatashareDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestAccRedshiftDatashare_Tables(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_tables"), "-", "_")
+	config := func(tables ...string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+	%[1]s = %[2]q
+	%[3]s = true
+}
+
+resource "redshift_table" "shared" {
+	schema = redshift_schema.schema.name
+	name   = "shared"
+
+	column {
+		name = "id"
+		type = "integer"
+	}
+}
+
+resource "redshift_table" "private" {
+	schema = redshift_schema.schema.name
+	name   = "private"
+
+	column {
+		name = "id"
+		type = "integer"
+	}
+}
+
+resource "redshift_datashare" "share" {
+	%[4]s = %[2]q
+	%[5]s = %[6]s
+
+	depends_on = [redshift_table.shared, redshift_table.private]
+}
+`, schemaNameAttr, shareName, schemaCascadeOnDeleteAttr, dataShareNameAttr, dataShareTablesAttr, tfArray(tables))
+	}
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatashareDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(shareName + ".shared"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftDatashareExists(shareName),
+					resource.TestCheckResourceAttr("redshift_datashare.share", fmt.Sprintf("%s.#", dataShareSchemasAttr), "0"),
+					resource.TestCheckResourceAttr("redshift_datashare.share", fmt.Sprintf("%s.#", dataShareTablesAttr), "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_datashare.share", fmt.Sprintf("%s.*", dataShareTablesAttr), shareName+".shared"),
+				),
+			},
+			{
+				Config: config(shareName+".shared", shareName+".private"),
+				Check:  resource.TestCheckResourceAttr("redshift_datashare.share", fmt.Sprintf("%s.#", dataShareTablesAttr), "2"),
+			},
+			{
+				ResourceName:      "redshift_datashare.share",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_datashare.share", fmt.Sprintf("%s.#", dataShareSchemasAttr), "0"),
+					resource.TestCheckResourceAttr("redshift_datashare.share", fmt.Sprintf("%s.#", dataShareTablesAttr), "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateDatashareTables(t *testing.T) {
+	if err := validateDatashareTables([]string{"public"}, []string{"sales.orders", "sales.customers"}); err != nil {
+		t.Errorf("Expected tables of other schemas to be valid, got %v", err)
+	}
+	if err := validateDatashareTables([]string{"Sales"}, []string{"sales.orders"}); err == nil {
+		t.Errorf("Expected a table of a shared schema to be invalid")
+	}
+}
+
+func TestQuoteDatashareTable(t *testing.T) {
+	expected := `"sales"."order items"`
+	if got := quoteDatashareTable("sales.order items"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestDatashareArn(t *testing.T) {
+	for region, expected := range map[string]string{
+		"eu-central-1":  "arn:aws:redshift:eu-central-1:123456789012:datashare:ns-1/sales",
+		"cn-north-1":    "arn:aws-cn:redshift:cn-north-1:123456789012:datashare:ns-1/sales",
+		"us-gov-west-1": "arn:aws-us-gov:redshift:us-gov-west-1:123456789012:datashare:ns-1/sales",
+	} {
+		if got := datashareArn(region, "123456789012", "ns-1", "sales"); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+}
+
+func TestAccRedshiftDatashare_PubliclyAccessibleDrift(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_public"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare" "share" {
+	%[1]s = %[2]q
+	%[3]s = true
+}
+`, dataShareNameAttr, shareName, dataSharePublicAccessibleAttr)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatashareDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_datashare.share", dataSharePublicAccessibleAttr, "true"),
+			},
+			{
+				// A flag changed outside of terraform is detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER DATASHARE %s SET PUBLICACCESSIBLE FALSE", pq.QuoteIdentifier(shareName))); err != nil {
+						t.Fatalf("could not alter datashare: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_datashare.share", dataSharePublicAccessibleAttr, "true"),
+			},
+		},
+	})
+}
@@ -15,6 +15,7 @@ import (
 const (
 	defaultPrivilegesUserAttr       = "user"
 	defaultPrivilegesGroupAttr      = "group"
+	defaultPrivilegesRoleAttr       = "role"
 	defaultPrivilegesOwnerAttr      = "owner"
 	defaultPrivilegesSchemaAttr     = "schema"
 	defaultPrivilegesPrivilegesAttr = "privileges"
@@ -23,55 +24,82 @@ const (
 	defaultPrivilegesAllSchemasID = 0
 )
 
+// defaultPrivilegesIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var defaultPrivilegesIdentifierAttrs = []string{
+	defaultPrivilegesUserAttr,
+	defaultPrivilegesGroupAttr,
+	defaultPrivilegesRoleAttr,
+	defaultPrivilegesOwnerAttr,
+	defaultPrivilegesSchemaAttr,
+}
+
 var defaultPrivilegesAllowedObjectTypes = []string{
 	"table",
+	"function",
+	"procedure",
 }
 
 var defaultPrivilegesObjectTypesCodes = map[string]string{
-	"table": "r",
+	"table":     "r",
+	"function":  "f",
+	"procedure": "p",
 }
 
 func redshiftDefaultPrivileges() *schema.Resource {
 	return &schema.Resource{
 		Description: `Defines the default set of access privileges to be applied to objects that are created in the future by the specified user. By default, users can change only their own default access privileges. Only a superuser can specify default privileges for other users.`,
-		ReadContext: ResourceFunc(resourceRedshiftDefaultPrivilegesRead),
-		CreateContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesCreate),
+		ReadContext: ResourceFuncInDatabase(resourceDatabase, ResourceNormalizeIdentifiers(defaultPrivilegesIdentifierAttrs, resourceRedshiftDefaultPrivilegesRead)),
+		CreateContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceNormalizeIdentifiers(defaultPrivilegesIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesCreate)),
 		),
-		DeleteContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesDelete),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceNormalizeIdentifiers(defaultPrivilegesIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesDelete)),
 		),
 		// Since we revoke all when creating, we can use create as update
-		UpdateContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesCreate),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceNormalizeIdentifiers(defaultPrivilegesIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesCreate)),
 		),
+		CustomizeDiff: validatePrivilegesForObjectType(defaultPrivilegesPrivilegesAttr, defaultPrivilegesObjectTypeAttr, validateDefaultPrivileges),
 
 		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("default privileges"),
 			defaultPrivilegesSchemaAttr: {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "If set, the specified default privileges are applied to new objects created in the specified schema. In this case, the user or user group that is the target of ALTER DEFAULT PRIVILEGES must have CREATE privilege for the specified schema. Default privileges that are specific to a schema are added to existing global default privileges. By default, default privileges are applied globally to the entire database.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "If set, the specified default privileges are applied to new objects created in the specified schema. In this case, the user or user group that is the target of ALTER DEFAULT PRIVILEGES must have CREATE privilege for the specified schema. Default privileges that are specific to a schema are added to existing global default privileges. By default, default privileges are applied globally to the entire database.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			defaultPrivilegesGroupAttr: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr},
-				Description:  "The name of the  group to which the specified default privileges are applied.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{defaultPrivilegesGroupAttr, defaultPrivilegesRoleAttr, defaultPrivilegesUserAttr},
+				Description:      "The name of the  group to which the specified default privileges are applied.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			defaultPrivilegesRoleAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{defaultPrivilegesGroupAttr, defaultPrivilegesRoleAttr, defaultPrivilegesUserAttr},
+				Description:      "The name of the role to which the specified default privileges are applied.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			defaultPrivilegesUserAttr: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr},
-				Description:  "The name of the user to which the specified default privileges are applied.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{defaultPrivilegesGroupAttr, defaultPrivilegesRoleAttr, defaultPrivilegesUserAttr},
+				Description:      "The name of the user to which the specified default privileges are applied.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			defaultPrivilegesOwnerAttr: {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the user for which default privileges are defined. Only a superuser can specify default privileges for other users.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the user for which default privileges are defined. Only a superuser can specify default privileges for other users.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			defaultPrivilegesObjectTypeAttr: {
 				Type:         schema.TypeString,
@@ -84,13 +112,14 @@ func redshiftDefaultPrivileges() *schema.Resource {
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validatePrivilegeName),
 					StateFunc: func(val interface{}) string {
 						return strings.ToLower(val.(string))
 					},
 				},
 				Set:         schema.HashString,
-				Description: "The list of privileges to apply as default privileges. See [ALTER DEFAULT PRIVILEGES command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_ALTER_DEFAULT_PRIVILEGES.html) to see what privileges are available to which object type.",
+				Description: "The list of privileges to apply as default privileges. See [ALTER DEFAULT PRIVILEGES command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_ALTER_DEFAULT_PRIVILEGES.html) to see what privileges are available to which object type. Use `all` alone to grant all privileges of the object type, it is kept as long as every privilege it expands to is granted.",
 			},
 		},
 	}
@@ -99,7 +128,7 @@ func redshiftDefaultPrivileges() *schema.Resource {
 func resourceRedshiftDefaultPrivilegesDelete(db *DBConnection, d *schema.ResourceData) error {
 	revokeAlterDefaultQuery := createAlterDefaultsRevokeQuery(d)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -112,6 +141,17 @@ func resourceRedshiftDefaultPrivilegesDelete(db *DBConnection, d *schema.Resourc
 	return tx.Commit()
 }
 
+func validateDefaultPrivileges(privileges []string, objectType string) error {
+	if !validatePrivileges(privileges, objectType) {
+		upper := make([]string, 0, len(privileges))
+		for _, p := range privileges {
+			upper = append(upper, strings.ToUpper(p))
+		}
+		return fmt.Errorf(`invalid privileges list %+v for object type %q`, upper, objectType)
+	}
+	return nil
+}
+
 func resourceRedshiftDefaultPrivilegesCreate(db *DBConnection, d *schema.ResourceData) error {
 	privilegesSet := d.Get(defaultPrivilegesPrivilegesAttr).(*schema.Set)
 	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
@@ -121,11 +161,11 @@ func resourceRedshiftDefaultPrivilegesCreate(db *DBConnection, d *schema.Resourc
 		privileges = append(privileges, strings.ToUpper(p.(string)))
 	}
 
-	if !validatePrivileges(privileges, objectType) {
-		return fmt.Errorf(`invalid privileges list %+v for object type %q`, privileges, objectType)
+	if err := validateDefaultPrivileges(privileges, objectType); err != nil {
+		return err
 	}
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -162,7 +202,7 @@ func resourceRedshiftDefaultPrivilegesReadImpl(db *DBConnection, d *schema.Resou
 	schemaName, schemaNameSet := d.GetOk(defaultPrivilegesSchemaAttr)
 	ownerName := d.Get(defaultPrivilegesOwnerAttr).(string)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -199,13 +239,22 @@ func resourceRedshiftDefaultPrivilegesReadImpl(db *DBConnection, d *schema.Resou
 		return fmt.Errorf("failed to get user ID: %w", err)
 	}
 
-	switch strings.ToUpper(d.Get(defaultPrivilegesObjectTypeAttr).(string)) {
-	case "TABLE":
+	configuredPrivileges := d.Get(defaultPrivilegesPrivilegesAttr).(*schema.Set)
+	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
+	_, isRole := d.GetOk(defaultPrivilegesRoleAttr)
+	switch {
+	case isRole || strings.ToUpper(objectType) != "TABLE":
+		log.Println("[DEBUG] reading default privileges from the ACL")
+		if err := readAclDefaultPrivileges(tx, d, defaultPrivilegesAclGrantee(d), schemaID, ownerID, objectType); err != nil {
+			return fmt.Errorf("failed to read %s privileges: %w", objectType, err)
+		}
+	case strings.ToUpper(objectType) == "TABLE":
 		log.Println("[DEBUG] reading default privileges")
 		if err := readGroupTableDefaultPrivileges(tx, d, entityID, schemaID, ownerID, entityIsUser); err != nil {
 			return fmt.Errorf("failed to read table privileges: %w", err)
 		}
 	}
+	d.Set(defaultPrivilegesPrivilegesAttr, reconcileAllPrivileges(configuredPrivileges, d.Get(defaultPrivilegesPrivilegesAttr).(*schema.Set), objectType))
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
@@ -287,11 +336,74 @@ func readGroupTableDefaultPrivileges(tx *sql.Tx, d *schema.ResourceData, entityI
 	return nil
 }
 
+// readAclDefaultPrivileges reads the default privileges of a grantee from the ACL of pg_default_acl.
+// It is used for roles, whose privileges can't be read from pg_user or pg_group like the ones of
+// users and groups, and for functions and procedures.
+func readAclDefaultPrivileges(tx *sql.Tx, d *schema.ResourceData, grantee string, schemaID, ownerID int, objectType string) error {
+	privileges, err := queryAclDefaultPrivileges(tx, grantee, schemaID, ownerID, objectType)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Collected privileges for %s: %v\n", grantee, privileges)
+
+	d.Set(defaultPrivilegesPrivilegesAttr, privileges)
+
+	return nil
+}
+
+// queryAclDefaultPrivileges returns the default privileges of a grantee on objects of the given type
+// created by the owner in the schema.
+func queryAclDefaultPrivileges(tx *sql.Tx, grantee string, schemaID, ownerID int, objectType string) ([]string, error) {
+	var rawAcl string
+	query := `
+		SELECT COALESCE(array_to_string(defaclacl, '|'), '')
+		FROM pg_default_acl
+		WHERE defaclnamespace = $1
+		AND defaclobjtype = $2
+		AND defacluser = $3`
+	err := tx.QueryRow(query, schemaID, defaultPrivilegesObjectTypesCodes[objectType], ownerID).Scan(&rawAcl)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to collect privileges: %w", err)
+	}
+
+	return defaultAclPrivileges(rawAcl, grantee)
+}
+
+// defaultPrivilegesAclGrantee returns the grantee as named in the ACL entries,
+// i.e. prefixed with `group ` for groups and `role ` for roles.
+func defaultPrivilegesAclGrantee(d *schema.ResourceData) string {
+	if groupName, isGroup := d.GetOk(defaultPrivilegesGroupAttr); isGroup {
+		return fmt.Sprintf("group %s", groupName.(string))
+	}
+	if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		return fmt.Sprintf("role %s", roleName.(string))
+	}
+	return d.Get(defaultPrivilegesUserAttr).(string)
+}
+
+// defaultAclPrivileges returns the privileges granted to the grantee in the default ACL.
+func defaultAclPrivileges(rawAcl, grantee string) ([]string, error) {
+	entries, err := parseAcl(rawAcl)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Grantee, grantee) {
+			privileges, _ := aclEntryPrivileges(entry.Privileges)
+			return privileges, nil
+		}
+	}
+	return []string{}, nil
+}
+
 func generateDefaultPrivilegesID(d *schema.ResourceData) string {
 	var entityName, schemaName string
 
 	if groupName, isGroup := d.GetOk(defaultPrivilegesGroupAttr); isGroup {
 		entityName = fmt.Sprintf("gn:%s", groupName.(string))
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = fmt.Sprintf("rn:%s", roleName.(string))
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = fmt.Sprintf("un:%s", userName.(string))
 	}
@@ -319,6 +431,9 @@ func createAlterDefaultsGrantQuery(d *schema.ResourceData, privileges []string)
 	if groupName, isGroup := d.GetOk(defaultPrivilegesGroupAttr); isGroup {
 		entityName = groupName.(string)
 		toWhomIndicator = "GROUP"
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = roleName.(string)
+		toWhomIndicator = "ROLE"
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = userName.(string)
 	}
@@ -348,6 +463,9 @@ func createAlterDefaultsRevokeQuery(d *schema.ResourceData) string {
 	if groupName, isGroup := d.GetOk(defaultPrivilegesGroupAttr); isGroup {
 		entityName = groupName.(string)
 		fromWhomIndicator = "GROUP"
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = roleName.(string)
+		fromWhomIndicator = "ROLE"
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = userName.(string)
 	}
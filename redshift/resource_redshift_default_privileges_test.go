@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -225,7 +226,7 @@ resource "redshift_default_privileges" "both" {
 		Steps: []resource.TestStep{
 			{
 				Config:      config,
-				ExpectError: regexp.MustCompile("only one of `group,user` can be specified"),
+				ExpectError: regexp.MustCompile("only one of `group,role,user` can be specified"),
 			},
 		},
 	})
@@ -246,12 +247,162 @@ resource "redshift_default_privileges" "none" {
 		Steps: []resource.TestStep{
 			{
 				Config:      config,
-				ExpectError: regexp.MustCompile("one of `group,user` must be specified"),
+				ExpectError: regexp.MustCompile("one of `group,role,user` must be specified"),
 			},
 		},
 	})
 }
 
+func TestAccRedshiftDefaultPrivileges_Role(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+	schemaName := generateRandomObjectName("tf_acc_schema")
+	rootUsername := getRootUsername()
+
+	config := func(privileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_default_privileges" "role" {
+  role        = redshift_role.role.name
+  owner       = %[3]q
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  privileges  = %[4]s
+}
+`, roleName, schemaName, rootUsername, privileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["select", "insert"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.role", "id", fmt.Sprintf("rn:%s_sn:%s_on:%s_ot:table", roleName, schemaName, rootUsername)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.role", "role", roleName),
+					resource.TestCheckResourceAttr("redshift_default_privileges.role", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.role", "privileges.*", "select"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.role", "privileges.*", "insert"),
+				),
+			},
+			{
+				Config: config(`["select"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.role", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.role", "privileges.*", "select"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDefaultPrivileges_Functions(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group")
+	roleName := generateRandomObjectName("tf_acc_role")
+	rootUsername := getRootUsername()
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_role" "role" {
+  name = %[2]q
+}
+
+resource "redshift_default_privileges" "functions" {
+  group       = redshift_group.group.name
+  owner       = %[3]q
+  object_type = "function"
+  privileges  = ["execute"]
+}
+
+resource "redshift_default_privileges" "procedures" {
+  role        = redshift_role.role.name
+  owner       = %[3]q
+  object_type = "procedure"
+  privileges  = ["execute"]
+}
+`, groupName, roleName, rootUsername)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.functions", "id", fmt.Sprintf("gn:%s_noschema_on:%s_ot:function", groupName, rootUsername)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.functions", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.functions", "privileges.*", "execute"),
+					resource.TestCheckResourceAttr("redshift_default_privileges.procedures", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.procedures", "privileges.*", "execute"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDefaultPrivileges_FunctionsInvalidPrivilege(t *testing.T) {
+	rootUsername := getRootUsername()
+	config := fmt.Sprintf(`
+resource "redshift_default_privileges" "functions" {
+  group       = "test_group"
+  owner       = %[1]q
+  object_type = "function"
+  privileges  = ["select"]
+}
+`, rootUsername)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid privileges list \[SELECT\] for object type "function"`),
+			},
+		},
+	})
+}
+
+func TestDefaultAclPrivileges(t *testing.T) {
+	rawAcl := "group analysts=r/admin|role etl=arw/admin|john=X/admin"
+
+	tests := map[string]struct {
+		grantee  string
+		expected []string
+	}{
+		"role":                     {grantee: "role etl", expected: []string{"insert", "select", "update"}},
+		"case insensitive":         {grantee: "role ETL", expected: []string{"insert", "select", "update"}},
+		"group":                    {grantee: "group analysts", expected: []string{"select"}},
+		"user":                     {grantee: "john", expected: []string{"execute"}},
+		"group with the same name": {grantee: "role analysts", expected: []string{}},
+		"missing role":             {grantee: "role auditors", expected: []string{}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := defaultAclPrivileges(rawAcl, tt.grantee)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func testAccCheckDefaultPrivilegesDestory(schemaID, ownerID int, objectType, groupName string) func(*terraform.State) error {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*Client)
@@ -307,3 +458,50 @@ func getRootUsername() string {
 	}
 	return envRootUsername
 }
+
+func TestAccRedshiftDefaultPrivileges_MixedCaseNames(t *testing.T) {
+	userName := generateRandomObjectName("TF_Acc_User")
+	schemaName := generateRandomObjectName("TF_Acc_Schema")
+	rootUsername := getRootUsername()
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_default_privileges" "user" {
+  user        = %[1]q
+  owner       = %[3]q
+  schema      = %[2]q
+  object_type = "table"
+  privileges  = ["select"]
+
+  depends_on = [redshift_user.user, redshift_schema.schema]
+}
+`, userName, schemaName, strings.ToUpper(rootUsername))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.user", "id", fmt.Sprintf("un:%s_sn:%s_on:%s_ot:table", strings.ToLower(userName), strings.ToLower(schemaName), strings.ToLower(rootUsername))),
+					resource.TestCheckResourceAttr("redshift_default_privileges.user", "user", strings.ToLower(userName)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.user", "owner", strings.ToLower(rootUsername)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.user", "privileges.#", "1"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,286 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	externalSchemaDatabaseNameAttr         = "database_name"
+	externalSchemaDropExternalDatabaseAttr = "drop_external_database"
+	externalSchemaCascadeOnDeleteAttr      = "cascade_on_delete"
+	externalSchemaDataCatalogSourceAttr    = "data_catalog_source"
+	externalSchemaHiveMetastoreSourceAttr  = "hive_metastore_source"
+	externalSchemaRdsPostgresSourceAttr    = "rds_postgres_source"
+	externalSchemaRdsMysqlSourceAttr       = "rds_mysql_source"
+	externalSchemaRedshiftSourceAttr       = "redshift_source"
+)
+
+var externalSchemaSourceAttrs = []string{
+	externalSchemaDataCatalogSourceAttr,
+	externalSchemaHiveMetastoreSourceAttr,
+	externalSchemaRdsPostgresSourceAttr,
+	externalSchemaRdsMysqlSourceAttr,
+	externalSchemaRedshiftSourceAttr,
+}
+
+func redshiftExternalSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages an external schema, which references a database in an external data catalog (Redshift Spectrum), a federated RDS database or another Redshift database. See https://docs.aws.amazon.com/redshift/latest/dg/r_CREATE_EXTERNAL_SCHEMA.html
+`,
+		CreateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftExternalSchemaCreate),
+		ReadContext:   ResourceFuncInDatabase(resourceDatabase, resourceRedshiftExternalSchemaRead),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftExternalSchemaUpdate),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftExternalSchemaDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateInDatabase,
+		},
+		CustomizeDiff: customdiff.All(
+			forceNewIfListSizeChanged(externalSchemaDataCatalogSourceAttr),
+			forceNewIfListSizeChanged(externalSchemaHiveMetastoreSourceAttr),
+			forceNewIfListSizeChanged(externalSchemaRdsPostgresSourceAttr),
+			forceNewIfListSizeChanged(externalSchemaRdsMysqlSourceAttr),
+			forceNewIfListSizeChanged(externalSchemaRedshiftSourceAttr),
+		),
+		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("external schema"),
+			schemaNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the external schema. Changing it renames the schema.",
+				ValidateFunc: validation.StringNotInSlice([]string{
+					"public",
+				}, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			schemaOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the external schema owner.",
+			},
+			externalSchemaDatabaseNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the external database, e.g. the AWS Glue database, the RDS database or the Redshift database.",
+			},
+			externalSchemaDropExternalDatabaseAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Drop the external database, and all its tables, in the data catalog when the schema is destroyed. Only supported for external schemas using a data catalog.",
+			},
+			externalSchemaCascadeOnDeleteAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates to automatically drop all objects in the schema when it is destroyed.",
+			},
+			externalSchemaDataCatalogSourceAttr: {
+				Type:         schema.TypeList,
+				Description:  "Configures the external schema from the AWS Glue Data Catalog or an Athena data catalog.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: externalSchemaSourceAttrs,
+				Elem:         externalSchemaDataCatalogSource(),
+			},
+			externalSchemaHiveMetastoreSourceAttr: {
+				Type:         schema.TypeList,
+				Description:  "Configures the external schema from a Hive Metastore.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: externalSchemaSourceAttrs,
+				Elem:         externalSchemaHiveMetastoreSource(),
+			},
+			externalSchemaRdsPostgresSourceAttr: {
+				Type:         schema.TypeList,
+				Description:  "Configures the external schema to reference data using a federated query to RDS POSTGRES or Aurora PostgreSQL.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: externalSchemaSourceAttrs,
+				Elem:         externalSchemaRdsPostgresSource(),
+			},
+			externalSchemaRdsMysqlSourceAttr: {
+				Type:         schema.TypeList,
+				Description:  "Configures the external schema to reference data using a federated query to RDS MYSQL or Aurora MySQL.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: externalSchemaSourceAttrs,
+				Elem:         externalSchemaRdsMysqlSource(),
+			},
+			externalSchemaRedshiftSourceAttr: {
+				Type:         schema.TypeList,
+				Description:  "Configures the external schema to reference another Redshift database, e.g. a datashare database.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: externalSchemaSourceAttrs,
+				Elem:         externalSchemaRedshiftSource(),
+			},
+		},
+	}
+}
+
+func resourceRedshiftExternalSchemaCreate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(schemaNameAttr).(string)
+
+	if err := checkPrivilege(db, "database", db.client.config.Database, "create"); err != nil {
+		return err
+	}
+
+	sourceQuery, err := externalSchemaSourceQuery(d)
+	if err != nil {
+		return err
+	}
+
+	tx, err := startCreateTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := fmt.Sprintf("CREATE EXTERNAL SCHEMA %s %s", pq.QuoteIdentifier(schemaName), sourceQuery)
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not create external schema: %w", err)
+	}
+
+	if v, ok := d.GetOk(schemaOwnerAttr); ok {
+		query = fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(v.(string)))
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not set external schema owner: %w", err)
+		}
+	}
+
+	var schemaOID string
+	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", strings.ToLower(schemaName)).Scan(&schemaOID); err != nil {
+		return fmt.Errorf("could not read external schema id: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(schemaOID)
+
+	return resourceRedshiftExternalSchemaRead(db, d)
+}
+
+// externalSchemaSourceQuery returns the FROM clause of the CREATE EXTERNAL SCHEMA statement
+// for the configured source block.
+func externalSchemaSourceQuery(d *schema.ResourceData) (string, error) {
+	sourceDbName := d.Get(externalSchemaDatabaseNameAttr).(string)
+	queryParts := map[string]func(*schema.ResourceData, string, string) string{
+		externalSchemaDataCatalogSourceAttr:   getDataCatalogConfigQueryPart,
+		externalSchemaHiveMetastoreSourceAttr: getHiveMetastoreConfigQueryPart,
+		externalSchemaRdsPostgresSourceAttr:   getRdsPostgresConfigQueryPart,
+		externalSchemaRdsMysqlSourceAttr:      getRdsMysqlConfigQueryPart,
+		externalSchemaRedshiftSourceAttr:      getRedshiftConfigQueryPart,
+	}
+	for _, sourceAttr := range externalSchemaSourceAttrs {
+		if _, ok := d.GetOk(sourceAttr); ok {
+			return queryParts[sourceAttr](d, fmt.Sprintf("%s.0", sourceAttr), sourceDbName), nil
+		}
+	}
+	return "", fmt.Errorf("can't create external schema: no source configuration found")
+}
+
+func resourceRedshiftExternalSchemaRead(db *DBConnection, d *schema.ResourceData) error {
+	var schemaName, schemaOwner string
+	query := `
+	SELECT
+		TRIM(svv_external_schemas.schemaname),
+		TRIM(COALESCE(pg_user_info.usename, ''))
+	FROM svv_external_schemas
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = svv_external_schemas.esowner
+	WHERE svv_external_schemas.esoid = $1`
+	log.Printf("[DEBUG] %s, $1=%s\n", query, d.Id())
+	if err := db.QueryRow(query, d.Id()).Scan(&schemaName, &schemaOwner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Redshift external schema (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading external schema: %w", err)
+	}
+
+	sourceDbName, sourceType, sourceConfiguration, err := readExternalSchemaSource(db, d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set(schemaNameAttr, schemaName)
+	d.Set(schemaOwnerAttr, schemaOwner)
+	d.Set(externalSchemaDatabaseNameAttr, sourceDbName)
+	for _, sourceAttr := range externalSchemaSourceAttrs {
+		if sourceAttr == sourceType {
+			d.Set(sourceAttr, []map[string]interface{}{sourceConfiguration})
+		} else {
+			d.Set(sourceAttr, nil)
+		}
+	}
+
+	return nil
+}
+
+func resourceRedshiftExternalSchemaUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setSchemaName(tx, d); err != nil {
+		return err
+	}
+
+	if err := setSchemaOwner(tx, db, d); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftExternalSchemaRead(db, d)
+}
+
+func resourceRedshiftExternalSchemaDelete(db *DBConnection, d *schema.ResourceData) error {
+	query := dropExternalSchemaQuery(
+		d.Get(schemaNameAttr).(string),
+		d.Get(externalSchemaDropExternalDatabaseAttr).(bool),
+		d.Get(externalSchemaCascadeOnDeleteAttr).(bool),
+	)
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("could not drop external schema: %w", err)
+	}
+
+	return nil
+}
+
+func dropExternalSchemaQuery(schemaName string, dropExternalDatabase, cascade bool) string {
+	query := fmt.Sprintf("DROP SCHEMA IF EXISTS %s", pq.QuoteIdentifier(schemaName))
+	if dropExternalDatabase {
+		query = fmt.Sprintf("%s DROP EXTERNAL DATABASE", query)
+	}
+	if cascade {
+		return fmt.Sprintf("%s CASCADE", query)
+	}
+	return fmt.Sprintf("%s RESTRICT", query)
+}
@@ -0,0 +1,163 @@
+package redshift
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftExternalSchema_Redshift(t *testing.T) {
+	dbName := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_REDSHIFT_DATABASE", t)
+	dbSchema := os.Getenv("REDSHIFT_EXTERNAL_SCHEMA_REDSHIFT_SCHEMA")
+	if dbSchema == "" {
+		dbSchema = "public"
+	}
+	schemaName := generateRandomObjectName("tf_acc_external_schema")
+	newSchemaName := generateRandomObjectName("tf_acc_external_schema_renamed")
+	userName := generateRandomObjectName("tf_acc_external_schema_owner")
+
+	config := func(name, owner string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "owner" {
+  name = %[1]q
+}
+
+resource "redshift_external_schema" "redshift" {
+  name          = %[2]q
+  owner         = %[3]s
+  database_name = %[4]q
+
+  redshift_source {
+    schema = %[5]q
+  }
+}
+`, userName, name, owner, dbName, dbSchema)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftExternalSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(schemaName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "name", schemaName),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "database_name", dbName),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "redshift_source.#", "1"),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "redshift_source.0.schema", dbSchema),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "data_catalog_source.#", "0"),
+					resource.TestCheckResourceAttrSet("redshift_external_schema.redshift", "owner"),
+				),
+			},
+			{
+				Config: config(newSchemaName, "redshift_user.owner.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(newSchemaName),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "name", newSchemaName),
+					resource.TestCheckResourceAttr("redshift_external_schema.redshift", "owner", userName),
+				),
+			},
+			{
+				ResourceName:            "redshift_external_schema.redshift",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_external_database", "cascade_on_delete"},
+			},
+		},
+	})
+}
+
+func TestAccRedshiftExternalSchema_DataCatalogDropExternalDatabase(t *testing.T) {
+	roleArn := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_IAM_ROLE_ARN", t)
+	schemaName := generateRandomObjectName("tf_acc_external_schema_catalog")
+	dbName := generateRandomObjectName("tf_acc_external_schema_catalog")
+
+	config := fmt.Sprintf(`
+resource "redshift_external_schema" "catalog" {
+  name                   = %[1]q
+  database_name          = %[2]q
+  drop_external_database = true
+
+  data_catalog_source {
+    iam_role_arns                           = [%[3]q]
+    create_external_database_if_not_exists = true
+  }
+}
+`, schemaName, dbName, roleArn)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftExternalSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_external_schema.catalog", "database_name", dbName),
+					resource.TestCheckResourceAttr("redshift_external_schema.catalog", "data_catalog_source.#", "1"),
+					resource.TestCheckResourceAttr("redshift_external_schema.catalog", "data_catalog_source.0.iam_role_arns.0", roleArn),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftExternalSchemaDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_external_schema" {
+			continue
+		}
+
+		exists, err := checkSchemaExists(client, rs.Primary.Attributes[schemaNameAttr])
+		if err != nil {
+			return fmt.Errorf("error checking external schema: %w", err)
+		}
+
+		if exists {
+			return fmt.Errorf("external schema still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func TestDropExternalSchemaQuery(t *testing.T) {
+	tests := map[string]struct {
+		dropExternalDatabase bool
+		cascade              bool
+		expected             string
+	}{
+		"restrict": {
+			expected: `DROP SCHEMA IF EXISTS "spectrum" RESTRICT`,
+		},
+		"cascade": {
+			cascade:  true,
+			expected: `DROP SCHEMA IF EXISTS "spectrum" CASCADE`,
+		},
+		"drop external database": {
+			dropExternalDatabase: true,
+			cascade:              true,
+			expected:             `DROP SCHEMA IF EXISTS "spectrum" DROP EXTERNAL DATABASE CASCADE`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := dropExternalSchemaQuery("spectrum", tt.dropExternalDatabase, tt.cascade); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	functionSchemaAttr       = "schema"
+	functionNameAttr         = "name"
+	functionArgumentAttr     = "argument"
+	functionArgumentNameAttr = "name"
+	functionArgumentTypeAttr = "type"
+	functionReturnsAttr      = "returns"
+	functionLanguageAttr     = "language"
+	functionVolatilityAttr   = "volatility"
+	functionBodyAttr         = "body"
+	functionOwnerAttr        = "owner"
+)
+
+var functionAllowedLanguages = []string{
+	"sql",
+	"plpythonu",
+}
+
+var functionAllowedVolatilities = []string{
+	"volatile",
+	"stable",
+	"immutable",
+}
+
+// See https://www.postgresql.org/docs/8.0/catalog-pg-proc.html
+var functionVolatilityCodes = map[string]string{
+	"v": "volatile",
+	"s": "stable",
+	"i": "immutable",
+}
+
+type functionArgument struct {
+	Name string
+	Type string
+}
+
+func redshiftFunction() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a scalar user-defined function (UDF) written in SQL or Python. As functions can be overloaded, a function is identified by its schema, its name and the types of its arguments.
+`,
+		CreateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftFunctionCreate),
+		ReadContext:   ResourceFuncInDatabase(resourceDatabase, resourceRedshiftFunctionRead),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftFunctionUpdate),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftFunctionDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateInDatabase,
+		},
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if d.Get(functionLanguageAttr).(string) != "sql" {
+				return nil
+			}
+			for _, argument := range expandFunctionArguments(d.Get(functionArgumentAttr).([]interface{})) {
+				if argument.Name != "" {
+					return fmt.Errorf("arguments of SQL functions can't be named, refer to them as $1, $2, ... in the body")
+				}
+			}
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("function"),
+			functionSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The schema of the function.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			functionNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the function. Redshift recommends to prefix the names of UDFs with `f_`.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			functionArgumentAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The arguments of the function, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						functionArgumentNameAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The name of the argument. Only Python functions have named arguments, arguments of SQL functions are referred to as `$1`, `$2`, ...",
+						},
+						functionArgumentTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The data type of the argument.",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return functionArgumentType(old) == functionArgumentType(new)
+							},
+						},
+					},
+				},
+			},
+			functionReturnsAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The data type of the value returned by the function.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return functionArgumentType(old) == functionArgumentType(new)
+				},
+			},
+			functionLanguageAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "sql",
+				Description:  "The language of the function, one of: " + strings.Join(functionAllowedLanguages, ", ") + ".",
+				ValidateFunc: validation.StringInSlice(functionAllowedLanguages, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			functionVolatilityAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "volatile",
+				Description:  "The volatility of the function, one of: " + strings.Join(functionAllowedVolatilities, ", ") + ". Use the strictest category that is valid for the function, so that Redshift can cache its results.",
+				ValidateFunc: validation.StringInSlice(functionAllowedVolatilities, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			functionBodyAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The body of the function: a SELECT clause for SQL functions or a Python program returning the result. Leading and trailing whitespace is ignored.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+			},
+			functionOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The owner of the function. Defaults to the user the provider is connected as.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftFunctionCreate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(functionSchemaAttr).(string)
+	functionName := d.Get(functionNameAttr).(string)
+	arguments := expandFunctionArguments(d.Get(functionArgumentAttr).([]interface{}))
+
+	if err := checkPrivilege(db, "schema", schemaName, "create"); err != nil {
+		return err
+	}
+
+	tx, err := startCreateTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := createOrReplaceFunction(tx, d); err != nil {
+		return err
+	}
+
+	if owner, ok := d.GetOk(functionOwnerAttr); ok {
+		if err := setFunctionOwner(tx, schemaName, functionName, arguments, owner.(string)); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateFunctionID(schemaName, functionName, arguments))
+
+	return resourceRedshiftFunctionRead(db, d)
+}
+
+func resourceRedshiftFunctionRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, functionName, argumentTypes, err := parseFunctionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	query := `
+	SELECT
+		oidvectortypes(pr.proargtypes),
+		COALESCE(array_to_string(pr.proargnames, ','), ''),
+		format_type(pr.prorettype, NULL),
+		lang.lanname,
+		pr.provolatile,
+		pr.prosrc,
+		TRIM(COALESCE(pg_user_info.usename, ''))
+	FROM pg_proc pr
+	JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+	JOIN pg_language lang ON lang.oid = pr.prolang
+	LEFT JOIN pg_user_info ON pg_user_info.usesysid = pr.proowner
+	WHERE nsp.nspname = $1 AND pr.proname = $2`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, schemaName, functionName)
+	rows, err := db.Query(query, schemaName, functionName)
+	if err != nil {
+		return fmt.Errorf("error reading function: %w", err)
+	}
+	defer rows.Close()
+
+	// Overloaded functions share their name, look for the one with the same argument types
+	found := false
+	for rows.Next() {
+		var rawArgumentTypes, rawArgumentNames, returns, language, volatility, body, owner string
+		if err := rows.Scan(&rawArgumentTypes, &rawArgumentNames, &returns, &language, &volatility, &body, &owner); err != nil {
+			return fmt.Errorf("error reading function: %w", err)
+		}
+
+		var types []string
+		if rawArgumentTypes != "" {
+			types = strings.Split(rawArgumentTypes, ", ")
+		}
+		if functionSignature(types) != functionSignature(argumentTypes) {
+			continue
+		}
+		found = true
+
+		var names []string
+		if rawArgumentNames != "" {
+			names = strings.Split(rawArgumentNames, ",")
+		}
+		arguments := make([]map[string]interface{}, len(types))
+		for i, argumentType := range types {
+			argument := map[string]interface{}{
+				functionArgumentTypeAttr: argumentType,
+			}
+			if i < len(names) {
+				argument[functionArgumentNameAttr] = names[i]
+			}
+			arguments[i] = argument
+		}
+
+		d.Set(functionSchemaAttr, schemaName)
+		d.Set(functionNameAttr, functionName)
+		d.Set(functionArgumentAttr, arguments)
+		d.Set(functionReturnsAttr, returns)
+		d.Set(functionLanguageAttr, language)
+		d.Set(functionVolatilityAttr, functionVolatilityCodes[volatility])
+		d.Set(functionOwnerAttr, owner)
+		if strings.TrimSpace(body) != strings.TrimSpace(d.Get(functionBodyAttr).(string)) {
+			d.Set(functionBodyAttr, body)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading function: %w", err)
+	}
+
+	if !found {
+		log.Printf("[WARN] Redshift function (%s) not found", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceRedshiftFunctionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(functionSchemaAttr).(string)
+	functionName := d.Get(functionNameAttr).(string)
+	arguments := expandFunctionArguments(d.Get(functionArgumentAttr).([]interface{}))
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if d.HasChanges(functionBodyAttr, functionLanguageAttr, functionVolatilityAttr) {
+		if err := createOrReplaceFunction(tx, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange(functionOwnerAttr) {
+		if owner := d.Get(functionOwnerAttr).(string); owner != "" {
+			if err := setFunctionOwner(tx, schemaName, functionName, arguments, owner); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftFunctionRead(db, d)
+}
+
+func resourceRedshiftFunctionDelete(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, functionName, argumentTypes, err := parseFunctionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DROP FUNCTION %s(%s)", tableIdentifier(schemaName, functionName), strings.Join(argumentTypes, ", "))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("could not drop function: %w", err)
+	}
+
+	return nil
+}
+
+func createOrReplaceFunction(tx *sql.Tx, d *schema.ResourceData) error {
+	query := createFunctionQuery(
+		d.Get(functionSchemaAttr).(string),
+		d.Get(functionNameAttr).(string),
+		expandFunctionArguments(d.Get(functionArgumentAttr).([]interface{})),
+		d.Get(functionReturnsAttr).(string),
+		d.Get(functionVolatilityAttr).(string),
+		d.Get(functionBodyAttr).(string),
+		d.Get(functionLanguageAttr).(string),
+	)
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not create function: %w", err)
+	}
+	return nil
+}
+
+func setFunctionOwner(tx *sql.Tx, schemaName, functionName string, arguments []functionArgument, owner string) error {
+	var types []string
+	for _, argument := range arguments {
+		types = append(types, argument.Type)
+	}
+	query := fmt.Sprintf("ALTER FUNCTION %s(%s) OWNER TO %s", tableIdentifier(schemaName, functionName), strings.Join(types, ", "), pq.QuoteIdentifier(owner))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error setting function owner: %w", err)
+	}
+	return nil
+}
+
+func createFunctionQuery(schemaName, functionName string, arguments []functionArgument, returns, volatility, body, language string) string {
+	var definitions []string
+	for _, argument := range arguments {
+		if argument.Name == "" {
+			definitions = append(definitions, argument.Type)
+		} else {
+			definitions = append(definitions, fmt.Sprintf("%s %s", pq.QuoteIdentifier(argument.Name), argument.Type))
+		}
+	}
+
+	quote := functionBodyQuote(body)
+	return fmt.Sprintf(
+		"CREATE OR REPLACE FUNCTION %s(%s) RETURNS %s %s AS %s\n%s\n%s LANGUAGE %s",
+		tableIdentifier(schemaName, functionName),
+		strings.Join(definitions, ", "),
+		returns,
+		strings.ToUpper(volatility),
+		quote,
+		strings.TrimSpace(body),
+		quote,
+		strings.ToLower(language),
+	)
+}
+
+// functionBodyQuote returns a dollar quote which doesn't appear in the function body.
+func functionBodyQuote(body string) string {
+	quote := "$$"
+	for i := 0; strings.Contains(body, quote); i++ {
+		quote = fmt.Sprintf("$body%d$", i)
+	}
+	return quote
+}
+
+func expandFunctionArguments(raw []interface{}) []functionArgument {
+	arguments := make([]functionArgument, 0, len(raw))
+	for _, r := range raw {
+		argument := r.(map[string]interface{})
+		arguments = append(arguments, functionArgument{
+			Name: argument[functionArgumentNameAttr].(string),
+			Type: argument[functionArgumentTypeAttr].(string),
+		})
+	}
+	return arguments
+}
+
+// functionArgumentType returns the name of an argument type as reported by oidvectortypes,
+// which doesn't include the type parameters, e.g. `character varying` for `varchar(20)`.
+func functionArgumentType(argumentType string) string {
+	normalized := normalizeColumnType(argumentType)
+	if idx := strings.Index(normalized, "("); idx != -1 {
+		normalized = normalized[:idx]
+	}
+	return strings.TrimSpace(normalized)
+}
+
+// functionSignature returns the normalized, comma separated list of argument types.
+func functionSignature(argumentTypes []string) string {
+	normalized := make([]string, len(argumentTypes))
+	for i, argumentType := range argumentTypes {
+		normalized[i] = functionArgumentType(argumentType)
+	}
+	return strings.Join(normalized, ",")
+}
+
+func generateFunctionID(schemaName, functionName string, arguments []functionArgument) string {
+	var types []string
+	for _, argument := range arguments {
+		types = append(types, argument.Type)
+	}
+	return fmt.Sprintf("%s(%s)", generateTableID(schemaName, functionName), functionSignature(types))
+}
+
+func parseFunctionID(id string) (string, string, []string, error) {
+	openIdx := strings.Index(id, "(")
+	if openIdx == -1 || !strings.HasSuffix(id, ")") {
+		return "", "", nil, fmt.Errorf("invalid function ID %q, expected <schema>.<function>(<argument types>)", id)
+	}
+
+	schemaName, functionName, err := parseTableID(id[:openIdx])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid function ID %q, expected <schema>.<function>(<argument types>)", id)
+	}
+
+	var argumentTypes []string
+	if rawTypes := strings.TrimSpace(id[openIdx+1 : len(id)-1]); rawTypes != "" {
+		for _, argumentType := range strings.Split(rawTypes, ",") {
+			argumentTypes = append(argumentTypes, functionArgumentType(argumentType))
+		}
+	}
+	return schemaName, functionName, argumentTypes, nil
+}
@@ -0,0 +1,195 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftFunction_Basic(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_function_schema")
+	functionName := generateRandomObjectName("f_tf_acc_function")
+
+	config := func(volatility, sqlBody, pythonBody string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_function" "sql" {
+  schema     = redshift_schema.schema.name
+  name       = %[2]q
+  returns    = "int"
+  volatility = %[3]q
+  body       = %[4]q
+
+  argument {
+    type = "int"
+  }
+
+  argument {
+    type = "int"
+  }
+}
+
+# Overloads the SQL function with different argument types
+resource "redshift_function" "python" {
+  schema     = redshift_schema.schema.name
+  name       = %[2]q
+  returns    = "varchar"
+  language   = "plpythonu"
+  volatility = "immutable"
+  body       = %[5]q
+
+  argument {
+    name = "a"
+    type = "varchar(64)"
+  }
+}
+`, schemaName, functionName, volatility, sqlBody, pythonBody)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("stable", "SELECT $1 + $2", "return a.upper()"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_function.sql", "id", fmt.Sprintf("%s.%s(integer,integer)", schemaName, functionName)),
+					resource.TestCheckResourceAttr("redshift_function.sql", "argument.#", "2"),
+					resource.TestCheckResourceAttr("redshift_function.sql", "language", "sql"),
+					resource.TestCheckResourceAttr("redshift_function.sql", "volatility", "stable"),
+					resource.TestCheckResourceAttrSet("redshift_function.sql", "owner"),
+					resource.TestCheckResourceAttr("redshift_function.python", "id", fmt.Sprintf("%s.%s(character varying)", schemaName, functionName)),
+					resource.TestCheckResourceAttr("redshift_function.python", "argument.0.name", "a"),
+					resource.TestCheckResourceAttr("redshift_function.python", "language", "plpythonu"),
+					resource.TestCheckResourceAttr("redshift_function.python", "volatility", "immutable"),
+				),
+			},
+			{
+				Config: config("immutable", "SELECT $1 * $2", "return a.lower()"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_function.sql", "volatility", "immutable"),
+					resource.TestCheckResourceAttr("redshift_function.sql", "body", "SELECT $1 * $2"),
+					resource.TestCheckResourceAttr("redshift_function.python", "body", "return a.lower()"),
+				),
+			},
+			{
+				ResourceName:      "redshift_function.sql",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The catalog reports the normalized type names
+				ImportStateVerifyIgnore: []string{"argument", "returns"},
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftFunctionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	db, err := client.Connect()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_function" {
+			continue
+		}
+
+		schemaName, functionName, _, err := parseFunctionID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow(`
+			SELECT COUNT(*)
+			FROM pg_proc pr
+			JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+			WHERE nsp.nspname = $1 AND pr.proname = $2`, schemaName, functionName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error checking function: %w", err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("function %s still exists after destroy", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestParseFunctionID(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		schemaName    string
+		functionName  string
+		argumentTypes []string
+		wantErr       bool
+	}{
+		"without arguments": {
+			id:           "public.f_now()",
+			schemaName:   "public",
+			functionName: "f_now",
+		},
+		"with arguments": {
+			id:            "public.f_add(int, varchar(10))",
+			schemaName:    "public",
+			functionName:  "f_add",
+			argumentTypes: []string{"integer", "character varying"},
+		},
+		"missing arguments": {
+			id:      "public.f_add",
+			wantErr: true,
+		},
+		"missing schema": {
+			id:      "f_add(integer)",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			schemaName, functionName, argumentTypes, err := parseFunctionID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFunctionID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if schemaName != tt.schemaName || functionName != tt.functionName || !reflect.DeepEqual(argumentTypes, tt.argumentTypes) {
+				t.Errorf("Expected %s, %s, %v but got %s, %s, %v", tt.schemaName, tt.functionName, tt.argumentTypes, schemaName, functionName, argumentTypes)
+			}
+		})
+	}
+}
+
+func TestGenerateFunctionID(t *testing.T) {
+	arguments := []functionArgument{
+		{Type: "int"},
+		{Name: "label", Type: "VARCHAR(64)"},
+	}
+	expected := "public.f_label(integer,character varying)"
+	if got := generateFunctionID("Public", "F_Label", arguments); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestCreateFunctionQuery(t *testing.T) {
+	arguments := []functionArgument{
+		{Name: "a", Type: "int"},
+		{Name: "b", Type: "int"},
+	}
+	expected := "CREATE OR REPLACE FUNCTION \"public\".\"f_add\"(\"a\" int, \"b\" int) RETURNS int IMMUTABLE AS $$\nreturn a + b\n$$ LANGUAGE plpythonu"
+	if got := createFunctionQuery("public", "f_add", arguments, "int", "immutable", "\n  return a + b\n", "plpythonu"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	expected = "CREATE OR REPLACE FUNCTION \"public\".\"f_dollar\"() RETURNS varchar VOLATILE AS $body0$\nSELECT '$$'\n$body0$ LANGUAGE sql"
+	if got := createFunctionQuery("public", "f_dollar", nil, "varchar", "volatile", "SELECT '$$'", "sql"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
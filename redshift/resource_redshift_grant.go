@@ -1,6 +1,7 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -20,11 +21,21 @@ const (
 	grantSchemaAttr     = "schema"
 	grantObjectTypeAttr = "object_type"
 	grantObjectsAttr    = "objects"
+	grantColumnsAttr    = "columns"
 	grantPrivilegesAttr = "privileges"
+	grantGrantorAttr    = "grantor"
+
+	grantWithGrantOptionAttr     = "with_grant_option"
+	grantGrantablePrivilegesAttr = "grantable_privileges"
+
+	grantReapplyOnGrantorChangeAttr = "reapply_on_grantor_change"
 
 	grantToPublicName = "public"
 )
 
+// grantIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var grantIdentifierAttrs = []string{grantUserAttr, grantGroupAttr, grantRoleAttr, grantSchemaAttr}
+
 var grantAllowedObjectTypes = []string{
 	"table",
 	"schema",
@@ -32,8 +43,12 @@ var grantAllowedObjectTypes = []string{
 	"function",
 	"procedure",
 	"language",
+	"datashare",
 }
 
+// grantColumnPrivileges are the privileges that can be granted on the columns of a table.
+var grantColumnPrivileges = []string{"select", "update"}
+
 var grantObjectTypesCodes = map[string][]string{
 	"table":     {"r", "m", "v"},
 	"procedure": {"p"},
@@ -44,64 +59,63 @@ func redshiftGrant() *schema.Resource {
 	return &schema.Resource{
 		Description: `
 Defines access privileges for users and  groups. Privileges include access options such as being able to read data in tables and views, write data, create tables, and drop tables. Use this command to give specific privileges for a table, database, schema, function, procedure, language, or column.
+
+On datashares, the privileges ` + "`alter`" + ` and ` + "`share`" + ` allow users, groups and roles of the producer to alter the datashare and to add consumers to it. The consumers themselves are granted usage of the datashare with ` + "`redshift_datashare_privilege`" + `.
 `,
-		ReadContext: ResourceFunc(resourceRedshiftGrantRead),
-		CreateContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftGrantCreate),
+		ReadContext: ResourceFuncInDatabase(grantConnectionDatabase, ResourceNormalizeIdentifiers(grantIdentifierAttrs, resourceRedshiftGrantRead)),
+		CreateContext: ResourceFuncInDatabase(grantConnectionDatabase,
+			ResourceNormalizeIdentifiers(grantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftGrantCreate)),
 		),
-		DeleteContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftGrantDelete),
+		DeleteContext: ResourceFuncInDatabase(grantConnectionDatabase,
+			ResourceNormalizeIdentifiers(grantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftGrantDelete)),
 		),
 
-		// Since we revoke all when creating, we can use create as update
-		UpdateContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftGrantCreate),
+		UpdateContext: ResourceFuncInDatabase(grantConnectionDatabase,
+			ResourceNormalizeIdentifiers(grantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftGrantUpdate)),
 		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftGrantImport,
+		},
+		CustomizeDiff: validatePrivilegesForObjectType(grantPrivilegesAttr, grantObjectTypeAttr, validateGrantPrivileges),
 
 		Schema: map[string]*schema.Schema{
 			grantUserAttr: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
-				Description:  "The name of the user to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
-				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("^(?i)public$"), "User name cannot be 'public'. To use GRANT ... TO PUBLIC set the group name to 'public' instead."),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:      "The name of the user to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
+				ValidateFunc:     validation.StringDoesNotMatch(regexp.MustCompile("^(?i)public$"), "User name cannot be 'public'. To use GRANT ... TO PUBLIC set the group name to 'public' instead."),
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			grantGroupAttr: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
-				Description:  "The name of the group to grant privileges on. Exactly one of `user`, `group`, or `role` must be set. Settings the group name to `public` or `PUBLIC` (it is case insensitive in this case) will result in a `GRANT ... TO PUBLIC` statement.",
-				StateFunc: func(val interface{}) string {
-					name := val.(string)
-					if strings.ToLower(name) == grantToPublicName {
-						return strings.ToLower(name)
-					}
-					return name
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:      "The name of the group to grant privileges on. Exactly one of `user`, `group`, or `role` must be set. Settings the group name to `public` or `PUBLIC` (it is case insensitive in this case) will result in a `GRANT ... TO PUBLIC` statement.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			grantRoleAttr: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
-				Description:  "The name of the role to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:      "The name of the role to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			grantSchemaAttr: {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The database schema to grant privileges on.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "The database schema to grant privileges on.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			grantDatabaseAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "The name of the database to grant privileges on. Only used when `object_type` is `database`. By default, the database to which the provider is connected will be used",
+				Description: "The name of the database to grant privileges on when `object_type` is `database`. For the other object types, the database of the objects, the provider connects to it with its own credentials. By default, the database to which the provider is connected will be used",
 			},
 			grantObjectTypeAttr: {
 				Type:         schema.TypeString,
@@ -120,25 +134,104 @@ Defines access privileges for users and  groups. Privileges include access optio
 					},
 				},
 				Set:         schema.HashString,
-				Description: "The objects upon which to grant the privileges. An empty list (the default) means to grant permissions on all objects of the specified type. Ignored when `object_type` is one of (`database`, `schema`).",
+				Description: "The objects upon which to grant the privileges. An empty list (the default) means to grant permissions on all objects of the specified type, e.g. `GRANT ... ON ALL TABLES IN SCHEMA`, the privileges are then verified on every object currently in the schema. Ignored when `object_type` is one of (`database`, `schema`). Required when `object_type` is `language` or `datashare`. Views, including late-binding views, are granted with `object_type` `table`. Functions and procedures are identified by their name and argument types, e.g. `f_add(int, int)`, to tell overloads apart.",
+			},
+			grantColumnsAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:         schema.HashString,
+				Description: "The columns of the `objects` to grant the privileges on instead of the whole tables. Only used when `object_type` is `table`, requires `objects` and only the privileges `select` and `update` can be granted on columns, without grant option.",
 			},
 			grantPrivilegesAttr: {
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validatePrivilegeName),
 					StateFunc: func(val interface{}) string {
 						return strings.ToLower(val.(string))
 					},
 				},
 				Set:         schema.HashString,
-				Description: "The list of privileges to apply as default privileges. See [GRANT command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html) to see what privileges are available to which object type. An empty list could be provided to revoke all privileges for this user or group. Required when `object_type` is set to `language`.",
+				Description: "The list of privileges to apply as default privileges. See [GRANT command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html) to see what privileges are available to which object type. An empty list could be provided to revoke all privileges for this user or group. Required when `object_type` is set to `language`. The privileges for databases are `create`, `temporary` (or `temp`) and `usage`, Redshift has no `connect` privilege. Use `all` alone to grant all privileges of the object type, it is kept as long as every privilege it expands to is granted.",
+			},
+			grantWithGrantOptionAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the grantee can grant the privileges to other users. Can only be used when granting to a `user`. Toggling it does not revoke the privileges themselves.",
+			},
+			grantGrantablePrivilegesAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Set:           schema.HashString,
+				ConflictsWith: []string{grantWithGrantOptionAttr},
+				Description:   "The subset of `privileges` the grantee can grant to other users, e.g. `select` while `insert` is not grantable. Can only be used when granting to a `user`. Changing it does not revoke the privileges themselves.",
+			},
+			grantGrantorAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who granted the privileges, as recorded in the access control list. Empty if no privileges are granted or if the objects were granted by different users.",
+			},
+			grantReapplyOnGrantorChangeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Re-apply the privileges when the access control list holds no entry granted by the user the provider is connected as, e.g. when the privileges were re-granted by another user. Entries granted by other users are ignored.",
 			},
 		},
 	}
 }
 
 func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateGrantParameters(d); err != nil {
+		return err
+	}
+
+	if err := checkGrantGranteeType(db, d); err != nil {
+		return err
+	}
+
+	databaseName := getDatabaseName(db, d)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range grantStatements(d, databaseName) {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateGrantID(d))
+
+	return resourceRedshiftGrantReadImpl(db, d)
+}
+
+// validateGrantParameters checks the combination of the configured parameters before any
+// statement is executed.
+func validateGrantParameters(d *schema.ResourceData) error {
 	objectType := d.Get(grantObjectTypeAttr).(string)
 	schemaName := d.Get(grantSchemaAttr).(string)
 	objects := d.Get(grantObjectsAttr).(*schema.Set).List()
@@ -148,7 +241,6 @@ func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error
 		privileges = append(privileges, p.(string))
 	}
 
-	// validate parameters
 	if (objectType == "table" || objectType == "function" || objectType == "procedure") && schemaName == "" {
 		return fmt.Errorf("parameter `%s` is required for objects of type table, function and procedure", grantSchemaAttr)
 	}
@@ -157,41 +249,196 @@ func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error
 		return fmt.Errorf("cannot specify `%s` when `%s` is `database` or `schema`", grantObjectsAttr, grantObjectTypeAttr)
 	}
 
-	if objectType == "language" && len(objects) == 0 {
-		return fmt.Errorf("parameter `%s` is required for objects of type language", grantObjectsAttr)
+	if (objectType == "language" || objectType == "datashare") && len(objects) == 0 {
+		return fmt.Errorf("parameter `%s` is required for objects of type %s", grantObjectsAttr, objectType)
+	}
+
+	if objectType == "datashare" && (d.Get(grantWithGrantOptionAttr).(bool) || d.Get(grantGrantablePrivilegesAttr).(*schema.Set).Len() > 0) {
+		return fmt.Errorf("privileges on datashares can't be granted with grant option, remove `%s` and `%s`", grantWithGrantOptionAttr, grantGrantablePrivilegesAttr)
+	}
+
+	if err := validateGrantPrivileges(privileges, objectType); err != nil {
+		return err
+	}
+
+	if err := validateGrantOption(d); err != nil {
+		return err
+	}
+
+	if err := validateGrantColumns(d); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGrantColumns checks that column-level privileges are only granted on specific tables
+// and only with the privileges Redshift supports on columns.
+func validateGrantColumns(d *schema.ResourceData) error {
+	if d.Get(grantColumnsAttr).(*schema.Set).Len() == 0 {
+		return nil
+	}
+	if d.Get(grantObjectTypeAttr).(string) != "table" {
+		return fmt.Errorf("`%s` can only be used when `%s` is `table`", grantColumnsAttr, grantObjectTypeAttr)
+	}
+	if d.Get(grantObjectsAttr).(*schema.Set).Len() == 0 {
+		return fmt.Errorf("`%s` requires the tables to be listed in `%s`", grantColumnsAttr, grantObjectsAttr)
+	}
+	if d.Get(grantWithGrantOptionAttr).(bool) || d.Get(grantGrantablePrivilegesAttr).(*schema.Set).Len() > 0 {
+		return fmt.Errorf("column privileges can't be granted with grant option, remove `%s` and `%s`", grantWithGrantOptionAttr, grantGrantablePrivilegesAttr)
+	}
+	columnPrivileges := schema.NewSet(schema.HashString, nil)
+	for _, p := range grantColumnPrivileges {
+		columnPrivileges.Add(p)
+	}
+	if invalid := d.Get(grantPrivilegesAttr).(*schema.Set).Difference(columnPrivileges); invalid.Len() > 0 {
+		return fmt.Errorf("only %v can be granted on columns, not %v", grantColumnPrivileges, setToStringList(invalid))
+	}
+	return nil
+}
+
+// checkGrantGranteeType verifies that the grantee is of the declared type.
+func checkGrantGranteeType(db *DBConnection, d *schema.ResourceData) error {
+	if isGrantToPublic(d) {
+		return nil
+	}
+	for _, attr := range []string{grantUserAttr, grantGroupAttr, grantRoleAttr} {
+		if name, ok := d.GetOk(attr); ok {
+			return checkPrincipalType(db, attr, name.(string))
+		}
+	}
+	return nil
+}
+
+// grantStatements returns the statements executed on creation of the grant: the revocation of
+// all existing privileges followed by the grant of the configured privileges, if any.
+func grantStatements(d *schema.ResourceData, databaseName string) []string {
+	statements := []string{createGrantsRevokeQuery(d, databaseName)}
+	if d.Get(grantPrivilegesAttr).(*schema.Set).Len() == 0 {
+		log.Printf("[DEBUG] no privileges to grant for %s", d.Get(grantGroupAttr).(string))
+		return statements
+	}
+
+	if d.Get(grantColumnsAttr).(*schema.Set).Len() > 0 {
+		// Every column privilege is granted with its own column list
+		for _, privilege := range setToStringList(d.Get(grantPrivilegesAttr).(*schema.Set)) {
+			statements = append(statements, columnGrantQuery(d, privilege))
+		}
+		return statements
+	}
+
+	grantable := d.Get(grantGrantablePrivilegesAttr).(*schema.Set)
+	if grantable.Len() == 0 {
+		return append(statements, createGrantsQuery(d, databaseName))
+	}
+
+	// Privileges with and without grant option require separate statements
+	if privileges := d.Get(grantPrivilegesAttr).(*schema.Set).Difference(grantable); privileges.Len() > 0 {
+		statements = append(statements, grantQuery(d, databaseName, setToStringList(privileges), false))
+	}
+	return append(statements, grantQuery(d, databaseName, setToStringList(grantable), true))
+}
+
+func resourceRedshiftGrantUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChanges(grantWithGrantOptionAttr, grantGrantablePrivilegesAttr) && !d.HasChanges(grantPrivilegesAttr, grantObjectsAttr) {
+		return updateGrantOption(db, d)
+	}
+
+	// Since we revoke all when creating, we can use create as update
+	return resourceRedshiftGrantCreate(db, d)
+}
+
+// updateGrantOption adds or removes the grant option of the already granted privileges
+// in a single transaction, without revoking the privileges themselves.
+func updateGrantOption(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateGrantOption(d); err != nil {
+		return err
 	}
 
-	if !validatePrivileges(privileges, objectType) {
-		return fmt.Errorf(`invalid privileges list %+v for object of type %q`, privileges, objectType)
+	if d.Get(grantPrivilegesAttr).(*schema.Set).Len() == 0 {
+		return resourceRedshiftGrantReadImpl(db, d)
 	}
 
 	databaseName := getDatabaseName(db, d)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
-	if err := revokeGrants(tx, databaseName, d); err != nil {
-		return err
+	var queries []string
+	oldGrantable, newGrantable := d.GetChange(grantGrantablePrivilegesAttr)
+	if d.HasChange(grantWithGrantOptionAttr) {
+		if d.Get(grantWithGrantOptionAttr).(bool) {
+			queries = append(queries, createGrantsQuery(d, databaseName))
+		} else {
+			queries = append(queries, createRevokeGrantOptionQuery(d, databaseName))
+		}
+		// The grant option of all privileges was just granted or revoked
+		oldGrantable = schema.NewSet(schema.HashString, nil)
 	}
 
-	if err := createGrants(tx, databaseName, d); err != nil {
-		return err
+	if !d.Get(grantWithGrantOptionAttr).(bool) {
+		if added := newGrantable.(*schema.Set).Difference(oldGrantable.(*schema.Set)); added.Len() > 0 {
+			queries = append(queries, grantQuery(d, databaseName, setToStringList(added), true))
+		}
+		if removed := oldGrantable.(*schema.Set).Difference(newGrantable.(*schema.Set)); removed.Len() > 0 {
+			queries = append(queries, revokeGrantOptionQuery(d, databaseName, setToStringList(removed)))
+		}
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
 	}
 
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
-	d.SetId(generateGrantID(d))
-
 	return resourceRedshiftGrantReadImpl(db, d)
 }
 
+func validateGrantPrivileges(privileges []string, objectType string) error {
+	if validatePrivileges(privileges, objectType) {
+		return nil
+	}
+	if objectType == "database" {
+		for _, p := range privileges {
+			if strings.ToLower(p) == "connect" {
+				return fmt.Errorf("there is no CONNECT privilege on databases in Redshift, every user can connect to every database: supported database privileges are `create`, `temporary` (or `temp`) and `usage` (for databases created from datashares)")
+			}
+		}
+	}
+	if objectType == "datashare" {
+		for _, p := range privileges {
+			if strings.ToLower(p) == "usage" {
+				return fmt.Errorf("usage of datashares is granted to consumer namespaces and accounts with redshift_datashare_privilege: supported datashare privileges are `alter` and `share`")
+			}
+		}
+	}
+	return fmt.Errorf(`invalid privileges list %+v for object of type %q`, privileges, objectType)
+}
+
+func validateGrantOption(d *schema.ResourceData) error {
+	grantable := d.Get(grantGrantablePrivilegesAttr).(*schema.Set)
+	if !d.Get(grantWithGrantOptionAttr).(bool) && grantable.Len() == 0 {
+		return nil
+	}
+	if _, isUser := d.GetOk(grantUserAttr); !isUser {
+		return fmt.Errorf("`%s` and `%s` can only be used when granting privileges to a `%s`", grantWithGrantOptionAttr, grantGrantablePrivilegesAttr, grantUserAttr)
+	}
+	privileges := expandAllPrivileges(d.Get(grantPrivilegesAttr).(*schema.Set), d.Get(grantObjectTypeAttr).(string))
+	if notGranted := grantable.Difference(privileges); notGranted.Len() > 0 {
+		return fmt.Errorf("`%s` must be a subset of `%s`, not granted: %v", grantGrantablePrivilegesAttr, grantPrivilegesAttr, setToStringList(notGranted))
+	}
+	return nil
+}
+
 func resourceRedshiftGrantDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -223,20 +470,237 @@ func resourceRedshiftGrantReadImpl(db *DBConnection, d *schema.ResourceData) err
 		return nil
 	}
 
+	configuredPrivileges := d.Get(grantPrivilegesAttr).(*schema.Set)
+
+	var err error
 	switch objectType {
 	case "database":
-		return readDatabaseGrants(db, d)
+		err = readDatabaseGrants(db, d)
 	case "schema":
-		return readSchemaGrants(db, d)
+		err = readSchemaGrants(db, d)
 	case "table":
-		return readTableGrants(db, d)
+		if d.Get(grantColumnsAttr).(*schema.Set).Len() > 0 {
+			// Column privileges are not part of the access control list of the table
+			if err := readColumnGrants(db, d); err != nil {
+				return err
+			}
+			d.Set(grantGrantorAttr, "")
+			return nil
+		}
+		err = readTableGrants(db, d)
 	case "function", "procedure":
-		return readCallableGrants(db, d)
+		err = readCallableGrants(db, d)
 	case "language":
-		return readLanguageGrants(db, d)
+		err = readLanguageGrants(db, d)
+	case "datashare":
+		err = readDatashareGrants(db, d)
 	default:
 		return fmt.Errorf("unsupported %s: %q", grantObjectTypeAttr, objectType)
 	}
+	if err != nil {
+		return err
+	}
+
+	if err := readGrantAcl(db, d); err != nil {
+		return err
+	}
+
+	privileges := reconcileAllPrivileges(configuredPrivileges, d.Get(grantPrivilegesAttr).(*schema.Set), objectType)
+	d.Set(grantPrivilegesAttr, reconcilePrivilegeAliases(configuredPrivileges, privileges))
+
+	return nil
+}
+
+// tableAclQuery selects the name and the serialized ACL of the tables of a schema.
+const tableAclQuery = `
+  SELECT relname, COALESCE(array_to_string(relacl, '|'), '')
+  FROM pg_class cl
+  JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE
+    cl.relkind = ANY($1)
+    AND nsp.nspname=$2
+`
+
+// readGrantAcl reads the grantor and the grant option of the privileges from the access
+// control list and, if requested, marks the privileges for re-application when the grantor
+// differs from the user the provider is connected as.
+func readGrantAcl(db *DBConnection, d *schema.ResourceData) error {
+	objectType := d.Get(grantObjectTypeAttr).(string)
+	schemaName := d.Get(grantSchemaAttr).(string)
+
+	var query string
+	var queryArgs []interface{}
+	switch objectType {
+	case "database":
+		query = "SELECT datname, COALESCE(array_to_string(datacl, '|'), '') FROM pg_database WHERE datname=$1"
+		queryArgs = []interface{}{getDatabaseName(db, d)}
+	case "schema":
+		query = "SELECT nspname, COALESCE(array_to_string(nspacl, '|'), '') FROM pg_namespace WHERE nspname=$1"
+		queryArgs = []interface{}{schemaName}
+	case "table":
+		query = tableAclQuery
+		queryArgs = []interface{}{pq.Array(grantObjectTypesCodes["table"]), schemaName}
+	case "function", "procedure":
+		query = `
+  SELECT proname || '(' || oidvectortypes(proargtypes) || ')', COALESCE(array_to_string(proacl, '|'), '')
+  FROM pg_proc_info pr
+  JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+  WHERE
+    nsp.nspname=$1
+    AND pr.prokind=ANY($2)
+`
+		queryArgs = []interface{}{schemaName, pq.Array(grantObjectTypesCodes[objectType])}
+	case "language":
+		query = "SELECT lanname, COALESCE(array_to_string(lanacl, '|'), '') FROM pg_language"
+	default:
+		return nil
+	}
+
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+	isCallable := objectType == "function" || objectType == "procedure"
+	if isCallable {
+		objects = callableSignatures(objects)
+	}
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	grantee := getGrantAclGrantee(d)
+	grantors := map[string]bool{}
+	withGrantOption := false
+	privileges := d.Get(grantPrivilegesAttr).(*schema.Set).List()
+	grantable := map[string]bool{}
+	matchedEntries := 0
+	for rows.Next() {
+		var objName, rawAcl string
+		if err := rows.Scan(&objName, &rawAcl); err != nil {
+			return err
+		}
+
+		if isCallable && objects.Len() > 0 && !matchesCallable(objects, objName) {
+			continue
+		}
+		if !isCallable && objectType != "database" && objectType != "schema" && objects.Len() > 0 && !objects.Contains(objName) {
+			continue
+		}
+
+		entries, err := parseAcl(rawAcl)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Grantee == grantee {
+				withGrantOption = (len(grantors) == 0 || withGrantOption) && aclHasGrantOption(entry.Privileges)
+				grantors[entry.Grantor] = true
+				for _, p := range privileges {
+					privilege := p.(string)
+					grantable[privilege] = (matchedEntries == 0 || grantable[privilege]) && aclPrivilegeHasGrantOption(entry.Privileges, privilege)
+				}
+				matchedEntries++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	grantor := ""
+	if len(grantors) == 1 {
+		for g := range grantors {
+			grantor = g
+		}
+	}
+	log.Printf("[DEBUG] Collected grantors for %s: %v", grantee, grantors)
+	d.Set(grantGrantorAttr, grantor)
+
+	// The grant option is tracked either for all privileges or per privilege
+	grantablePrivileges := schema.NewSet(schema.HashString, nil)
+	if d.Get(grantWithGrantOptionAttr).(bool) {
+		d.Set(grantWithGrantOptionAttr, withGrantOption)
+	} else {
+		for privilege, isGrantable := range grantable {
+			if isGrantable {
+				grantablePrivileges.Add(privilege)
+			}
+		}
+	}
+	d.Set(grantGrantablePrivilegesAttr, grantablePrivileges)
+
+	if !d.Get(grantReapplyOnGrantorChangeAttr).(bool) || len(grantors) == 0 {
+		return nil
+	}
+
+	rawUsername, err := db.client.config.GetUsername(db)
+	if err != nil {
+		return fmt.Errorf("error retrieving username: %w", err)
+	}
+	if username := permanentUsername(rawUsername); !isGrantedBy(grantors, username) {
+		log.Printf("[WARN] Grantors %v don't include %q, privileges will be re-applied", grantors, username)
+		d.Set(grantPrivilegesAttr, schema.NewSet(schema.HashString, nil))
+	}
+
+	return nil
+}
+
+// isGrantedBy returns whether the access control list holds an entry for the grantee granted by the user.
+// The entries granted by other users are ignored, as re-applying the privileges doesn't remove them.
+func isGrantedBy(grantors map[string]bool, username string) bool {
+	return grantors[username]
+}
+
+// readTableAclPrivileges returns the privileges the grantee holds on every table of the schema,
+// along with the number of tables in the schema.
+func readTableAclPrivileges(tx *sql.Tx, schemaName, grantee string) (*schema.Set, int, error) {
+	rows, err := tx.Query(tableAclQuery, pq.Array(grantObjectTypesCodes["table"]), schemaName)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var common *schema.Set
+	tables := 0
+	for rows.Next() {
+		var objName, rawAcl string
+		if err := rows.Scan(&objName, &rawAcl); err != nil {
+			return nil, 0, err
+		}
+		privileges, err := defaultAclPrivileges(rawAcl, grantee)
+		if err != nil {
+			return nil, 0, err
+		}
+		granted := schema.NewSet(schema.HashString, nil)
+		for _, privilege := range privileges {
+			granted.Add(privilege)
+		}
+		if common == nil {
+			common = granted
+		} else {
+			common = common.Intersection(granted)
+		}
+		tables++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if common == nil {
+		common = schema.NewSet(schema.HashString, nil)
+	}
+
+	return common, tables, nil
+}
+
+// getGrantAclGrantee returns the grantee name how it appears in the access control list.
+func getGrantAclGrantee(d *schema.ResourceData) string {
+	if isGrantToPublic(d) {
+		return ""
+	}
+	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		return fmt.Sprintf("group %s", groupName.(string))
+	}
+	return d.Get(grantUserAttr).(string)
 }
 
 func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
@@ -448,6 +912,8 @@ func readTableGrants(db *DBConnection, d *schema.ResourceData) error {
 	}
 	defer rows.Close()
 
+	granted := map[string]*schema.Set{}
+	var tableNames []string
 	for rows.Next() {
 		var objName string
 		var tableSelect, tableUpdate, tableInsert, tableDelete, tableDrop, tableReferences, tableRule, tableTrigger bool
@@ -486,17 +952,113 @@ func readTableGrants(db *DBConnection, d *schema.ResourceData) error {
 			privilegesSet.Add("trigger")
 		}
 
-		if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
-			d.Set(grantPrivilegesAttr, privilegesSet)
-			break
-		}
+		granted[objName] = privilegesSet
+		tableNames = append(tableNames, objName)
 
 		log.Printf("[DEBUG] Collected table grants; table: '%v'; privileges: %v; for: %s", objName, privilegesSet.List(), entityName)
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(grantPrivilegesAttr, grantedTablePrivileges(d.Get(grantPrivilegesAttr).(*schema.Set), granted, tableNames))
 
 	return nil
 }
 
+// grantedTablePrivileges returns the privileges granted on every one of the tables, or the configured privileges
+// if they are granted as configured, e.g. `all`. Without tables, the configured privileges are kept.
+func grantedTablePrivileges(configured *schema.Set, granted map[string]*schema.Set, tableNames []string) *schema.Set {
+	if len(tableNames) == 0 {
+		return configured
+	}
+	privileges := privilegesGrantedOnAll(granted, tableNames)
+	if reconcileAllPrivileges(configured, privileges, "table").Equal(configured) {
+		return configured
+	}
+	return privileges
+}
+
+// columnPrivilege is a privilege granted on a column of a table.
+type columnPrivilege struct {
+	table     string
+	column    string
+	privilege string
+}
+
+func readColumnGrants(db *DBConnection, d *schema.ResourceData) error {
+	log.Printf("[DEBUG] Reading column grants")
+
+	schemaName := d.Get(grantSchemaAttr).(string)
+	query := `
+  SELECT relation_name, column_name, LOWER(privilege_type)
+  FROM svv_column_privileges
+  WHERE
+    namespace_name=$1
+    AND identity_type=$2
+    AND identity_name=$3
+`
+	queryArgs := []interface{}{schemaName, grantUserAttr, d.Get(grantUserAttr).(string)}
+	if isGrantToPublic(d) {
+		query = `
+  SELECT relation_name, column_name, LOWER(privilege_type)
+  FROM svv_column_privileges
+  WHERE
+    namespace_name=$1
+    AND identity_type='public'
+`
+		queryArgs = []interface{}{schemaName}
+	} else if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		queryArgs = []interface{}{schemaName, grantGroupAttr, groupName.(string)}
+	}
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	granted := map[columnPrivilege]bool{}
+	for rows.Next() {
+		var grant columnPrivilege
+		if err := rows.Scan(&grant.table, &grant.column, &grant.privilege); err != nil {
+			return err
+		}
+		granted[grant] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	privileges := columnPrivilegesGrantedOnAll(
+		granted,
+		setToStringList(d.Get(grantObjectsAttr).(*schema.Set)),
+		setToStringList(d.Get(grantColumnsAttr).(*schema.Set)),
+	)
+	log.Printf("[DEBUG] Collected column grants; privileges: %v", privileges)
+	d.Set(grantPrivilegesAttr, privileges)
+
+	return nil
+}
+
+// columnPrivilegesGrantedOnAll returns the column privileges granted on every column of every table,
+// a privilege missing on a single column is not granted.
+func columnPrivilegesGrantedOnAll(granted map[columnPrivilege]bool, tables, columns []string) []string {
+	privileges := []string{}
+	for _, privilege := range grantColumnPrivileges {
+		grantedOnAll := true
+		for _, table := range tables {
+			for _, column := range columns {
+				grantedOnAll = grantedOnAll && granted[columnPrivilege{table: table, column: column, privilege: privilege}]
+			}
+		}
+		if grantedOnAll {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges
+}
+
 func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 	log.Printf("[DEBUG] Reading callable grants")
 
@@ -510,7 +1072,7 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 		entityName = d.Get(grantUserAttr).(string)
 		query = `
 	SELECT
-		proname,
+		proname || '(' || oidvectortypes(pr.proargtypes) || ')',
 		decode(nvl(charindex('X',split_part(split_part(regexp_replace(replace(array_to_string(pr.proacl, '|'), '"', ''),'group '||u.usename,'__avoidGroupPrivs__'), u.usename||'=', 2) ,'/',1)), 0), 0,0,1) AS EXECUTE
 	FROM pg_proc_info pr
 		JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace,
@@ -524,7 +1086,7 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 		entityName = d.Get(grantGroupAttr).(string)
 		query = `
 	SELECT
-		proname,
+		proname || '(' || oidvectortypes(pr.proargtypes) || ')',
 		decode(nvl(charindex('X',split_part(split_part(replace(array_to_string(pr.proacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)), 0), 0,0,1) AS EXECUTE
 	FROM pg_proc_info pr
 		JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace,
@@ -536,7 +1098,7 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 `
 	}
 
-	callables := stripArgumentsFromCallablesDefinitions(d.Get(grantObjectsAttr).(*schema.Set))
+	callables := callableSignatures(d.Get(grantObjectsAttr).(*schema.Set))
 	queryArgs := []interface{}{
 		schemaName, entityName, pq.Array(grantObjectTypesCodes[objectType]),
 	}
@@ -544,7 +1106,7 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 	if isGrantToPublic(d) {
 		query = `
 	SELECT
-		proname,
+		proname || '(' || oidvectortypes(pr.proargtypes) || ')',
 		decode(nvl(charindex('X',split_part(split_part(regexp_replace(replace(array_to_string(pr.proacl, '|'), '"', ''),'[^|]+=','__avoidUserPrivs__'), '=', 2) ,'/',1)), 0), 0,0,1) AS EXECUTE
 	FROM pg_proc_info pr
 		JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
@@ -563,16 +1125,8 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 	}
 	defer rows.Close()
 
-	contains := func(callables []string, objName string) bool {
-		for _, callable := range callables {
-			if callable == objName {
-				return true
-			}
-		}
-		return false
-	}
-
-	privilegesSet := schema.NewSet(schema.HashString, nil)
+	// EXECUTE is only granted if it is granted on every matching overload
+	matched, executeOnAll := 0, true
 	for rows.Next() {
 		var objName string
 		var callableExecute bool
@@ -580,13 +1134,20 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 		if err := rows.Scan(&objName, &callableExecute); err != nil {
 			return err
 		}
-		if len(callables) > 0 && !contains(callables, objName) {
+		if callables.Len() > 0 && !matchesCallable(callables, objName) {
 			continue
 		}
 
-		if callableExecute {
-			privilegesSet.Add("execute")
-		}
+		matched++
+		executeOnAll = executeOnAll && callableExecute
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	privilegesSet := schema.NewSet(schema.HashString, nil)
+	if matched > 0 && executeOnAll {
+		privilegesSet.Add("execute")
 	}
 
 	if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
@@ -664,29 +1225,82 @@ func readLanguageGrants(db *DBConnection, d *schema.ResourceData) error {
 			privilegesSet.Add("usage")
 		}
 
-		if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
+		configuredPrivileges := d.Get(grantPrivilegesAttr).(*schema.Set)
+		if !reconcileAllPrivileges(configuredPrivileges, privilegesSet, "language").Equal(configuredPrivileges) {
 			d.Set(grantPrivilegesAttr, privilegesSet)
 			break
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] Reading language grants - Done")
 
 	return nil
 }
 
-func revokeGrants(tx *sql.Tx, databaseName string, d *schema.ResourceData) error {
-	query := createGrantsRevokeQuery(d, databaseName)
-	_, err := tx.Exec(query)
-	return err
+// readDatashareGrants reads the privileges granted on all the datashares of the grant from SVV_DATASHARE_PRIVILEGES.
+func readDatashareGrants(db *DBConnection, d *schema.ResourceData) error {
+	identityType, identityName := "user", d.Get(grantUserAttr).(string)
+	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		identityType, identityName = "group", groupName.(string)
+	}
+
+	query := "SELECT datashare_name, lower(privilege_type) FROM SVV_DATASHARE_PRIVILEGES WHERE identity_type = $1 AND identity_name = $2"
+	queryArgs := []interface{}{identityType, identityName}
+	if isGrantToPublic(d) {
+		query = "SELECT datashare_name, lower(privilege_type) FROM SVV_DATASHARE_PRIVILEGES WHERE identity_type = $1"
+		queryArgs = []interface{}{grantToPublicName}
+	}
+
+	log.Printf("[DEBUG] %s\n", query)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("could not read datashare privileges: %w", err)
+	}
+	defer rows.Close()
+
+	granted := map[string]*schema.Set{}
+	for rows.Next() {
+		var shareName, privilege string
+		if err := rows.Scan(&shareName, &privilege); err != nil {
+			return err
+		}
+		if _, ok := granted[shareName]; !ok {
+			granted[shareName] = schema.NewSet(schema.HashString, nil)
+		}
+		granted[shareName].Add(privilege)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(grantPrivilegesAttr, privilegesGrantedOnAll(granted, setToStringList(d.Get(grantObjectsAttr).(*schema.Set))))
+	return nil
 }
 
-func createGrants(tx *sql.Tx, databaseName string, d *schema.ResourceData) error {
-	if d.Get(grantPrivilegesAttr).(*schema.Set).Len() == 0 {
-		log.Printf("[DEBUG] no privileges to grant for %s", d.Get(grantGroupAttr).(string))
-		return nil
+// privilegesGrantedOnAll returns the privileges granted on every one of the objects, e.g. datashares.
+func privilegesGrantedOnAll(granted map[string]*schema.Set, objectNames []string) *schema.Set {
+	var privileges *schema.Set
+	for _, objectName := range objectNames {
+		objectPrivileges, ok := granted[objectName]
+		if !ok {
+			return schema.NewSet(schema.HashString, nil)
+		}
+		if privileges == nil {
+			privileges = objectPrivileges
+		} else {
+			privileges = privileges.Intersection(objectPrivileges)
+		}
+	}
+	if privileges == nil {
+		return schema.NewSet(schema.HashString, nil)
 	}
+	return privileges
+}
 
-	query := createGrantsQuery(d, databaseName)
+func revokeGrants(tx *sql.Tx, databaseName string, d *schema.ResourceData) error {
+	query := createGrantsRevokeQuery(d, databaseName)
 	_, err := tx.Exec(query)
 	return err
 }
@@ -727,7 +1341,15 @@ func createGrantsRevokeQuery(d *schema.ResourceData, databaseName string) string
 		)
 	case "TABLE":
 		objects := d.Get(grantObjectsAttr).(*schema.Set)
-		if objects.Len() > 0 {
+		if columns := d.Get(grantColumnsAttr).(*schema.Set); columns.Len() > 0 {
+			query = fmt.Sprintf(
+				"REVOKE ALL PRIVILEGES (%s) ON TABLE %s FROM %s %s",
+				setToPgIdentList(columns, ""),
+				setToPgIdentList(objects, d.Get(grantSchemaAttr).(string)),
+				toWhomIndicator,
+				fromEntityName,
+			)
+		} else if objects.Len() > 0 {
 			query = fmt.Sprintf(
 				"REVOKE ALL PRIVILEGES ON %s %s FROM %s %s",
 				strings.ToUpper(d.Get(grantObjectTypeAttr).(string)),
@@ -771,18 +1393,85 @@ func createGrantsRevokeQuery(d *schema.ResourceData, databaseName string) string
 			toWhomIndicator,
 			fromEntityName,
 		)
+	case "DATASHARE":
+		objects := d.Get(grantObjectsAttr).(*schema.Set)
+		query = fmt.Sprintf(
+			"REVOKE %s ON DATASHARE %s FROM %s %s",
+			strings.ToUpper(strings.Join(datasharePrivileges, ",")),
+			setToPgIdentList(objects, ""),
+			toWhomIndicator,
+			fromEntityName,
+		)
 	}
 	log.Printf("[DEBUG] Created REVOKE query: %s", query)
 	return query
 }
 
 func createGrantsQuery(d *schema.ResourceData, databaseName string) string {
-	var query, toWhomIndicator, entityName string
-	var privileges []string
-	for _, p := range d.Get(grantPrivilegesAttr).(*schema.Set).List() {
-		privileges = append(privileges, p.(string))
+	return grantQuery(d, databaseName, setToStringList(d.Get(grantPrivilegesAttr).(*schema.Set)), d.Get(grantWithGrantOptionAttr).(bool))
+}
+
+// grantQuery returns the GRANT statement of the given privileges on the objects of the grant.
+func grantQuery(d *schema.ResourceData, databaseName string, privileges []string, withGrantOption bool) string {
+	toWhomIndicator, toEntityName := getGrantGrantee(d)
+
+	query := fmt.Sprintf(
+		"GRANT %s ON %s TO %s %s",
+		strings.Join(privileges, ","),
+		grantOnClause(d, databaseName),
+		toWhomIndicator,
+		toEntityName,
+	)
+	if withGrantOption {
+		query = fmt.Sprintf("%s WITH GRANT OPTION", query)
 	}
 
+	log.Printf("[DEBUG] Created GRANT query: %s", query)
+	return query
+}
+
+// columnGrantQuery returns the GRANT statement of the privilege on the columns of the tables of the grant.
+func columnGrantQuery(d *schema.ResourceData, privilege string) string {
+	toWhomIndicator, toEntityName := getGrantGrantee(d)
+
+	query := fmt.Sprintf(
+		"GRANT %s (%s) ON TABLE %s TO %s %s",
+		privilege,
+		setToPgIdentList(d.Get(grantColumnsAttr).(*schema.Set), ""),
+		setToPgIdentList(d.Get(grantObjectsAttr).(*schema.Set), d.Get(grantSchemaAttr).(string)),
+		toWhomIndicator,
+		toEntityName,
+	)
+
+	log.Printf("[DEBUG] Created GRANT query: %s", query)
+	return query
+}
+
+func createRevokeGrantOptionQuery(d *schema.ResourceData, databaseName string) string {
+	return revokeGrantOptionQuery(d, databaseName, setToStringList(d.Get(grantPrivilegesAttr).(*schema.Set)))
+}
+
+// revokeGrantOptionQuery returns the statement revoking the grant option of the given privileges
+// on the objects of the grant.
+func revokeGrantOptionQuery(d *schema.ResourceData, databaseName string, privileges []string) string {
+	fromWhomIndicator, fromEntityName := getGrantGrantee(d)
+
+	query := fmt.Sprintf(
+		"REVOKE GRANT OPTION FOR %s ON %s FROM %s %s",
+		strings.Join(privileges, ","),
+		grantOnClause(d, databaseName),
+		fromWhomIndicator,
+		fromEntityName,
+	)
+
+	log.Printf("[DEBUG] Created REVOKE GRANT OPTION query: %s", query)
+	return query
+}
+
+// getGrantGrantee returns the keyword indicating the type of the grantee (if any) and its quoted name.
+func getGrantGrantee(d *schema.ResourceData) (string, string) {
+	var toWhomIndicator, entityName string
+
 	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
 		toWhomIndicator = "GROUP"
 		entityName = groupName.(string)
@@ -793,75 +1482,37 @@ func createGrantsQuery(d *schema.ResourceData, databaseName string) string {
 		entityName = roleName.(string)
 	}
 
-	toEntityName := pq.QuoteIdentifier(entityName)
 	if isGrantToPublic(d) {
-		toWhomIndicator = ""
-		toEntityName = "PUBLIC"
+		return "", "PUBLIC"
 	}
+	return toWhomIndicator, pq.QuoteIdentifier(entityName)
+}
 
-	switch strings.ToUpper(d.Get(grantObjectTypeAttr).(string)) {
+// grantOnClause returns the objects part of a GRANT statement, i.e. everything between ON and TO.
+func grantOnClause(d *schema.ResourceData, databaseName string) string {
+	objectType := strings.ToUpper(d.Get(grantObjectTypeAttr).(string))
+	schemaName := d.Get(grantSchemaAttr).(string)
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+
+	switch objectType {
 	case "DATABASE":
-		query = fmt.Sprintf(
-			"GRANT %s ON DATABASE %s TO %s %s",
-			strings.Join(privileges, ","),
-			pq.QuoteIdentifier(databaseName),
-			toWhomIndicator,
-			toEntityName,
-		)
+		return fmt.Sprintf("DATABASE %s", pq.QuoteIdentifier(databaseName))
 	case "SCHEMA":
-		query = fmt.Sprintf(
-			"GRANT %s ON SCHEMA %s TO %s %s",
-			strings.Join(privileges, ","),
-			pq.QuoteIdentifier(d.Get(grantSchemaAttr).(string)),
-			toWhomIndicator,
-			toEntityName,
-		)
+		return fmt.Sprintf("SCHEMA %s", pq.QuoteIdentifier(schemaName))
+	case "DATASHARE":
+		return fmt.Sprintf("DATASHARE %s", setToPgIdentList(objects, ""))
 	case "TABLE", "LANGUAGE":
-		objects := d.Get(grantObjectsAttr).(*schema.Set)
 		if objects.Len() > 0 {
-			query = fmt.Sprintf(
-				"GRANT %s ON %s %s TO %s %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(d.Get(grantObjectTypeAttr).(string)),
-				setToPgIdentList(objects, d.Get(grantSchemaAttr).(string)),
-				toWhomIndicator,
-				toEntityName,
-			)
-		} else {
-			query = fmt.Sprintf(
-				"GRANT %s ON ALL %sS IN SCHEMA %s TO %s %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(d.Get(grantObjectTypeAttr).(string)),
-				pq.QuoteIdentifier(d.Get(grantSchemaAttr).(string)),
-				toWhomIndicator,
-				toEntityName,
-			)
+			return fmt.Sprintf("%s %s", objectType, setToPgIdentList(objects, schemaName))
 		}
+		return fmt.Sprintf("ALL %sS IN SCHEMA %s", objectType, pq.QuoteIdentifier(schemaName))
 	case "FUNCTION", "PROCEDURE":
-		objects := d.Get(grantObjectsAttr).(*schema.Set)
 		if objects.Len() > 0 {
-			query = fmt.Sprintf(
-				"GRANT %s ON %s %s TO %s %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(d.Get(grantObjectTypeAttr).(string)),
-				setToPgIdentListNotQuoted(objects, d.Get(grantSchemaAttr).(string)),
-				toWhomIndicator,
-				toEntityName,
-			)
-		} else {
-			query = fmt.Sprintf(
-				"GRANT %s ON ALL %sS IN SCHEMA %s TO %s %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(d.Get(grantObjectTypeAttr).(string)),
-				pq.QuoteIdentifier(d.Get(grantSchemaAttr).(string)),
-				toWhomIndicator,
-				toEntityName,
-			)
+			return fmt.Sprintf("%s %s", objectType, setToPgIdentListNotQuoted(objects, schemaName))
 		}
+		return fmt.Sprintf("ALL %sS IN SCHEMA %s", objectType, pq.QuoteIdentifier(schemaName))
 	}
-
-	log.Printf("[DEBUG] Created GRANT query: %s", query)
-	return query
+	return ""
 }
 
 func getDatabaseName(db *DBConnection, d *schema.ResourceData) string {
@@ -872,6 +1523,15 @@ func getDatabaseName(db *DBConnection, d *schema.ResourceData) string {
 	return databaseName
 }
 
+// grantConnectionDatabase returns the database to connect to, which is the database of the objects unless
+// privileges on a database are granted, that works from any database.
+func grantConnectionDatabase(d *schema.ResourceData) string {
+	if d.Get(grantObjectTypeAttr).(string) == "database" {
+		return ""
+	}
+	return d.Get(grantDatabaseAttr).(string)
+}
+
 func isGrantToPublic(d *schema.ResourceData) bool {
 	if _, isGroup := d.GetOk(grantGroupAttr); isGroup {
 		entityName := d.Get(grantGroupAttr).(string)
@@ -901,7 +1561,7 @@ func generateGrantID(d *schema.ResourceData) string {
 	objectType := fmt.Sprintf("ot:%s", d.Get(grantObjectTypeAttr).(string))
 	parts = append(parts, objectType)
 
-	if objectType != "ot:database" && objectType != "ot:language" {
+	if objectType != "ot:database" && objectType != "ot:language" && objectType != "ot:datashare" {
 		parts = append(parts, d.Get(grantSchemaAttr).(string))
 	}
 
@@ -909,5 +1569,128 @@ func generateGrantID(d *schema.ResourceData) string {
 		parts = append(parts, object.(string))
 	}
 
+	for _, column := range d.Get(grantColumnsAttr).(*schema.Set).List() {
+		parts = append(parts, fmt.Sprintf("cn:%s", column.(string)))
+	}
+
 	return strings.Join(parts, "_")
 }
+
+// grantImportIDFormat documents the ID accepted by terraform import, e.g.
+// `user:john:table:analytics:events,clicks` or `group:public:database`.
+const grantImportIDFormat = "<user|group>:<grantee>:<object_type>[:<schema>|:<database>][:<object>,...]"
+
+type grantImportID struct {
+	granteeAttr string
+	grantee     string
+	objectType  string
+	schema      string
+	database    string
+	objects     []string
+}
+
+// parseGrantImportID parses the import ID of a grant. The schema is required for schemas, tables,
+// functions and procedures, omitting the objects means all objects of the type in the schema.
+// Languages and datashares take the objects directly after the object type, databases optionally the database name.
+func parseGrantImportID(id string) (*grantImportID, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid grant import ID %q, %s, expected format %s", id, reason, grantImportIDFormat)
+	}
+
+	parts := strings.SplitN(id, ":", 5)
+	if len(parts) < 3 {
+		return nil, invalid("missing grantee or object type")
+	}
+
+	parsed := &grantImportID{grantee: parts[1], objectType: parts[2]}
+	switch parts[0] {
+	case "user":
+		parsed.granteeAttr = grantUserAttr
+	case "group":
+		parsed.granteeAttr = grantGroupAttr
+	default:
+		return nil, invalid(fmt.Sprintf("the grantee type must be user or group but is %q", parts[0]))
+	}
+	if parsed.grantee == "" {
+		return nil, invalid("the grantee is empty")
+	}
+
+	args := parts[3:]
+	switch parsed.objectType {
+	case "database":
+		if len(args) > 1 {
+			return nil, invalid("databases take at most the database name")
+		}
+		if len(args) == 1 {
+			parsed.database = args[0]
+		}
+	case "language", "datashare":
+		if len(args) != 1 || args[0] == "" {
+			return nil, invalid(fmt.Sprintf("%ss take the %s names", parsed.objectType, parsed.objectType))
+		}
+		parsed.objects = splitGrantObjects(args[0])
+	case "schema":
+		if len(args) != 1 || args[0] == "" {
+			return nil, invalid("schemas take the schema name")
+		}
+		parsed.schema = args[0]
+	case "table", "function", "procedure":
+		if len(args) == 0 || args[0] == "" {
+			return nil, invalid(fmt.Sprintf("the schema of the %s is missing", parsed.objectType))
+		}
+		parsed.schema = args[0]
+		if len(args) == 2 {
+			parsed.objects = splitGrantObjects(args[1])
+		}
+	default:
+		return nil, invalid(fmt.Sprintf("the object type must be one of %s", strings.Join(grantAllowedObjectTypes, ", ")))
+	}
+
+	return parsed, nil
+}
+
+// splitGrantObjects splits a comma separated list of objects, keeping the commas separating
+// the argument types of functions and procedures, e.g. `f_add(int, int),f_now()`.
+func splitGrantObjects(value string) []string {
+	var objects []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				objects = append(objects, strings.TrimSpace(value[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	objects = append(objects, strings.TrimSpace(value[start:]))
+	return objects
+}
+
+func resourceRedshiftGrantImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parsed, err := parseGrantImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set(parsed.granteeAttr, parsed.grantee)
+	d.Set(grantObjectTypeAttr, parsed.objectType)
+	if parsed.schema != "" {
+		d.Set(grantSchemaAttr, parsed.schema)
+	}
+	if parsed.database != "" {
+		d.Set(grantDatabaseAttr, parsed.database)
+	}
+	d.Set(grantObjectsAttr, parsed.objects)
+	// The privileges and grant options are read from the catalog
+	d.Set(grantPrivilegesAttr, []string{})
+	d.Set(grantWithGrantOptionAttr, false)
+	d.Set(grantReapplyOnGrantorChangeAttr, false)
+	d.SetId(generateGrantID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -2,11 +2,15 @@ package redshift
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/lib/pq"
 )
@@ -224,6 +228,140 @@ func TestAccRedshiftGrant_BasicDatabase(t *testing.T) {
 	}
 }
 
+func TestAccRedshiftGrant_DatabaseAccessDrift(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_grant_db_access")
+	config := func(privileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_grant" "database" {
+  user        = redshift_user.user.name
+  object_type = "database"
+  privileges  = %[2]s
+}
+`, userName, privileges)
+	}
+
+	revokeOutOfBand := func() {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("couldn't start redshift connection: %s", err)
+		}
+		query := fmt.Sprintf("REVOKE TEMPORARY ON DATABASE %s FROM %s", pq.QuoteIdentifier(client.config.Database), pq.QuoteIdentifier(userName))
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("couldn't revoke database privilege: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`["connect"]`),
+				ExpectError: regexp.MustCompile("there is no CONNECT privilege on databases in Redshift"),
+			},
+			{
+				Config: config(`["create", "temporary"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "create"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "temporary"),
+				),
+			},
+			{
+				// Revoking a privilege outside of Terraform must be detected as drift
+				PreConfig:          revokeOutOfBand,
+				Config:             config(`["create", "temporary"]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(`["create", "temporary"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "temporary"),
+				),
+			},
+			{
+				// TEMP is read back as TEMPORARY, the configured alias must be kept
+				Config: config(`["create", "temp"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "temp"),
+				),
+			},
+			{
+				Config:   config(`["create", "temp"]`),
+				PlanOnly: true,
+			},
+			{
+				Config: config(`["create"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "create"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateGrantPrivileges(t *testing.T) {
+	tests := map[string]struct {
+		privileges []string
+		objectType string
+		err        string
+	}{
+		"database privileges": {
+			privileges: []string{"create", "temporary", "usage"},
+			objectType: "database",
+		},
+		"temp on database": {
+			privileges: []string{"TEMP"},
+			objectType: "database",
+		},
+		"temp on schema": {
+			privileges: []string{"temp"},
+			objectType: "schema",
+			err:        "invalid privileges list",
+		},
+		"connect on database": {
+			privileges: []string{"CONNECT"},
+			objectType: "database",
+			err:        "there is no CONNECT privilege on databases in Redshift",
+		},
+		"invalid database privilege": {
+			privileges: []string{"select"},
+			objectType: "database",
+			err:        "invalid privileges list",
+		},
+		"connect on schema": {
+			privileges: []string{"connect"},
+			objectType: "schema",
+			err:        "invalid privileges list",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateGrantPrivileges(tt.privileges, tt.objectType)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q but got %v", tt.err, err)
+			}
+		})
+	}
+}
+
 func TestAccRedshiftGrant_BasicSchema(t *testing.T) {
 	groupNames := []string{
 		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
@@ -382,256 +520,1525 @@ func TestAccRedshiftGrant_BasicTable(t *testing.T) {
 	}
 }
 
-func TestAccRedshiftGrant_BasicCallables(t *testing.T) {
-	groupNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group@tf_acc_domain.tld"), "-", "_"),
-	}
-	userNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
-	}
-	schema := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_basic"), "-", "_")
+func TestAccRedshiftGrant_WithGrantOption(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_")
 
-	for i, groupName := range groupNames {
-		userName := userNames[i]
-		resource.Test(t, resource.TestCase{
-			PreCheck:          func() { testAccPreCheck(t) },
-			ProviderFactories: testAccProviders,
-			CheckDestroy:      func(s *terraform.State) error { return nil },
-			Steps: []resource.TestStep{
-				{
-					Config: testAccRedshiftGrantBasicCallablesConfigUserGroup(userName, groupName, schema),
-				},
-				{
-					PreConfig: func() {
-						dbClient := testAccProvider.Meta().(*Client)
-						conn, err := dbClient.Connect()
-						defer dbClient.Close()
-						if err != nil {
-							t.Fatalf("couldn't start redshift connection: %s", err)
-						}
-						err = testAccRedshiftGrantBasicCallablesCreateSchemaAndCallables(t, conn, schema)
-						if err != nil {
-							t.Fatalf("couldn't setup database: %s", err)
-						}
-					},
-					Config: testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
-					Check: resource.ComposeTestCheckFunc(
-						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "id", fmt.Sprintf("gn:%s_ot:function_%s_test_call(float,float)", groupName, schema)),
-						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "group", groupName),
-						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "object_type", "function"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_fun", "privileges.*", "execute"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "id", fmt.Sprintf("gn:%s_ot:procedure_%s_test_call()", groupName, schema)),
-						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "group", groupName),
-						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "object_type", "procedure"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_proc", "privileges.*", "execute"),
+	config := func(withGrantOption bool) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  password = "TestPassword123"
+}
 
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "id", fmt.Sprintf("un:%s_ot:function_%s_test_call(int,int)_test_call(float,float)", userName, schema)),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "user", userName),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "object_type", "function"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user_fun", "privileges.*", "execute"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "id", fmt.Sprintf("un:%s_ot:procedure_%s_test_call()", userName, schema)),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "user", userName),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "object_type", "procedure"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user_proc", "privileges.*", "execute"),
-					),
-				},
-				{
-					Config:  testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
-					Destroy: true,
-				},
-				// Creating additional dummy step as TestStep does not have PostConfig
-				// property, so clean up cannot be performed in the previous one.
-				{
-					PreConfig: func() {
-						dbClient := testAccProvider.Meta().(*Client)
-						conn, err := dbClient.Connect()
-						defer dbClient.Close()
-						if err != nil {
-							t.Errorf("couldn't cleanup resources: %s", err)
-						}
-						err = testAccRedshiftGrantBasicCallablesDropResources(t, conn, schema)
-						if err != nil {
-							t.Errorf("couldn't cleanup resources: %s", err)
-						}
-					},
-					Config:   testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
-					PlanOnly: true,
-					Destroy:  true,
-				},
-			},
-		})
+resource "redshift_grant" "grant" {
+  user = redshift_user.user.name
+  schema = "pg_catalog"
+
+  object_type = "table"
+  objects = ["pg_user_info"]
+  privileges = ["select"]
+  with_grant_option = %[2]t
+}
+`, userName, withGrantOption)
 	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "false"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "select", true),
+					testAccCheckRedshiftGrantTableGrantOption(userName, "pg_catalog", "pg_user_info", false),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "true"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "select", true),
+					testAccCheckRedshiftGrantTableGrantOption(userName, "pg_catalog", "pg_user_info", true),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "false"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "select", true),
+					testAccCheckRedshiftGrantTableGrantOption(userName, "pg_catalog", "pg_user_info", false),
+				),
+			},
+		},
+	})
 }
 
-func TestAccRedshiftGrant_BasicLanguage(t *testing.T) {
-	groupNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group@tf_acc_domain.tld"), "-", "_"),
-	}
-	userNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
-	}
-	addedLanguage := "plpythonu"
-	secondLanguage := "plpgsql"
+func testAccCheckRedshiftGrantTablePrivilege(userName, tableName, privilege string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
 
-	for i, groupName := range groupNames {
-		userName := userNames[i]
-		config := fmt.Sprintf(`
-		resource "redshift_user" "user" {
-		  name = %[1]q
+		var hasPrivilege bool
+		if err := db.QueryRow("SELECT has_table_privilege($1, $2, $3)", userName, tableName, privilege).Scan(&hasPrivilege); err != nil {
+			return fmt.Errorf("error checking privilege %q of %q on %q: %w", privilege, userName, tableName, err)
 		}
-		
-		resource "redshift_group" "group" {
-		  name = %[2]q
+		if hasPrivilege != expected {
+			return fmt.Errorf("expected privilege %q of %q on %q to be %t but was %t", privilege, userName, tableName, expected, hasPrivilege)
 		}
-		
-		resource "redshift_grant" "grant" {
-		  group  = redshift_group.group.name
-		  objects = [%[3]q, %[4]q]
-		
-		  object_type = "language"
-		  privileges = ["usage"]
+		return nil
+	}
+}
+
+func testAccCheckRedshiftGrantTableGrantOption(userName, schemaName, tableName string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
 		}
-		
-		resource "redshift_grant" "grant_user" {
-		  user = redshift_user.user.name
-		  objects = [%[3]q, %[4]q]
-		
-		  object_type = "language"
-		  privileges = ["usage"]
+
+		var rawAcl string
+		query := `
+  SELECT COALESCE(array_to_string(relacl, '|'), '')
+  FROM pg_class cl
+  JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2
+`
+		if err := db.QueryRow(query, schemaName, tableName).Scan(&rawAcl); err != nil {
+			return fmt.Errorf("error reading ACL of %s.%s: %w", schemaName, tableName, err)
+		}
+		entries, err := parseAcl(rawAcl)
+		if err != nil {
+			return err
 		}
-		`, userName, groupName, addedLanguage, secondLanguage)
-		resource.Test(t, resource.TestCase{
-			PreCheck:          func() { testAccPreCheck(t) },
-			ProviderFactories: testAccProviders,
-			CheckDestroy:      func(s *terraform.State) error { return nil },
-			Steps: []resource.TestStep{
-				{
-					Config: config,
-					Check: resource.ComposeTestCheckFunc(
-						resource.TestCheckResourceAttr("redshift_grant.grant", "id", fmt.Sprintf("gn:%s_ot:language_%s_%s", groupName, addedLanguage, secondLanguage)),
-						resource.TestCheckResourceAttr("redshift_grant.grant", "group", groupName),
-						resource.TestCheckResourceAttr("redshift_grant.grant", "object_type", "language"),
-						resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "usage"),
 
-						resource.TestCheckResourceAttr("redshift_grant.grant_user", "id", fmt.Sprintf("un:%s_ot:language_%s_%s", userName, addedLanguage, secondLanguage)),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user", "user", userName),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user", "object_type", "language"),
-						resource.TestCheckResourceAttr("redshift_grant.grant_user", "privileges.#", "1"),
-						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user", "privileges.*", "usage"),
-					),
-				},
-			},
-		})
+		withGrantOption := false
+		for _, entry := range entries {
+			if entry.Grantee == userName {
+				withGrantOption = aclHasGrantOption(entry.Privileges)
+			}
+		}
+		if withGrantOption != expected {
+			return fmt.Errorf("expected grant option of %q on %s.%s to be %t but was %t", userName, schemaName, tableName, expected, withGrantOption)
+		}
+		return nil
 	}
 }
 
-func TestAccRedshiftGrant_Regression_GH_Issue_24(t *testing.T) {
-	userNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
+func TestCreateGrantOptionQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:            "bob",
+		grantSchemaAttr:          "test",
+		grantObjectTypeAttr:      "table",
+		grantObjectsAttr:         []interface{}{"tbl"},
+		grantPrivilegesAttr:      []interface{}{"select"},
+		grantWithGrantOptionAttr: true,
+	})
+
+	expected := `GRANT select ON TABLE "test"."tbl" TO  "bob" WITH GRANT OPTION`
+	if got := createGrantsQuery(d, "db"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
 	}
-	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grant"), "-", "_")
-	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_db_grant"), "-", "_")
 
-	for _, userName := range userNames {
-		config := fmt.Sprintf(`
-		resource "redshift_user" "user" {
-		name = %[1]q
-		}
+	expected = `REVOKE GRANT OPTION FOR select ON TABLE "test"."tbl" FROM  "bob"`
+	if got := createRevokeGrantOptionQuery(d, "db"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
 
-		# Create a group named the same as user
-		resource "redshift_group" "group" {
-		name = %[1]q
-		}
+func TestAccRedshiftGrant_GrantablePrivileges(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_user")
+	schemaName := generateRandomObjectName("tf_acc_schema_grantable")
+	tableName := generateRandomObjectName("tf_acc_table_grantable")
 
-		# Create a schema and set user as owner
-		resource "redshift_schema" "schema" {
-		name = %[2]q
+	config := func(grantablePrivileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
 
-		owner = redshift_user.user.name
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_table" "table" {
+  schema = redshift_schema.schema.name
+  name   = %[3]q
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_grant" "grant" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_table.table.name]
+  privileges  = ["select", "insert", "update"]
+
+  grantable_privileges = %[4]s
+}
+`, userName, schemaName, tableName, grantablePrivileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["select"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "grantable_privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "grantable_privileges.*", "select"),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "select", true),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "insert", false),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "update", false),
+				),
+			},
+			{
+				Config: config(`["insert", "update"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "grantable_privileges.#", "2"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "3"),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "select", false),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "insert", true),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "update", true),
+				),
+			},
+			{
+				Config: config(`[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "grantable_privileges.#", "0"),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "insert", false),
+					testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, "update", false),
+				),
+			},
+			{
+				Config:      config(`["delete"]`),
+				ExpectError: regexp.MustCompile("must be a subset of `privileges`"),
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftGrantTablePrivilegeGrantOption(userName, schemaName, tableName, privilege string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
 		}
 
-		# The schema owner user will have all (create, usage) privileges on the schema
-		# Set only 'create' privilege to a group with the same name as user. In previous versions this would trigger a permanent diff in plan.
-		resource "redshift_grant" "schema" {
-		group = redshift_group.group.name
-		schema = redshift_schema.schema.name
+		var rawAcl string
+		query := `
+  SELECT COALESCE(array_to_string(relacl, '|'), '')
+  FROM pg_class cl
+  JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2
+`
+		if err := db.QueryRow(query, schemaName, tableName).Scan(&rawAcl); err != nil {
+			return fmt.Errorf("error reading ACL of %s.%s: %w", schemaName, tableName, err)
+		}
+		entries, err := parseAcl(rawAcl)
+		if err != nil {
+			return err
+		}
 
-		object_type = "schema"
-		privileges = ["create"]
+		withGrantOption := false
+		for _, entry := range entries {
+			if entry.Grantee == userName {
+				withGrantOption = aclPrivilegeHasGrantOption(entry.Privileges, privilege)
+			}
 		}
-		`, userName, schemaName, dbName)
+		if withGrantOption != expected {
+			return fmt.Errorf("expected grant option of %s for %q on %s.%s to be %t but was %t", privilege, userName, schemaName, tableName, expected, withGrantOption)
+		}
+		return nil
+	}
+}
+
+func TestGrantStatementsWithGrantablePrivileges(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:                "bob",
+		grantSchemaAttr:              "test",
+		grantObjectTypeAttr:          "table",
+		grantObjectsAttr:             []interface{}{"tbl"},
+		grantPrivilegesAttr:          []interface{}{"select", "insert"},
+		grantGrantablePrivilegesAttr: []interface{}{"select"},
+	})
+
+	expected := []string{
+		createGrantsRevokeQuery(d, "db"),
+		`GRANT insert ON TABLE "test"."tbl" TO  "bob"`,
+		`GRANT select ON TABLE "test"."tbl" TO  "bob" WITH GRANT OPTION`,
+	}
+	if got := grantStatements(d, "db"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	expectedRevoke := `REVOKE GRANT OPTION FOR select ON TABLE "test"."tbl" FROM  "bob"`
+	if got := revokeGrantOptionQuery(d, "db", []string{"select"}); got != expectedRevoke {
+		t.Errorf("Expected %q but got %q", expectedRevoke, got)
+	}
+}
+
+func TestValidateGrantOption(t *testing.T) {
+	tests := map[string]struct {
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		"grantable subset": {
+			raw: map[string]interface{}{
+				grantUserAttr:                "bob",
+				grantPrivilegesAttr:          []interface{}{"select", "insert"},
+				grantGrantablePrivilegesAttr: []interface{}{"select"},
+			},
+		},
+		"grantable not granted": {
+			raw: map[string]interface{}{
+				grantUserAttr:                "bob",
+				grantPrivilegesAttr:          []interface{}{"select"},
+				grantGrantablePrivilegesAttr: []interface{}{"insert"},
+			},
+			wantErr: true,
+		},
+		"grantable to group": {
+			raw: map[string]interface{}{
+				grantGroupAttr:               "analysts",
+				grantPrivilegesAttr:          []interface{}{"select"},
+				grantGrantablePrivilegesAttr: []interface{}{"select"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.raw[grantObjectTypeAttr] = "table"
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, tt.raw)
+			if err := validateGrantOption(d); (err != nil) != tt.wantErr {
+				t.Errorf("validateGrantOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_AllPrivileges(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group_all")
+	schemaName := generateRandomObjectName("tf_acc_schema_all")
+	tableName := generateRandomObjectName("tf_acc_table_all")
+	ownerName := generateRandomObjectName("tf_acc_owner_all")
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_table" "table" {
+  schema = redshift_schema.schema.name
+  name   = %[3]q
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_grant" "database" {
+  group       = redshift_group.group.name
+  object_type = "database"
+  privileges  = ["ALL"]
+}
+
+resource "redshift_grant" "schema" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["all"]
+}
+
+resource "redshift_grant" "table" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_table.table.name]
+  privileges  = ["all"]
+}
+
+resource "redshift_user" "owner" {
+  name = %[4]q
+}
+
+resource "redshift_default_privileges" "table" {
+  group       = redshift_group.group.name
+  owner       = redshift_user.owner.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  privileges  = ["all"]
+}
+`, groupName, schemaName, tableName, ownerName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "all"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.schema", "privileges.*", "all"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.table", "privileges.*", "all"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.table", "privileges.*", "all"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_LateBindingView(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_user_late_binding")
+	schemaName := generateRandomObjectName("tf_acc_schema_late_binding")
+	viewName := generateRandomObjectName("tf_acc_view_late_binding")
+
+	// The underlying table doesn't exist, late-binding views are not validated
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_view" "view" {
+  schema                 = redshift_schema.schema.name
+  name                   = %[3]q
+  query                  = "SELECT id FROM ${redshift_schema.schema.name}.missing_table"
+  with_no_schema_binding = true
+}
+
+resource "redshift_grant" "view" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_view.view.name]
+  privileges  = ["select"]
+}
+`, userName, schemaName, viewName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.view", "privileges.#", "1"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, fmt.Sprintf("%s.%s", schemaName, viewName), "select", true),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_LateBindingViewOverExternalTable(t *testing.T) {
+	roleArn := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_IAM_ROLE_ARN", t)
+	userName := generateRandomObjectName("tf_acc_user_late_binding")
+	schemaName := generateRandomObjectName("tf_acc_schema_late_binding")
+	externalSchemaName := generateRandomObjectName("tf_acc_external_late_binding")
+	viewName := generateRandomObjectName("tf_acc_view_late_binding")
+
+	// The external table is not accessible, neither when creating the view nor when reading the grant
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_external_schema" "spectrum" {
+  name                   = %[3]q
+  database_name          = %[3]q
+  drop_external_database = true
+
+  data_catalog_source {
+    iam_role_arns                          = [%[5]q]
+    create_external_database_if_not_exists = true
+  }
+}
+
+resource "redshift_view" "view" {
+  schema                 = redshift_schema.schema.name
+  name                   = %[4]q
+  query                  = "SELECT id FROM ${redshift_external_schema.spectrum.name}.events"
+  with_no_schema_binding = true
+}
+
+resource "redshift_grant" "view" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_view.view.name]
+  privileges  = ["select"]
+}
+`, userName, schemaName, externalSchemaName, viewName, roleArn)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.view", "privileges.#", "1"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, fmt.Sprintf("%s.%s", schemaName, viewName), "select", true),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_BasicCallables(t *testing.T) {
+	groupNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group@tf_acc_domain.tld"), "-", "_"),
+	}
+	userNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
+	}
+	schema := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_basic"), "-", "_")
+
+	for i, groupName := range groupNames {
+		userName := userNames[i]
 		resource.Test(t, resource.TestCase{
 			PreCheck:          func() { testAccPreCheck(t) },
 			ProviderFactories: testAccProviders,
 			CheckDestroy:      func(s *terraform.State) error { return nil },
 			Steps: []resource.TestStep{
 				{
-					Config: config,
-					Check:  resource.ComposeTestCheckFunc(),
+					Config: testAccRedshiftGrantBasicCallablesConfigUserGroup(userName, groupName, schema),
 				},
-				// The 'ExpectNonEmptyPlan: false' option will fail the test if second run on the same config  will show any changes
 				{
-					Config:             config,
-					Check:              resource.ComposeTestCheckFunc(),
-					ExpectNonEmptyPlan: false,
+					PreConfig: func() {
+						dbClient := testAccProvider.Meta().(*Client)
+						conn, err := dbClient.Connect()
+						defer dbClient.Close()
+						if err != nil {
+							t.Fatalf("couldn't start redshift connection: %s", err)
+						}
+						err = testAccRedshiftGrantBasicCallablesCreateSchemaAndCallables(t, conn, schema)
+						if err != nil {
+							t.Fatalf("couldn't setup database: %s", err)
+						}
+					},
+					Config: testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "id", fmt.Sprintf("gn:%s_ot:function_%s_test_call(float,float)", groupName, schema)),
+						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "group", groupName),
+						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "object_type", "function"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_fun", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_fun", "privileges.*", "execute"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "id", fmt.Sprintf("gn:%s_ot:procedure_%s_test_call()", groupName, schema)),
+						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "group", groupName),
+						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "object_type", "procedure"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_proc", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_proc", "privileges.*", "execute"),
+
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "id", fmt.Sprintf("un:%s_ot:function_%s_test_call(int,int)_test_call(float,float)", userName, schema)),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "user", userName),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "object_type", "function"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_fun", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user_fun", "privileges.*", "execute"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "id", fmt.Sprintf("un:%s_ot:procedure_%s_test_call()", userName, schema)),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "user", userName),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "object_type", "procedure"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user_proc", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user_proc", "privileges.*", "execute"),
+					),
+				},
+				{
+					Config:  testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
+					Destroy: true,
+				},
+				// Creating additional dummy step as TestStep does not have PostConfig
+				// property, so clean up cannot be performed in the previous one.
+				{
+					PreConfig: func() {
+						dbClient := testAccProvider.Meta().(*Client)
+						conn, err := dbClient.Connect()
+						defer dbClient.Close()
+						if err != nil {
+							t.Errorf("couldn't cleanup resources: %s", err)
+						}
+						err = testAccRedshiftGrantBasicCallablesDropResources(t, conn, schema)
+						if err != nil {
+							t.Errorf("couldn't cleanup resources: %s", err)
+						}
+					},
+					Config:   testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(userName, groupName, schema),
+					PlanOnly: true,
+					Destroy:  true,
 				},
 			},
 		})
 	}
 }
 
-func TestAccRedshiftGrant_Regression_Issue_43(t *testing.T) {
-	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_grant"), "-", "_")
+func TestAccRedshiftGrant_BasicLanguage(t *testing.T) {
+	groupNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group@tf_acc_domain.tld"), "-", "_"),
+	}
+	userNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
+	}
+	addedLanguage := "plpythonu"
+	secondLanguage := "plpgsql"
+
+	for i, groupName := range groupNames {
+		userName := userNames[i]
+		config := fmt.Sprintf(`
+		resource "redshift_user" "user" {
+		  name = %[1]q
+		}
+		
+		resource "redshift_group" "group" {
+		  name = %[2]q
+		}
+		
+		resource "redshift_grant" "grant" {
+		  group  = redshift_group.group.name
+		  objects = [%[3]q, %[4]q]
+		
+		  object_type = "language"
+		  privileges = ["usage"]
+		}
+		
+		resource "redshift_grant" "grant_user" {
+		  user = redshift_user.user.name
+		  objects = [%[3]q, %[4]q]
+		
+		  object_type = "language"
+		  privileges = ["usage"]
+		}
+		`, userName, groupName, addedLanguage, secondLanguage)
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviders,
+			CheckDestroy:      func(s *terraform.State) error { return nil },
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("redshift_grant.grant", "id", fmt.Sprintf("gn:%s_ot:language_%s_%s", groupName, addedLanguage, secondLanguage)),
+						resource.TestCheckResourceAttr("redshift_grant.grant", "group", groupName),
+						resource.TestCheckResourceAttr("redshift_grant.grant", "object_type", "language"),
+						resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "usage"),
+
+						resource.TestCheckResourceAttr("redshift_grant.grant_user", "id", fmt.Sprintf("un:%s_ot:language_%s_%s", userName, addedLanguage, secondLanguage)),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user", "user", userName),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user", "object_type", "language"),
+						resource.TestCheckResourceAttr("redshift_grant.grant_user", "privileges.#", "1"),
+						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user", "privileges.*", "usage"),
+					),
+				},
+			},
+		})
+	}
+}
+
+func TestAccRedshiftGrant_Regression_GH_Issue_24(t *testing.T) {
+	userNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
+	}
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grant"), "-", "_")
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_db_grant"), "-", "_")
+
+	for _, userName := range userNames {
+		config := fmt.Sprintf(`
+		resource "redshift_user" "user" {
+		name = %[1]q
+		}
+
+		# Create a group named the same as user
+		resource "redshift_group" "group" {
+		name = %[1]q
+		}
+
+		# Create a schema and set user as owner
+		resource "redshift_schema" "schema" {
+		name = %[2]q
+
+		owner = redshift_user.user.name
+		}
+
+		# The schema owner user will have all (create, usage) privileges on the schema
+		# Set only 'create' privilege to a group with the same name as user. In previous versions this would trigger a permanent diff in plan.
+		resource "redshift_grant" "schema" {
+		group = redshift_group.group.name
+		schema = redshift_schema.schema.name
+
+		object_type = "schema"
+		privileges = ["create"]
+		}
+		`, userName, schemaName, dbName)
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviders,
+			CheckDestroy:      func(s *terraform.State) error { return nil },
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check:  resource.ComposeTestCheckFunc(),
+				},
+				// The 'ExpectNonEmptyPlan: false' option will fail the test if second run on the same config  will show any changes
+				{
+					Config:             config,
+					Check:              resource.ComposeTestCheckFunc(),
+					ExpectNonEmptyPlan: false,
+				},
+			},
+		})
+	}
+}
+
+func TestAccRedshiftGrant_Regression_Issue_43(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_grant"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name      = %[1]q
+}
+
+resource "redshift_group" "y_schema" {
+  name  = "y_schema"
+  users = [redshift_user.user.name]
+}
+
+resource "redshift_group" "y" {
+  name  = "y"
+  users = [redshift_user.user.name]
+}
+
+resource "redshift_schema" "x" {
+  name  = "x"
+  owner = redshift_user.user.name
+}
+
+resource "redshift_schema" "schema_x" {
+  name  = "schema_x"
+  owner = redshift_user.user.name
+}
+
+resource "redshift_grant" "grants1" {
+  group       = redshift_group.y_schema.name
+  schema      = redshift_schema.x.name
+  object_type = "schema"
+  privileges  = ["USAGE"]
+}
+
+resource "redshift_grant" "grants2" {
+  group       = redshift_group.y.name
+  schema      = redshift_schema.schema_x.name
+  object_type = "schema"
+  privileges  = ["USAGE"]
+}
+`, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccRedshiftGrantRegressionIssue43CompareIds("redshift_grant.grants1", "redshift_grant.grants2"),
+			},
+		},
+	})
+}
+
+func testAccRedshiftGrantRegressionIssue43CompareIds(addr1 string, addr2 string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs1, ok := s.RootModule().Resources[addr1]
+		if !ok {
+			return fmt.Errorf("not found: %q", addr1)
+		}
+		rs2, ok := s.RootModule().Resources[addr2]
+		if !ok {
+			return fmt.Errorf("not found: %q", addr2)
+		}
+
+		if rs1.Primary.ID == rs2.Primary.ID {
+			return fmt.Errorf("resources %q and %q have the same ID: %q", addr1, addr2, rs1.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccRedshiftGrantBasicCallablesConfigUserGroup(username, group, _ string) string {
+	return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+`, username, group)
+}
+
+func testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(username, group, schema string) string {
+	return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_grant" "grant_fun" {
+	schema = %[3]q
+  group  = redshift_group.group.name
+  objects = ["test_call(float,float)"]
+
+  object_type = "function"
+  privileges = ["execute"]
+}
+
+resource "redshift_grant" "grant_proc" {
+	schema = %[3]q
+  group  = redshift_group.group.name
+  objects = ["test_call()"]
+
+  object_type = "procedure"
+  privileges = ["execute"]
+}
+
+resource "redshift_grant" "grant_user_fun" {
+	schema = %[3]q
+  user = redshift_user.user.name
+  objects = ["test_call(float,float)", "test_call(int,int)"]
+
+  object_type = "function"
+  privileges = ["execute"]
+}
+
+resource "redshift_grant" "grant_user_proc" {
+	schema = %[3]q
+  user = redshift_user.user.name
+  objects = ["test_call()"]
+
+  object_type = "procedure"
+  privileges = ["execute"]
+}
+`, username, group, schema)
+}
+
+func testAccRedshiftGrantBasicCallablesCreateSchemaAndCallables(_ *testing.T, db *DBConnection, schema string) error {
+	_, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(schema)))
+	if err != nil {
+		return fmt.Errorf("couldn't create schema: %s", err)
+	}
+
+	function := fmt.Sprintf(`
+	create function %s.test_call (a float, b float)
+		returns float
+	stable
+	as $$
+		if a > b:
+			return a
+		return b
+	$$ language plpythonu;
+`, schema)
+
+	_, err = db.Exec(function)
+	if err != nil {
+		return fmt.Errorf("couldn't create function: %s", err)
+	}
+
+	function2 := fmt.Sprintf(`
+	create function %s.test_call (a int, b int)
+		returns int
+	stable
+	as $$
+		if a > b:
+			return a
+		return b
+	$$ language plpythonu;
+`, schema)
+
+	_, err = db.Exec(function2)
+	if err != nil {
+		return fmt.Errorf("couldn't create function2: %s", err)
+	}
+
+	procedure := fmt.Sprintf(`
+	CREATE PROCEDURE %s.test_call() AS $$
+		BEGIN
+	RAISE NOTICE 'Hello, world!';
+		END
+	$$ LANGUAGE plpgsql;
+	`, schema)
+
+	_, err = db.Exec(procedure)
+	if err != nil {
+		return fmt.Errorf("couldn't create procedure: %s", err)
+	}
+
+	return nil
+}
+
+func testAccRedshiftGrantBasicCallablesDropResources(_ *testing.T, db *DBConnection, schema string) error {
+	query := fmt.Sprintf("DROP SCHEMA %s CASCADE", pq.QuoteIdentifier(schema))
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("couldn't drop test schema: %s", err)
+	}
+	return nil
+}
+
+func TestAccRedshiftGrant_Import(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_user_grant_import")
+	groupName := generateRandomObjectName("tf_acc_group_grant_import")
+	schemaName := generateRandomObjectName("tf_acc_schema_grant_import")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[3]q
+}
+
+resource "redshift_table" "events" {
+  schema = redshift_schema.schema.name
+  name   = "events"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_grant" "schema" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+
+resource "redshift_grant" "table" {
+  user                 = redshift_user.user.name
+  schema               = redshift_schema.schema.name
+  object_type          = "table"
+  objects              = [redshift_table.events.name]
+  privileges           = ["select", "insert"]
+  grantable_privileges = ["select"]
+}
+
+resource "redshift_grant" "database" {
+  group       = redshift_group.group.name
+  object_type = "database"
+  privileges  = ["temporary"]
+}
+`, userName, groupName, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:      "redshift_grant.schema",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("group:%s:schema:%s", groupName, schemaName),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_grant.table",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("user:%s:table:%s:events", userName, schemaName),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_grant.database",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("group:%s:database", groupName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseGrantImportID(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		expected *grantImportID
+		wantErr  bool
+	}{
+		"database": {
+			id:       "group:public:database",
+			expected: &grantImportID{granteeAttr: grantGroupAttr, grantee: "public", objectType: "database"},
+		},
+		"named database": {
+			id:       "user:john:database:analytics",
+			expected: &grantImportID{granteeAttr: grantUserAttr, grantee: "john", objectType: "database", database: "analytics"},
+		},
+		"schema": {
+			id:       "group:analysts:schema:sales",
+			expected: &grantImportID{granteeAttr: grantGroupAttr, grantee: "analysts", objectType: "schema", schema: "sales"},
+		},
+		"all tables": {
+			id:       "user:john:table:sales",
+			expected: &grantImportID{granteeAttr: grantUserAttr, grantee: "john", objectType: "table", schema: "sales"},
+		},
+		"tables": {
+			id:       "user:john:table:sales:orders,customers",
+			expected: &grantImportID{granteeAttr: grantUserAttr, grantee: "john", objectType: "table", schema: "sales", objects: []string{"orders", "customers"}},
+		},
+		"functions with arguments": {
+			id:       "user:john:function:sales:f_add(int, int),f_now()",
+			expected: &grantImportID{granteeAttr: grantUserAttr, grantee: "john", objectType: "function", schema: "sales", objects: []string{"f_add(int, int)", "f_now()"}},
+		},
+		"languages": {
+			id:       "group:developers:language:plpythonu,sql",
+			expected: &grantImportID{granteeAttr: grantGroupAttr, grantee: "developers", objectType: "language", objects: []string{"plpythonu", "sql"}},
+		},
+		"datashares": {
+			id:       "user:john:datashare:sales_share",
+			expected: &grantImportID{granteeAttr: grantUserAttr, grantee: "john", objectType: "datashare", objects: []string{"sales_share"}},
+		},
+		"datashare without name": {
+			id:      "user:john:datashare",
+			wantErr: true,
+		},
+		"resource id": {
+			id:      "un:john_ot:table_sales_orders",
+			wantErr: true,
+		},
+		"role": {
+			id:      "role:admin:schema:sales",
+			wantErr: true,
+		},
+		"empty grantee": {
+			id:      "user::schema:sales",
+			wantErr: true,
+		},
+		"unknown object type": {
+			id:      "user:john:view:sales",
+			wantErr: true,
+		},
+		"schema without name": {
+			id:      "user:john:schema",
+			wantErr: true,
+		},
+		"table without schema": {
+			id:      "user:john:table",
+			wantErr: true,
+		},
+		"database with objects": {
+			id:      "user:john:database:analytics:public",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseGrantImportID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGrantImportID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_GranteeTypeMismatch(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_grant_role")
+	groupName := generateRandomObjectName("tf_acc_grant_group")
+
+	config := func(grantee string) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_grant" "grant" {
+  %[3]s
+  schema      = "pg_catalog"
+  object_type = "schema"
+  privileges  = ["usage"]
+
+  depends_on = [redshift_role.role, redshift_group.group]
+}
+`, roleName, groupName, grantee)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config(fmt.Sprintf("group = %q", roleName)),
+				ExpectError: regexp.MustCompile(`group "` + roleName + `" does not exist, but a role with that name does`),
+			},
+			{
+				Config:      config(fmt.Sprintf("role = %q", groupName)),
+				ExpectError: regexp.MustCompile(`role "` + groupName + `" does not exist, but a group with that name does`),
+			},
+			{
+				Config:      config(fmt.Sprintf("user = %q", roleName)),
+				ExpectError: regexp.MustCompile(`user "` + roleName + `" does not exist, but a role with that name does`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_Columns(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_grant_user")
+	schemaName := generateRandomObjectName("tf_acc_grant_schema")
+
+	config := func(privileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_table" "events" {
+  schema = redshift_schema.schema.name
+  name   = "events"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+
+  column {
+    name = "name"
+    type = "varchar(64)"
+  }
+
+  column {
+    name = "secret"
+    type = "varchar(64)"
+  }
+}
+
+resource "redshift_grant" "columns" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_table.events.name]
+  columns     = ["id", "name"]
+  privileges  = %[3]s
+}
+`, userName, schemaName, privileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["select"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.columns", "columns.#", "2"),
+					resource.TestCheckResourceAttr("redshift_grant.columns", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "privileges.*", "select"),
+					testAccCheckRedshiftGrantColumnPrivilege(userName, schemaName, "events", "name", "select", true),
+					testAccCheckRedshiftGrantColumnPrivilege(userName, schemaName, "events", "secret", "select", false),
+					testAccCheckRedshiftGrantTablePrivilege(userName, fmt.Sprintf("%s.events", schemaName), "select", false),
+				),
+			},
+			{
+				Config: config(`["select", "update"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.columns", "privileges.#", "2"),
+					testAccCheckRedshiftGrantColumnPrivilege(userName, schemaName, "events", "id", "update", true),
+				),
+			},
+			{
+				Config:      config(`["select", "insert"]`),
+				ExpectError: regexp.MustCompile("only \\[select update\\] can be granted on columns"),
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftGrantColumnPrivilege(userName, schemaName, tableName, columnName, privilege string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow(`
+			SELECT COUNT(*)
+			FROM svv_column_privileges
+			WHERE namespace_name = $1 AND relation_name = $2 AND column_name = $3
+				AND identity_type = 'user' AND identity_name = $4 AND LOWER(privilege_type) = $5`,
+			schemaName, tableName, columnName, userName, privilege).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error checking column privilege: %w", err)
+		}
+		if (count > 0) != expected {
+			return fmt.Errorf("expected %s on column %s.%s.%s granted to %s to be %t", privilege, schemaName, tableName, columnName, userName, expected)
+		}
+		return nil
+	}
+}
+
+func TestGrantStatementsWithColumns(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"tbl"},
+		grantColumnsAttr:    []interface{}{"id"},
+		grantPrivilegesAttr: []interface{}{"select", "update"},
+	})
+
+	expected := []string{
+		`REVOKE ALL PRIVILEGES ("id") ON TABLE "test"."tbl" FROM GROUP "analysts"`,
+		`GRANT select ("id") ON TABLE "test"."tbl" TO GROUP "analysts"`,
+		`GRANT update ("id") ON TABLE "test"."tbl" TO GROUP "analysts"`,
+	}
+	got := grantStatements(d, "db")
+	sort.Strings(got[1:])
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	if id, expectedID := generateGrantID(d), "gn:analysts_ot:table_test_tbl_cn:id"; id != expectedID {
+		t.Errorf("Expected ID %q but got %q", expectedID, id)
+	}
+}
+
+func TestValidateGrantColumns(t *testing.T) {
+	tests := map[string]struct {
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		"without columns": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+		},
+		"column privileges": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"tbl"},
+				grantColumnsAttr:    []interface{}{"id"},
+				grantPrivilegesAttr: []interface{}{"select", "update"},
+			},
+		},
+		"not a column privilege": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"tbl"},
+				grantColumnsAttr:    []interface{}{"id"},
+				grantPrivilegesAttr: []interface{}{"select", "insert"},
+			},
+			wantErr: true,
+		},
+		"all tables": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr: "table",
+				grantColumnsAttr:    []interface{}{"id"},
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			wantErr: true,
+		},
+		"not a table": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr: "function",
+				grantObjectsAttr:    []interface{}{"f_add(int, int)"},
+				grantColumnsAttr:    []interface{}{"id"},
+				grantPrivilegesAttr: []interface{}{"execute"},
+			},
+			wantErr: true,
+		},
+		"with grant option": {
+			raw: map[string]interface{}{
+				grantObjectTypeAttr:          "table",
+				grantObjectsAttr:             []interface{}{"tbl"},
+				grantColumnsAttr:             []interface{}{"id"},
+				grantPrivilegesAttr:          []interface{}{"select"},
+				grantGrantablePrivilegesAttr: []interface{}{"select"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.raw[grantUserAttr] = "bob"
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, tt.raw)
+			if err := validateGrantColumns(d); (err != nil) != tt.wantErr {
+				t.Errorf("validateGrantColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumnPrivilegesGrantedOnAll(t *testing.T) {
+	granted := map[columnPrivilege]bool{
+		{table: "events", column: "id", privilege: "select"}:   true,
+		{table: "events", column: "name", privilege: "select"}: true,
+		{table: "events", column: "id", privilege: "update"}:   true,
+		{table: "clicks", column: "id", privilege: "select"}:   true,
+	}
+
+	tests := map[string]struct {
+		tables   []string
+		columns  []string
+		expected []string
+	}{
+		"granted on all columns": {
+			tables:   []string{"events"},
+			columns:  []string{"id"},
+			expected: []string{"select", "update"},
+		},
+		"missing on a column": {
+			tables:   []string{"events"},
+			columns:  []string{"id", "name"},
+			expected: []string{"select"},
+		},
+		"missing on a table": {
+			tables:   []string{"events", "clicks"},
+			columns:  []string{"name"},
+			expected: []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := columnPrivilegesGrantedOnAll(granted, tt.tables, tt.columns); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_AllTablesInSchema(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_grant_group")
+	schemaName := generateRandomObjectName("tf_acc_grant_schema")
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_table" "events" {
+  schema = redshift_schema.schema.name
+  name   = "events"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+}
+
+resource "redshift_grant" "all_tables" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  privileges  = ["select"]
+
+  depends_on = [redshift_table.events]
+}
+`, groupName, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.all_tables", "objects.#", "0"),
+					resource.TestCheckResourceAttr("redshift_grant.all_tables", "privileges.#", "1"),
+				),
+			},
+			{
+				// A table created afterwards lacks the privilege, the plan must re-apply the grant
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("CREATE TABLE %s.clicks (id bigint)", pq.QuoteIdentifier(schemaName))); err != nil {
+						t.Fatalf("could not create table: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.all_tables", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.all_tables", "privileges.*", "select"),
+				),
+			},
+			{
+				// The out of band table has to be dropped before the schema
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("DROP TABLE %s.clicks", pq.QuoteIdentifier(schemaName))); err != nil {
+						t.Fatalf("could not drop table: %v", err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestGrantStatementsAllTablesInSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "table",
+		grantPrivilegesAttr: []interface{}{"select"},
+	})
+
+	expected := []string{
+		`REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA "test" FROM GROUP "analysts"`,
+		`GRANT select ON ALL TABLES IN SCHEMA "test" TO GROUP "analysts"`,
+	}
+	if got := grantStatements(d, "db"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestAccRedshiftGrant_FunctionOverload(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_grant_user")
+	schemaName := generateRandomObjectName("tf_acc_grant_schema")
 
 	config := fmt.Sprintf(`
 resource "redshift_user" "user" {
-  name      = %[1]q
+  name = %[1]q
 }
 
-resource "redshift_group" "y_schema" {
-  name  = "y_schema"
-  users = [redshift_user.user.name]
+resource "redshift_schema" "schema" {
+  name = %[2]q
 }
 
-resource "redshift_group" "y" {
-  name  = "y"
-  users = [redshift_user.user.name]
-}
+resource "redshift_function" "add_int" {
+  schema     = redshift_schema.schema.name
+  name       = "f_add"
+  returns    = "int"
+  volatility = "immutable"
+  body       = "SELECT $1 + $2"
 
-resource "redshift_schema" "x" {
-  name  = "x"
-  owner = redshift_user.user.name
-}
+  argument {
+    type = "int"
+  }
 
-resource "redshift_schema" "schema_x" {
-  name  = "schema_x"
-  owner = redshift_user.user.name
+  argument {
+    type = "int"
+  }
 }
 
-resource "redshift_grant" "grants1" {
-  group       = redshift_group.y_schema.name
-  schema      = redshift_schema.x.name
-  object_type = "schema"
-  privileges  = ["USAGE"]
+resource "redshift_function" "add_float" {
+  schema     = redshift_schema.schema.name
+  name       = "f_add"
+  returns    = "float"
+  volatility = "immutable"
+  body       = "SELECT $1 + $2"
+
+  argument {
+    type = "float"
+  }
+
+  argument {
+    type = "float"
+  }
 }
 
-resource "redshift_grant" "grants2" {
-  group       = redshift_group.y.name
-  schema      = redshift_schema.schema_x.name
-  object_type = "schema"
-  privileges  = ["USAGE"]
+resource "redshift_grant" "add_int" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "function"
+  objects     = ["f_add(int, int)"]
+  privileges  = ["execute"]
+
+  depends_on = [redshift_function.add_int, redshift_function.add_float]
 }
-`, userName)
+`, userName, schemaName)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -640,150 +2047,391 @@ resource "redshift_grant" "grants2" {
 		Steps: []resource.TestStep{
 			{
 				Config: config,
-				Check:  testAccRedshiftGrantRegressionIssue43CompareIds("redshift_grant.grants1", "redshift_grant.grants2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.add_int", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.add_int", "privileges.*", "execute"),
+					testAccCheckRedshiftGrantFunctionPrivilege(userName, fmt.Sprintf("%s.f_add(int, int)", schemaName), true),
+					testAccCheckRedshiftGrantFunctionPrivilege(userName, fmt.Sprintf("%s.f_add(float, float)", schemaName), false),
+				),
+			},
+			{
+				// Revoking the privilege on the granted overload must be detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					query := fmt.Sprintf("REVOKE EXECUTE ON FUNCTION %s.f_add(int, int) FROM %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(userName))
+					if _, err := db.Exec(query); err != nil {
+						t.Fatalf("could not revoke privilege: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
 			},
 		},
 	})
 }
 
-func testAccRedshiftGrantRegressionIssue43CompareIds(addr1 string, addr2 string) resource.TestCheckFunc {
+func testAccCheckRedshiftGrantFunctionPrivilege(userName, function string, expected bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rs1, ok := s.RootModule().Resources[addr1]
-		if !ok {
-			return fmt.Errorf("not found: %q", addr1)
-		}
-		rs2, ok := s.RootModule().Resources[addr2]
-		if !ok {
-			return fmt.Errorf("not found: %q", addr2)
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
 		}
 
-		if rs1.Primary.ID == rs2.Primary.ID {
-			return fmt.Errorf("resources %q and %q have the same ID: %q", addr1, addr2, rs1.Primary.ID)
+		var hasPrivilege bool
+		if err := db.QueryRow("SELECT has_function_privilege($1, $2, 'execute')", userName, function).Scan(&hasPrivilege); err != nil {
+			return fmt.Errorf("error checking privilege of %q on %q: %w", userName, function, err)
+		}
+		if hasPrivilege != expected {
+			return fmt.Errorf("expected execute privilege of %q on %q to be %t but was %t", userName, function, expected, hasPrivilege)
 		}
-
 		return nil
 	}
 }
 
-func testAccRedshiftGrantBasicCallablesConfigUserGroup(username, group, _ string) string {
-	return fmt.Sprintf(`
-resource "redshift_user" "user" {
-  name = %[1]q
+func TestGrantStatementsLanguage(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "developers",
+		grantObjectTypeAttr: "language",
+		grantObjectsAttr:    []interface{}{"plpythonu"},
+		grantPrivilegesAttr: []interface{}{"usage"},
+	})
+
+	expected := []string{
+		`REVOKE USAGE ON LANGUAGE "plpythonu" FROM GROUP "developers"`,
+		`GRANT usage ON LANGUAGE "plpythonu" TO GROUP "developers"`,
+	}
+	if got := grantStatements(d, "db"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	if err := validateGrantParameters(d); err != nil {
+		t.Errorf("Expected language grant to be valid but got %v", err)
+	}
+	if err := validateGrantPrivileges([]string{"usage", "create"}, "language"); err == nil {
+		t.Error("Expected only usage to be valid on languages")
+	}
 }
 
-resource "redshift_group" "group" {
-  name = %[2]q
+func TestGrantStatementsDatashare(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:       "john",
+		grantObjectTypeAttr: "datashare",
+		grantObjectsAttr:    []interface{}{"sales_share"},
+		grantPrivilegesAttr: []interface{}{"share"},
+	})
+
+	expected := []string{
+		`REVOKE ALTER,SHARE ON DATASHARE "sales_share" FROM  "john"`,
+		`GRANT share ON DATASHARE "sales_share" TO  "john"`,
+	}
+	if got := grantStatements(d, "db"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	if expected := "un:john_ot:datashare_sales_share"; generateGrantID(d) != expected {
+		t.Errorf("Expected ID %q but got %q", expected, generateGrantID(d))
+	}
+
+	if err := validateGrantParameters(d); err != nil {
+		t.Errorf("Expected datashare grant to be valid but got %v", err)
+	}
+	for _, privileges := range [][]string{{"usage"}, {"all"}, {"select"}} {
+		if err := validateGrantPrivileges(privileges, "datashare"); err == nil {
+			t.Errorf("Expected %v to be invalid on datashares", privileges)
+		}
+	}
+
+	withoutObjects := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:       "john",
+		grantObjectTypeAttr: "datashare",
+		grantPrivilegesAttr: []interface{}{"alter"},
+	})
+	if err := validateGrantParameters(withoutObjects); err == nil {
+		t.Error("Expected datashare grant without objects to be invalid")
+	}
+
+	withGrantOption := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:            "john",
+		grantObjectTypeAttr:      "datashare",
+		grantObjectsAttr:         []interface{}{"sales_share"},
+		grantPrivilegesAttr:      []interface{}{"alter"},
+		grantWithGrantOptionAttr: true,
+	})
+	if err := validateGrantParameters(withGrantOption); err == nil {
+		t.Error("Expected datashare grant with grant option to be invalid")
+	}
 }
-`, username, group)
+
+func TestDatasharePrivilegesGrantedOnAll(t *testing.T) {
+	granted := map[string]*schema.Set{
+		"sales":     schema.NewSet(schema.HashString, []interface{}{"alter", "share"}),
+		"marketing": schema.NewSet(schema.HashString, []interface{}{"share"}),
+	}
+
+	tests := map[string]struct {
+		shareNames []string
+		expected   []interface{}
+	}{
+		"single datashare": {
+			shareNames: []string{"sales"},
+			expected:   []interface{}{"alter", "share"},
+		},
+		"granted on all datashares": {
+			shareNames: []string{"sales", "marketing"},
+			expected:   []interface{}{"share"},
+		},
+		"missing on a datashare": {
+			shareNames: []string{"sales", "finance"},
+			expected:   []interface{}{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := schema.NewSet(schema.HashString, tt.expected)
+			if got := privilegesGrantedOnAll(granted, tt.shareNames); !got.Equal(expected) {
+				t.Errorf("Expected %v but got %v", expected.List(), got.List())
+			}
+		})
+	}
 }
 
-func testAccRedshiftGrantBasicCallablesConfigUserGroupWithGrants(username, group, schema string) string {
-	return fmt.Sprintf(`
+func TestGrantedTablePrivileges(t *testing.T) {
+	granted := map[string]*schema.Set{
+		"orders":    schema.NewSet(schema.HashString, []interface{}{"select", "insert"}),
+		"customers": schema.NewSet(schema.HashString, []interface{}{"select", "update"}),
+	}
+	tableNames := []string{"orders", "customers"}
+
+	tests := map[string]struct {
+		configured []interface{}
+		tableNames []string
+		expected   []interface{}
+	}{
+		"import": {
+			configured: []interface{}{},
+			tableNames: tableNames,
+			expected:   []interface{}{"select"},
+		},
+		"import in reverse order": {
+			configured: []interface{}{},
+			tableNames: []string{"customers", "orders"},
+			expected:   []interface{}{"select"},
+		},
+		"granted on all tables": {
+			configured: []interface{}{"select"},
+			tableNames: tableNames,
+			expected:   []interface{}{"select"},
+		},
+		"missing on a table": {
+			configured: []interface{}{"select", "insert"},
+			tableNames: tableNames,
+			expected:   []interface{}{"select"},
+		},
+		"no tables": {
+			configured: []interface{}{"select"},
+			expected:   []interface{}{"select"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := schema.NewSet(schema.HashString, tt.expected)
+			if got := grantedTablePrivileges(schema.NewSet(schema.HashString, tt.configured), granted, tt.tableNames); !got.Equal(expected) {
+				t.Errorf("Expected %v but got %v", expected.List(), got.List())
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_Datashare(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	userName := generateRandomObjectName("tf_acc_datashare_grant_user")
+	shareName := generateRandomObjectName("tf_acc_datashare_grant")
+
+	config := func(privileges string) string {
+		return fmt.Sprintf(`
 resource "redshift_user" "user" {
   name = %[1]q
 }
 
-resource "redshift_group" "group" {
+resource "redshift_datashare" "share" {
   name = %[2]q
 }
 
-resource "redshift_grant" "grant_fun" {
-	schema = %[3]q
-  group  = redshift_group.group.name
-  objects = ["test_call(float,float)"]
+resource "redshift_grant" "datashare" {
+  user        = redshift_user.user.name
+  object_type = "datashare"
+  objects     = [redshift_datashare.share.name]
+  privileges  = %[3]s
+}
+`, userName, shareName, privileges)
+	}
 
-  object_type = "function"
-  privileges = ["execute"]
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`["usage"]`),
+				ExpectError: regexp.MustCompile("redshift_datashare_privilege"),
+			},
+			{
+				Config: config(`["alter", "share"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.datashare", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.datashare", "privileges.*", "alter"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.datashare", "privileges.*", "share"),
+				),
+			},
+			{
+				Config: config(`["share"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.datashare", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.datashare", "privileges.*", "share"),
+				),
+			},
+			{
+				Config:   config(`["share"]`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "redshift_grant.datashare",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("user:%s:datashare:%s", userName, shareName),
+				ImportStateVerify: true,
+			},
+		},
+	})
 }
 
-resource "redshift_grant" "grant_proc" {
-	schema = %[3]q
-  group  = redshift_group.group.name
-  objects = ["test_call()"]
+func TestAccRedshiftGrant_MixedCaseNames(t *testing.T) {
+	userName := generateRandomObjectName("TF_Acc_User")
+	groupName := generateRandomObjectName("TF_Acc_Group")
+	schemaName := generateRandomObjectName("TF_Acc_Schema")
 
-  object_type = "procedure"
-  privileges = ["execute"]
+	// The grants use the names as written instead of the normalized attributes of the principals
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
 }
 
-resource "redshift_grant" "grant_user_fun" {
-	schema = %[3]q
-  user = redshift_user.user.name
-  objects = ["test_call(float,float)", "test_call(int,int)"]
+resource "redshift_group" "group" {
+  name = %[2]q
+}
 
-  object_type = "function"
-  privileges = ["execute"]
+resource "redshift_schema" "schema" {
+  name = %[3]q
 }
 
-resource "redshift_grant" "grant_user_proc" {
-	schema = %[3]q
-  user = redshift_user.user.name
-  objects = ["test_call()"]
+resource "redshift_grant" "user" {
+  user        = %[1]q
+  schema      = %[3]q
+  object_type = "schema"
+  privileges  = ["usage"]
 
-  object_type = "procedure"
-  privileges = ["execute"]
+  depends_on = [redshift_user.user, redshift_schema.schema]
 }
-`, username, group, schema)
-}
-
-func testAccRedshiftGrantBasicCallablesCreateSchemaAndCallables(_ *testing.T, db *DBConnection, schema string) error {
-	_, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(schema)))
-	if err != nil {
-		return fmt.Errorf("couldn't create schema: %s", err)
-	}
 
-	function := fmt.Sprintf(`
-	create function %s.test_call (a float, b float)
-		returns float
-	stable
-	as $$
-		if a > b:
-			return a
-		return b
-	$$ language plpythonu;
-`, schema)
+resource "redshift_grant" "group" {
+  group       = %[2]q
+  schema      = %[3]q
+  object_type = "table"
+  privileges  = ["select"]
 
-	_, err = db.Exec(function)
-	if err != nil {
-		return fmt.Errorf("couldn't create function: %s", err)
-	}
+  depends_on = [redshift_group.group, redshift_schema.schema]
+}
+`, userName, groupName, schemaName)
 
-	function2 := fmt.Sprintf(`
-	create function %s.test_call (a int, b int)
-		returns int
-	stable
-	as $$
-		if a > b:
-			return a
-		return b
-	$$ language plpythonu;
-`, schema)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.user", "id", fmt.Sprintf("un:%s_ot:schema_%s", strings.ToLower(userName), strings.ToLower(schemaName))),
+					resource.TestCheckResourceAttr("redshift_grant.user", "user", strings.ToLower(userName)),
+					resource.TestCheckResourceAttr("redshift_grant.user", "schema", strings.ToLower(schemaName)),
+					resource.TestCheckResourceAttr("redshift_grant.user", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("redshift_grant.group", "group", strings.ToLower(groupName)),
+					resource.TestCheckResourceAttr("redshift_grant.group", "privileges.#", "1"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
 
-	_, err = db.Exec(function2)
-	if err != nil {
-		return fmt.Errorf("couldn't create function2: %s", err)
+func TestAccRedshiftGrant_InvalidPrivilegesDuringPlan(t *testing.T) {
+	config := func(objectType, privileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_grant" "grant" {
+  group       = "public"
+  schema      = "public"
+  object_type = %[1]q
+  privileges  = %[2]s
+}
+`, objectType, privileges)
 	}
 
-	procedure := fmt.Sprintf(`
-	CREATE PROCEDURE %s.test_call() AS $$
-		BEGIN
-	RAISE NOTICE 'Hello, world!';
-		END
-	$$ LANGUAGE plpgsql;
-	`, schema)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("table", `["selct"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected privileges.\d+ to be one of`),
+			},
+			{
+				Config:      config("table", `["select", "execute"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid privileges list \[.*\] for object of type "table"`),
+			},
+			{
+				Config:      config("function", `["select"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid privileges list \[select\] for object of type "function"`),
+			},
+		},
+	})
+}
 
-	_, err = db.Exec(procedure)
-	if err != nil {
-		return fmt.Errorf("couldn't create procedure: %s", err)
+func TestIsGrantedBy(t *testing.T) {
+	tests := map[string]struct {
+		grantors map[string]bool
+		expected bool
+	}{
+		"granted by the user": {
+			grantors: map[string]bool{"admin": true},
+			expected: true,
+		},
+		"granted by the user and another grantor": {
+			grantors: map[string]bool{"admin": true, "alice": true},
+			expected: true,
+		},
+		"granted by other grantors": {
+			grantors: map[string]bool{"alice": true, "bob": true},
+			expected: false,
+		},
 	}
 
-	return nil
-}
-
-func testAccRedshiftGrantBasicCallablesDropResources(_ *testing.T, db *DBConnection, schema string) error {
-	query := fmt.Sprintf("DROP SCHEMA %s CASCADE", pq.QuoteIdentifier(schema))
-	_, err := db.Exec(query)
-	if err != nil {
-		return fmt.Errorf("couldn't drop test schema: %s", err)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isGrantedBy(tt.grantors, "admin"); got != tt.expected {
+				t.Errorf("Expected %t but got %t", tt.expected, got)
+			}
+		})
 	}
-	return nil
 }
@@ -0,0 +1,418 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	grantsGrantAttr = "grant"
+)
+
+// grantsSpecAttrs are the attributes of a grant of redshift_grants, a subset of the attributes of redshift_grant.
+var grantsSpecAttrs = []string{
+	grantUserAttr,
+	grantGroupAttr,
+	grantRoleAttr,
+	grantSchemaAttr,
+	grantObjectTypeAttr,
+	grantObjectsAttr,
+	grantPrivilegesAttr,
+	grantWithGrantOptionAttr,
+}
+
+func redshiftGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages many grants in a single resource. The grants are applied in a single transaction and the statements of
+grants which only differ by their grantee are merged, e.g. ` + "`GRANT select ON TABLE a TO alice, GROUP analysts`" + `,
+which speeds up large role based access setups considerably compared to one ` + "`redshift_grant`" + ` per grantee.
+
+Every grant behaves like a ` + "`redshift_grant`" + `: it revokes all privileges of the grantee on its objects before
+granting the configured privileges, so an object of a grantee can only be part of a single grant. Only the grants
+which changed are applied again on update. Column privileges, grantable privileges and the re-application on grantor
+changes require ` + "`redshift_grant`" + `. Privileges on databases are granted on the database the resource is managed
+in. Do not manage the same grantee and objects with ` + "`redshift_grant`" + `.
+
+The resource can't be imported: it only knows which grants it manages from its configuration, the privileges in the
+database can't be attributed to a single ` + "`redshift_grants`" + `. Import the grants as ` + "`redshift_grant`" + ` instead.
+`,
+		CreateContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftGrantsCreate),
+		),
+		ReadContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftGrantsRead),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftGrantsUpdate),
+		),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftGrantsDelete),
+		),
+		CustomizeDiff: validateGrantsPrivileges,
+
+		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("grants"),
+			grantsGrantAttr: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The grants to manage.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						grantUserAttr: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The name of the user to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
+							ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("^(?i)public$"), "User name cannot be 'public'. To use GRANT ... TO PUBLIC set the group name to 'public' instead."),
+						},
+						grantGroupAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the group to grant privileges on. Exactly one of `user`, `group`, or `role` must be set. Setting the group name to `public` results in a `GRANT ... TO PUBLIC` statement.",
+						},
+						grantRoleAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the role to grant privileges on. Exactly one of `user`, `group`, or `role` must be set.",
+						},
+						grantSchemaAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The database schema to grant privileges on.",
+						},
+						grantObjectTypeAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(grantAllowedObjectTypes, false),
+							Description:  "The Redshift object type to grant privileges on (one of: " + strings.Join(grantAllowedObjectTypes, ", ") + ").",
+						},
+						grantObjectsAttr: {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								StateFunc: func(val interface{}) string {
+									return strings.ToLower(val.(string))
+								},
+							},
+							Set:         schema.HashString,
+							Description: "The objects upon which to grant the privileges, as for `redshift_grant`. An empty list means all objects of the type in the schema.",
+						},
+						grantPrivilegesAttr: {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validatePrivilegeName),
+								StateFunc: func(val interface{}) string {
+									return strings.ToLower(val.(string))
+								},
+							},
+							Set:         schema.HashString,
+							Description: "The privileges to grant, as for `redshift_grant`. An empty list revokes all privileges of the grantee on the objects.",
+						},
+						grantWithGrantOptionAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the grantee can grant the privileges to other users. Can only be used when granting to a `user`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateGrantsPrivileges checks during the plan that the privileges of every grant are supported by its object type
+// and that no object of a grantee is targeted by several grants.
+func validateGrantsPrivileges(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(grantsGrantAttr) {
+		return nil
+	}
+	specs := d.Get(grantsGrantAttr).(*schema.Set).List()
+	for _, raw := range specs {
+		spec := raw.(map[string]interface{})
+		if err := validateGrantPrivileges(setToStringList(spec[grantPrivilegesAttr].(*schema.Set)), spec[grantObjectTypeAttr].(string)); err != nil {
+			return err
+		}
+	}
+	return validateGrantsTargets(specs)
+}
+
+// validateGrantsTargets checks that every object of a grantee is targeted by a single grant. Every grant revokes all
+// privileges of its grantee on its objects before granting its own, so the privileges of several grants on the same
+// object would depend on the order of the statements and be read back merged for all of them.
+func validateGrantsTargets(specs []interface{}) error {
+	targets := map[string]bool{}
+	for _, raw := range specs {
+		spec := raw.(map[string]interface{})
+		objects := setToStringList(spec[grantObjectsAttr].(*schema.Set))
+		if len(objects) == 0 {
+			// All objects of the type in the schema
+			objects = []string{""}
+		}
+		for _, object := range objects {
+			target := strings.ToLower(strings.Join([]string{
+				spec[grantUserAttr].(string),
+				spec[grantGroupAttr].(string),
+				spec[grantRoleAttr].(string),
+				spec[grantObjectTypeAttr].(string),
+				spec[grantSchemaAttr].(string),
+				object,
+			}, "\x00"))
+			if targets[target] {
+				return fmt.Errorf("several `%s` blocks grant privileges on the same %s to the same grantee, merge their privileges into a single `%s` block", grantsGrantAttr, spec[grantObjectTypeAttr], grantsGrantAttr)
+			}
+			targets[target] = true
+		}
+	}
+	return nil
+}
+
+// grantsSpecData returns the data of a grant of redshift_grants as the data of a redshift_grant,
+// to validate, build and read it with the functions of redshift_grant.
+func grantsSpecData(spec map[string]interface{}) *schema.ResourceData {
+	d := redshiftGrant().Data(nil)
+	for _, attr := range grantsSpecAttrs {
+		d.Set(attr, spec[attr])
+	}
+	return d
+}
+
+func grantsSpecsData(specs *schema.Set) []*schema.ResourceData {
+	data := make([]*schema.ResourceData, 0, specs.Len())
+	for _, spec := range specs.List() {
+		data = append(data, grantsSpecData(spec.(map[string]interface{})))
+	}
+	return data
+}
+
+// validateGrantsSpec checks a grant like redshift_grant does on creation.
+func validateGrantsSpec(d *schema.ResourceData) error {
+	var grantees int
+	for _, attr := range []string{grantUserAttr, grantGroupAttr, grantRoleAttr} {
+		if _, ok := d.GetOk(attr); ok {
+			grantees++
+		}
+	}
+	if grantees != 1 {
+		return fmt.Errorf("exactly one of `%s`, `%s` or `%s` must be set for every `%s`", grantUserAttr, grantGroupAttr, grantRoleAttr, grantsGrantAttr)
+	}
+	return validateGrantParameters(d)
+}
+
+// checkGrantsGranteeTypes verifies that the grantees are of the declared types, every grantee only once.
+func checkGrantsGranteeTypes(db *DBConnection, specs []*schema.ResourceData) error {
+	checked := map[string]bool{}
+	for _, d := range specs {
+		toWhomIndicator, entityName := getGrantGrantee(d)
+		grantee := toWhomIndicator + " " + entityName
+		if checked[grantee] {
+			continue
+		}
+		if err := checkGrantGranteeType(db, d); err != nil {
+			return err
+		}
+		checked[grantee] = true
+	}
+	return nil
+}
+
+// grantsStatement is a GRANT or REVOKE statement for a single grantee.
+type grantsStatement struct {
+	// action is the statement up to the grantee, e.g. `GRANT select ON TABLE "s"."t" TO`
+	action  string
+	grantee string
+	// suffix follows the grantee, statements with a suffix are never merged
+	suffix string
+}
+
+// grantsRevokeStatement returns the statement revoking all privileges of the grantee on the objects of the grant.
+func grantsRevokeStatement(d *schema.ResourceData, databaseName string) grantsStatement {
+	privileges := "ALL PRIVILEGES"
+	switch d.Get(grantObjectTypeAttr).(string) {
+	case "language":
+		privileges = "USAGE"
+	case "datashare":
+		privileges = strings.ToUpper(strings.Join(datasharePrivileges, ","))
+	}
+	return grantsStatement{
+		action:  fmt.Sprintf("REVOKE %s ON %s FROM", privileges, grantOnClause(d, databaseName)),
+		grantee: grantsStatementGrantee(d),
+	}
+}
+
+// grantsGrantStatement returns the statement granting the configured privileges, false if there are none.
+func grantsGrantStatement(d *schema.ResourceData, databaseName string) (grantsStatement, bool) {
+	privileges := setToStringList(d.Get(grantPrivilegesAttr).(*schema.Set))
+	if len(privileges) == 0 {
+		return grantsStatement{}, false
+	}
+	sort.Strings(privileges)
+
+	statement := grantsStatement{
+		action:  fmt.Sprintf("GRANT %s ON %s TO", strings.Join(privileges, ","), grantOnClause(d, databaseName)),
+		grantee: grantsStatementGrantee(d),
+	}
+	if d.Get(grantWithGrantOptionAttr).(bool) {
+		statement.suffix = "WITH GRANT OPTION"
+	}
+	return statement, true
+}
+
+func grantsStatementGrantee(d *schema.ResourceData) string {
+	toWhomIndicator, entityName := getGrantGrantee(d)
+	return strings.TrimSpace(toWhomIndicator + " " + entityName)
+}
+
+// grantsStatements returns the statements applying the grants: the revocation of the privileges of
+// the removed and the added grants followed by the grant of the privileges of the added grants.
+func grantsStatements(removed, added []*schema.ResourceData, databaseName string) []string {
+	var revokes, grants []grantsStatement
+	for _, d := range removed {
+		revokes = append(revokes, grantsRevokeStatement(d, databaseName))
+	}
+	for _, d := range added {
+		revokes = append(revokes, grantsRevokeStatement(d, databaseName))
+		if statement, ok := grantsGrantStatement(d, databaseName); ok {
+			grants = append(grants, statement)
+		}
+	}
+	return append(mergeGrantsStatements(revokes), mergeGrantsStatements(grants)...)
+}
+
+// mergeGrantsStatements merges the statements which only differ by their grantee into a single statement
+// with a list of grantees, in the order of their first occurrence.
+func mergeGrantsStatements(statements []grantsStatement) []string {
+	var actions []string
+	grantees := map[string][]string{}
+	for _, statement := range statements {
+		if statement.suffix != "" {
+			actions = append(actions, fmt.Sprintf("%s %s %s", statement.action, statement.grantee, statement.suffix))
+			continue
+		}
+		if _, ok := grantees[statement.action]; !ok {
+			actions = append(actions, statement.action)
+		}
+		grantees[statement.action] = appendIfMissing(grantees[statement.action], statement.grantee)
+	}
+
+	queries := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if names, ok := grantees[action]; ok {
+			action = fmt.Sprintf("%s %s", action, strings.Join(names, ", "))
+		}
+		queries = append(queries, action)
+	}
+	return queries
+}
+
+func appendIfMissing(list []string, value string) []string {
+	for _, elem := range list {
+		if elem == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+func execGrantsStatements(db *DBConnection, queries []string) error {
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("error executing %q: %w", query, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+func resourceRedshiftGrantsCreate(db *DBConnection, d *schema.ResourceData) error {
+	specs := grantsSpecsData(d.Get(grantsGrantAttr).(*schema.Set))
+	for _, spec := range specs {
+		if err := validateGrantsSpec(spec); err != nil {
+			return err
+		}
+	}
+	if err := checkGrantsGranteeTypes(db, specs); err != nil {
+		return err
+	}
+
+	if err := execGrantsStatements(db, grantsStatements(nil, specs, db.client.config.Database)); err != nil {
+		return err
+	}
+
+	d.SetId(id.UniqueId())
+
+	return resourceRedshiftGrantsRead(db, d)
+}
+
+func resourceRedshiftGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	specs := d.Get(grantsGrantAttr).(*schema.Set).List()
+	grants := make([]interface{}, 0, len(specs))
+	for _, raw := range specs {
+		spec := raw.(map[string]interface{})
+		specData := grantsSpecData(spec)
+		if err := resourceRedshiftGrantReadImpl(db, specData); err != nil {
+			return err
+		}
+
+		grant := make(map[string]interface{}, len(spec))
+		for attr, value := range spec {
+			grant[attr] = value
+		}
+		grant[grantPrivilegesAttr] = specData.Get(grantPrivilegesAttr)
+		grant[grantWithGrantOptionAttr] = specData.Get(grantWithGrantOptionAttr)
+		grants = append(grants, grant)
+	}
+
+	d.Set(grantsGrantAttr, grants)
+
+	return nil
+}
+
+func resourceRedshiftGrantsUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(grantsGrantAttr) {
+		return resourceRedshiftGrantsRead(db, d)
+	}
+
+	// Grants which are kept as they are don't need to be applied again
+	oldRaw, newRaw := d.GetChange(grantsGrantAttr)
+	removed := grantsSpecsData(oldRaw.(*schema.Set).Difference(newRaw.(*schema.Set)))
+	added := grantsSpecsData(newRaw.(*schema.Set).Difference(oldRaw.(*schema.Set)))
+	for _, spec := range added {
+		if err := validateGrantsSpec(spec); err != nil {
+			return err
+		}
+	}
+	if err := checkGrantsGranteeTypes(db, added); err != nil {
+		return err
+	}
+
+	if err := execGrantsStatements(db, grantsStatements(removed, added, db.client.config.Database)); err != nil {
+		return err
+	}
+
+	return resourceRedshiftGrantsRead(db, d)
+}
+
+func resourceRedshiftGrantsDelete(db *DBConnection, d *schema.ResourceData) error {
+	specs := grantsSpecsData(d.Get(grantsGrantAttr).(*schema.Set))
+	return execGrantsStatements(db, grantsStatements(specs, nil, db.client.config.Database))
+}
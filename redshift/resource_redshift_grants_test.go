@@ -0,0 +1,310 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testGrantsSpec(values map[string]interface{}) map[string]interface{} {
+	spec := map[string]interface{}{
+		grantUserAttr:            "",
+		grantGroupAttr:           "",
+		grantRoleAttr:            "",
+		grantSchemaAttr:          "",
+		grantObjectsAttr:         schema.NewSet(schema.HashString, nil),
+		grantPrivilegesAttr:      schema.NewSet(schema.HashString, nil),
+		grantWithGrantOptionAttr: false,
+	}
+	for attr, value := range values {
+		if list, ok := value.([]interface{}); ok {
+			value = schema.NewSet(schema.HashString, list)
+		}
+		spec[attr] = value
+	}
+	return spec
+}
+
+func TestGrantsStatements(t *testing.T) {
+	tableForUser := grantsSpecData(testGrantsSpec(map[string]interface{}{
+		grantUserAttr:       "alice",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"tbl"},
+		grantPrivilegesAttr: []interface{}{"select", "insert"},
+	}))
+	tableForGroup := grantsSpecData(testGrantsSpec(map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"tbl"},
+		grantPrivilegesAttr: []interface{}{"insert", "select"},
+	}))
+	schemaForRole := grantsSpecData(testGrantsSpec(map[string]interface{}{
+		grantRoleAttr:       "readers",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "schema",
+		grantPrivilegesAttr: []interface{}{"usage"},
+	}))
+	schemaForUserWithGrantOption := grantsSpecData(testGrantsSpec(map[string]interface{}{
+		grantUserAttr:            "bob",
+		grantSchemaAttr:          "test",
+		grantObjectTypeAttr:      "schema",
+		grantPrivilegesAttr:      []interface{}{"usage"},
+		grantWithGrantOptionAttr: true,
+	}))
+	noPrivilegesForPublic := grantsSpecData(testGrantsSpec(map[string]interface{}{
+		grantGroupAttr:      "public",
+		grantObjectTypeAttr: "language",
+		grantObjectsAttr:    []interface{}{"plpythonu"},
+	}))
+
+	cases := map[string]struct {
+		removed  []*schema.ResourceData
+		added    []*schema.ResourceData
+		expected []string
+	}{
+		"create": {
+			added: []*schema.ResourceData{tableForUser, tableForGroup, schemaForRole, schemaForUserWithGrantOption, noPrivilegesForPublic},
+			expected: []string{
+				`REVOKE ALL PRIVILEGES ON TABLE "test"."tbl" FROM "alice", GROUP "analysts"`,
+				`REVOKE ALL PRIVILEGES ON SCHEMA "test" FROM ROLE "readers", "bob"`,
+				`REVOKE USAGE ON LANGUAGE "plpythonu" FROM PUBLIC`,
+				`GRANT insert,select ON TABLE "test"."tbl" TO "alice", GROUP "analysts"`,
+				`GRANT usage ON SCHEMA "test" TO ROLE "readers"`,
+				`GRANT usage ON SCHEMA "test" TO "bob" WITH GRANT OPTION`,
+			},
+		},
+		"update": {
+			removed: []*schema.ResourceData{tableForGroup},
+			added:   []*schema.ResourceData{schemaForRole},
+			expected: []string{
+				`REVOKE ALL PRIVILEGES ON TABLE "test"."tbl" FROM GROUP "analysts"`,
+				`REVOKE ALL PRIVILEGES ON SCHEMA "test" FROM ROLE "readers"`,
+				`GRANT usage ON SCHEMA "test" TO ROLE "readers"`,
+			},
+		},
+		"delete": {
+			removed: []*schema.ResourceData{tableForUser, tableForGroup},
+			expected: []string{
+				`REVOKE ALL PRIVILEGES ON TABLE "test"."tbl" FROM "alice", GROUP "analysts"`,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := grantsStatements(c.removed, c.added, "db"); !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("Expected %q but got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateGrantsSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec        map[string]interface{}
+		expectError bool
+	}{
+		"valid": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+		},
+		"no grantee": {
+			spec: map[string]interface{}{
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			expectError: true,
+		},
+		"several grantees": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			expectError: true,
+		},
+		"grant option for a group": {
+			spec: map[string]interface{}{
+				grantGroupAttr:           "analysts",
+				grantSchemaAttr:          "test",
+				grantObjectTypeAttr:      "table",
+				grantPrivilegesAttr:      []interface{}{"select"},
+				grantWithGrantOptionAttr: true,
+			},
+			expectError: true,
+		},
+		"table without schema": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			expectError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateGrantsSpec(grantsSpecData(testGrantsSpec(c.spec)))
+			if c.expectError != (err != nil) {
+				t.Errorf("Expected error %t but got %v", c.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateGrantsTargets(t *testing.T) {
+	selectOnTable := testGrantsSpec(map[string]interface{}{
+		grantUserAttr:       "alice",
+		grantSchemaAttr:     "test",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"tbl", "other"},
+		grantPrivilegesAttr: []interface{}{"select"},
+	})
+
+	cases := map[string]struct {
+		spec        map[string]interface{}
+		expectError bool
+	}{
+		"same objects with other privileges": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"other", "tbl"},
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+			expectError: true,
+		},
+		"overlapping objects": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "Alice",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"TBL"},
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+			expectError: true,
+		},
+		"other grantee": {
+			spec: map[string]interface{}{
+				grantGroupAttr:      "alice",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"tbl"},
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+		},
+		"other schema": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantSchemaAttr:     "staging",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"tbl"},
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+		},
+		"other objects": {
+			spec: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantSchemaAttr:     "test",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"third"},
+				grantPrivilegesAttr: []interface{}{"insert"},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateGrantsTargets([]interface{}{selectOnTable, testGrantsSpec(c.spec)})
+			if c.expectError != (err != nil) {
+				t.Errorf("Expected error %t but got %v", c.expectError, err)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrants_Lifecycle(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_grants_user")
+	groupName := generateRandomObjectName("tf_acc_grants_group")
+	schemaName := generateRandomObjectName("tf_acc_grants_schema")
+
+	config := `
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[3]q
+}
+
+resource "redshift_grants" "grants" {
+  grant {
+    user        = redshift_user.user.name
+    schema      = redshift_schema.schema.name
+    object_type = "schema"
+    privileges  = ["usage"]
+  }
+
+  grant {
+    group       = redshift_group.group.name
+    schema      = redshift_schema.schema.name
+    object_type = "schema"
+    privileges  = ["usage"]
+  }
+
+  grant {
+    user        = redshift_user.user.name
+    schema      = "pg_catalog"
+    object_type = "table"
+    objects     = ["pg_user_info"]
+    privileges  = [%[4]s]
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, userName, groupName, schemaName, `"select"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grants.grants", "grant.#", "3"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "select", true),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "insert", false),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, userName, groupName, schemaName, `"select", "insert"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grants.grants", "grant.#", "3"),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "select", true),
+					testAccCheckRedshiftGrantTablePrivilege(userName, "pg_catalog.pg_user_info", "insert", true),
+				),
+			},
+			{
+				Config:   fmt.Sprintf(config, userName, groupName, schemaName, `"select", "insert"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
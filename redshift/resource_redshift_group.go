@@ -1,10 +1,12 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,43 +19,62 @@ const (
 	groupUsersAttr = "users"
 )
 
+// groupIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var groupIdentifierAttrs = []string{groupNameAttr}
+
 func redshiftGroup() *schema.Resource {
 	return &schema.Resource{
 		Description: `
 Groups are collections of users who are all granted whatever privileges are associated with the group. You can use groups to assign privileges by role. For example, you can create different groups for sales, administration, and support and give the users in each group the appropriate access to the data they require for their work. You can grant or revoke privileges at the group level, and those changes will apply to all members of the group, except for superusers.
 `,
-		CreateContext: ResourceFunc(resourceRedshiftGroupCreate),
-		ReadContext:   ResourceFunc(resourceRedshiftGroupRead),
-		UpdateContext: ResourceFunc(resourceRedshiftGroupUpdate),
+		CreateContext: ResourceFunc(ResourceNormalizeIdentifiers(groupIdentifierAttrs, resourceRedshiftGroupCreate)),
+		ReadContext:   ResourceFunc(ResourceNormalizeIdentifiers(groupIdentifierAttrs, resourceRedshiftGroupRead)),
+		UpdateContext: ResourceFunc(ResourceNormalizeIdentifiers(groupIdentifierAttrs, resourceRedshiftGroupUpdate)),
 		DeleteContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftGroupDelete),
+			ResourceNormalizeIdentifiers(groupIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftGroupDelete)),
 		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRedshiftGroupImport,
 		},
 
 		Schema: map[string]*schema.Schema{
 			groupNameAttr: {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Name of the user group. Group names beginning with two underscores are reserved for Amazon Redshift internal use.",
-				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("^__.*"), "Group names beginning with two underscores are reserved for Amazon Redshift internal use"),
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the user group. Group names beginning with two underscores are reserved for Amazon Redshift internal use.",
+				ValidateFunc:     validation.StringDoesNotMatch(regexp.MustCompile("^__.*"), "Group names beginning with two underscores are reserved for Amazon Redshift internal use"),
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			groupUsersAttr: {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "List of the user names to add to the group",
 			},
 		},
 	}
 }
 
+// resourceRedshiftGroupImport imports a group by its grosysid or by its name.
+func resourceRedshiftGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	db, err := meta.(*Client).Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	var groSysID string
+	if err := db.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", normalizeIdentifier(db, d.Id())).Scan(&groSysID); err != nil {
+		return nil, fmt.Errorf("could not get redshift group id for %q: %w", d.Id(), err)
+	}
+	d.SetId(groSysID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceRedshiftGroupRead(db *DBConnection, d *schema.ResourceData) error {
 	return resourceRedshiftGroupReadImpl(db, d)
 }
@@ -89,7 +110,7 @@ func resourceRedshiftGroupReadImpl(db *DBConnection, d *schema.ResourceData) err
 	}
 
 	d.Set(groupNameAttr, groupName)
-	d.Set(groupUsersAttr, groupUsers)
+	d.Set(groupUsersAttr, configuredUserNames(groupUsers, parseUserNames(d.Get(groupUsersAttr))))
 
 	return nil
 }
@@ -97,7 +118,7 @@ func resourceRedshiftGroupReadImpl(db *DBConnection, d *schema.ResourceData) err
 func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error {
 	groupName := d.Get(groupNameAttr).(string)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -120,7 +141,7 @@ func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error
 	}
 
 	var groSysID string
-	if err := tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", strings.ToLower(groupName)).Scan(&groSysID); err != nil {
+	if err := tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", normalizeIdentifier(db, groupName)).Scan(&groSysID); err != nil {
 		return fmt.Errorf("could not get redshift group id for %q: %w", groupName, err)
 	}
 
@@ -130,13 +151,17 @@ func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	if err := waitUntilVisible(db, fmt.Sprintf("group %q", groupName), "SELECT EXISTS(SELECT 1 FROM pg_group WHERE grosysid = $1)", d.Id()); err != nil {
+		return err
+	}
+
 	return resourceRedshiftGroupReadImpl(db, d)
 }
 
 func resourceRedshiftGroupDelete(db *DBConnection, d *schema.ResourceData) error {
 	groupName := d.Get(groupNameAttr).(string)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -170,7 +195,7 @@ func resourceRedshiftGroupDelete(db *DBConnection, d *schema.ResourceData) error
 }
 
 func resourceRedshiftGroupUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
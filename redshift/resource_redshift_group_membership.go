@@ -1,6 +1,7 @@
 package redshift
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/lib/pq"
 )
 
+const groupMembershipExclusiveAttr = "exclusive"
+
 func redshiftGroupMembership() *schema.Resource {
 	return &schema.Resource{
 		Description: fmt.Sprintf(`
@@ -19,7 +22,7 @@ Manages Redshift group memberships. Allows either to exclusively manage group me
 		UpdateContext: ResourceFunc(resourceRedshiftGroupMembershipUpdate),
 		DeleteContext: ResourceFunc(resourceRedshiftGroupMembershipDelete),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRedshiftGroupMembershipImport,
 		},
 		Schema: map[string]*schema.Schema{
 			groupNameAttr: {
@@ -32,7 +35,13 @@ Manages Redshift group memberships. Allows either to exclusively manage group me
 				Type:        schema.TypeSet,
 				Required:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "List of the user names to add to the group. Note: this resource does not check whether the specified users exist.",
+				Description: "List of the user names to add to the group. Note: this resource does not check whether the specified users exist. Users removed from the group outside of Terraform are added again.",
+			},
+			groupMembershipExclusiveAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the listed users are the only members of the group. If `true`, all other members are removed from the group. By default other members, e.g. added by an external sync, are left untouched and only the listed users are tracked.",
 			},
 		},
 	}
@@ -50,6 +59,12 @@ func resourceRedshiftGroupMembershipCreate(db *DBConnection, d *schema.ResourceD
 		return err
 	}
 
+	if d.Get(groupMembershipExclusiveAttr).(bool) {
+		if err := dropOtherUsersFromGroup(db, groupName, userNames); err != nil {
+			return err
+		}
+	}
+
 	return resourceRedshiftGroupMembershipRead(db, d)
 }
 
@@ -71,27 +86,93 @@ func resourceRedshiftGroupMembershipRead(db *DBConnection, d *schema.ResourceDat
 	groupName := d.Get(groupNameAttr).(string)
 	userNames := parseUserNames(d.Get(groupUsersAttr))
 
-	userNamesParam := buildUserStringArray(userNames, true)
+	// Unless the membership is exclusive, only the configured users are tracked
+	trackedUserNames := userNames
+	if d.Get(groupMembershipExclusiveAttr).(bool) {
+		trackedUserNames = nil
+	}
+	members, err := readGroupMembers(db, groupName, trackedUserNames)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		d.SetId("")
+		return nil
+	}
 
-	query := fmt.Sprintf(
-		`SELECT 1 FROM pg_group pgg JOIN pg_user pgu ON pgu.usesysid = ANY(pgg.grolist) WHERE pgg.groname = %s AND pgu.usename IN (%s);`,
-		pq.QuoteLiteral(groupName), userNamesParam,
-	)
+	d.Set(groupUsersAttr, configuredUserNames(members, userNames))
+	d.SetId(generateGroupMembershipId(groupName, userNames))
+	return nil
+}
+
+// readGroupMembers returns the given users which are members of the group, or all members of the group
+// when no users are given.
+func readGroupMembers(db *DBConnection, groupName string, userNames []string) ([]string, error) {
+	query := `SELECT pgu.usename FROM pg_group pgg JOIN pg_user pgu ON pgu.usesysid = ANY(pgg.grolist) WHERE pgg.groname = $1`
+	if len(userNames) > 0 {
+		query = fmt.Sprintf("%s AND pgu.usename IN (%s)", query, buildUserStringArray(userNames, true))
+	}
 
-	rows, err := db.Query(query)
+	rows, err := db.Query(query, groupName)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("could not read group membership for group %q: %w", groupName, err)
 	}
 	defer rows.Close()
-	if rows.Next() {
-		if err = rows.Err(); err != nil {
-			return fmt.Errorf("could not read group membership for group %q: %w", groupName, err)
+
+	members := make([]string, 0)
+	for rows.Next() {
+		var userName string
+		if err := rows.Scan(&userName); err != nil {
+			return nil, fmt.Errorf("could not read group membership for group %q: %w", groupName, err)
 		}
-		d.SetId(generateGroupMembershipId(groupName, userNames))
-	} else {
-		d.SetId("")
+		members = append(members, userName)
 	}
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read group membership for group %q: %w", groupName, err)
+	}
+
+	return members, nil
+}
+
+// configuredUserNames returns the user names read from the catalog as they are configured, regardless of their case.
+func configuredUserNames(members, userNames []string) []string {
+	configuredNames := make(map[string]string, len(userNames))
+	for _, userName := range userNames {
+		configuredNames[strings.ToLower(userName)] = userName
+	}
+
+	result := make([]string, len(members))
+	for i, member := range members {
+		result[i] = member
+		if configuredName, ok := configuredNames[member]; ok {
+			result[i] = configuredName
+		}
+	}
+	return result
+}
+
+// resourceRedshiftGroupMembershipImport imports all members of the group with the name given as import ID.
+func resourceRedshiftGroupMembershipImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	db, err := meta.(*Client).Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	groupName := d.Id()
+	members, err := readGroupMembers(db, groupName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("group %q doesn't exist or has no members", groupName)
+	}
+
+	d.Set(groupNameAttr, groupName)
+	d.Set(groupUsersAttr, members)
+	d.Set(groupMembershipExclusiveAttr, false)
+	d.SetId(generateGroupMembershipId(groupName, members))
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceRedshiftGroupMembershipUpdate(db *DBConnection, d *schema.ResourceData) error {
@@ -117,6 +198,11 @@ func resourceRedshiftGroupMembershipUpdate(db *DBConnection, d *schema.ResourceD
 	if err := addUsersToGroup(db, d.Get(groupNameAttr).(string), addedUserNames); err != nil {
 		return fmt.Errorf("error adding users to group while updating the resource: %w", err)
 	}
+	if d.Get(groupMembershipExclusiveAttr).(bool) {
+		if err := dropOtherUsersFromGroup(db, d.Get(groupNameAttr).(string), newUserNames); err != nil {
+			return fmt.Errorf("error removing other users from group while updating the resource: %w", err)
+		}
+	}
 	return resourceRedshiftGroupMembershipRead(db, d)
 }
 
@@ -170,6 +256,16 @@ func dropUsersFromGroup(db *DBConnection, groupName string, userNames []string)
 	return nil
 }
 
+// dropOtherUsersFromGroup removes all members but the given users from the group.
+func dropOtherUsersFromGroup(db *DBConnection, groupName string, userNames []string) error {
+	members, err := readGroupMembers(db, groupName, nil)
+	if err != nil {
+		return err
+	}
+	otherUserNames, _ := calculateUserNamesDiff(configuredUserNames(members, userNames), userNames)
+	return dropUsersFromGroup(db, groupName, otherUserNames)
+}
+
 func parseUserNames(rawUserNames interface{}) []string {
 	rawUserNamesTyped := rawUserNames.(*schema.Set).List()
 	userNames := make([]string, len(rawUserNamesTyped))
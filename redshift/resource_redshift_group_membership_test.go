@@ -490,3 +490,179 @@ func Test_calculateUserNamesDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestAccRedshiftGroupMembership_Import(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group_membership_import")
+	userName := generateRandomObjectName("tf_acc_group_membership_import_user")
+	config := fmt.Sprintf(`
+resource "redshift_group" "simple" {
+  name = %[1]q
+
+  lifecycle {
+    ignore_changes = [
+      users
+    ]
+  }
+}
+
+resource "redshift_user" "simple" {
+  name = %[2]q
+}
+
+resource "redshift_group_membership" "simple" {
+  name  = redshift_group.simple.name
+  users = [redshift_user.simple.name]
+}
+`, groupName, userName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftGroupMembershipPresence(groupName, userName, true),
+			},
+			{
+				ResourceName:      "redshift_group_membership.simple",
+				ImportState:       true,
+				ImportStateId:     groupName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGroupMembership_RemovedOutOfBand(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group_membership_drift")
+	userName := generateRandomObjectName("tf_acc_group_membership_drift_user")
+	config := fmt.Sprintf(`
+resource "redshift_group" "simple" {
+  name = %[1]q
+
+  lifecycle {
+    ignore_changes = [
+      users
+    ]
+  }
+}
+
+resource "redshift_user" "simple" {
+  name = %[2]q
+}
+
+resource "redshift_group_membership" "simple" {
+  name  = redshift_group.simple.name
+  users = [redshift_user.simple.name]
+}
+`, groupName, userName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftGroupMembershipPresence(groupName, userName, true),
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if err := dropUsersFromGroup(db, groupName, []string{userName}); err != nil {
+						t.Fatalf("could not remove user from group: %v", err)
+					}
+				},
+				Config: config,
+				Check:  testAccCheckRedshiftGroupMembershipPresence(groupName, userName, true),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGroupMembership_Exclusive(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group_membership_exclusive")
+	userName := generateRandomObjectName("tf_acc_group_membership_exclusive_user")
+	otherUserName := generateRandomObjectName("tf_acc_group_membership_other_user")
+	config := func(exclusive bool) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "simple" {
+  name = %[2]q
+}
+
+resource "redshift_user" "other" {
+  name = %[3]q
+}
+
+resource "redshift_group" "simple" {
+  name  = %[1]q
+  users = [redshift_user.other.name]
+
+  lifecycle {
+    ignore_changes = [
+      users
+    ]
+  }
+}
+
+resource "redshift_group_membership" "simple" {
+  name      = redshift_group.simple.name
+  users     = [redshift_user.simple.name]
+  exclusive = %[4]t
+}
+`, groupName, userName, otherUserName, exclusive)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_group_membership.simple", "users.#", "1"),
+					testAccCheckRedshiftGroupMembershipPresence(groupName, userName, true),
+					testAccCheckRedshiftGroupMembershipPresence(groupName, otherUserName, true),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_group_membership.simple", "users.#", "1"),
+					testAccCheckRedshiftGroupMembershipPresence(groupName, userName, true),
+					testAccCheckRedshiftGroupMembershipPresence(groupName, otherUserName, false),
+				),
+			},
+			{
+				// Users added outside of terraform are detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if err := addUsersToGroup(db, groupName, []string{otherUserName}); err != nil {
+						t.Fatalf("could not add user to group: %v", err)
+					}
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				Check:  testAccCheckRedshiftGroupMembershipPresence(groupName, otherUserName, false),
+			},
+		},
+	})
+}
+
+func Test_configuredUserNames(t *testing.T) {
+	got := configuredUserNames([]string{"user1", "user2"}, []string{"User1"})
+	if want := []string{"User1", "user2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("configuredUserNames() = %v, want %v", got, want)
+	}
+}
@@ -277,3 +277,42 @@ resource "redshift_user" "group_test_user2" {
   name = "group_test_user2"
 }
 `
+
+func TestAccRedshiftGroup_ImportByName(t *testing.T) {
+	groupName := generateRandomObjectName("tf_acc_group_import")
+	userName := generateRandomObjectName("tf_acc_group_import_user")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_group" "group" {
+  name  = %[1]q
+  users = [redshift_user.user.name]
+}
+`, groupName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftGroupExists(groupName),
+			},
+			{
+				ResourceName:      "redshift_group.group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_group.group",
+				ImportState:       true,
+				ImportStateId:     groupName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
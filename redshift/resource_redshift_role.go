@@ -5,16 +5,63 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
 )
 
 const (
-	roleNameAttr = "name"
+	roleNameAttr             = "name"
+	roleOwnerAttr            = "owner"
+	roleSystemPrivilegesAttr = "system_privileges"
 )
 
+// roleIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var roleIdentifierAttrs = []string{roleNameAttr}
+
+// See https://docs.aws.amazon.com/redshift/latest/dg/r_roles-default.html
+var roleAllowedSystemPrivileges = []string{
+	"ACCESS CATALOG",
+	"ACCESS SYSTEM TABLE",
+	"ALTER DATASHARE",
+	"ALTER DEFAULT PRIVILEGES",
+	"ALTER TABLE",
+	"ALTER USER",
+	"ANALYZE",
+	"CANCEL",
+	"CREATE DATASHARE",
+	"CREATE LIBRARY",
+	"CREATE MATERIALIZED VIEW",
+	"CREATE MODEL",
+	"CREATE OR REPLACE EXTERNAL FUNCTION",
+	"CREATE OR REPLACE FUNCTION",
+	"CREATE OR REPLACE PROCEDURE",
+	"CREATE OR REPLACE VIEW",
+	"CREATE ROLE",
+	"CREATE SCHEMA",
+	"CREATE TABLE",
+	"CREATE USER",
+	"DROP DATASHARE",
+	"DROP FUNCTION",
+	"DROP LIBRARY",
+	"DROP MATERIALIZED VIEW",
+	"DROP MODEL",
+	"DROP PROCEDURE",
+	"DROP ROLE",
+	"DROP SCHEMA",
+	"DROP TABLE",
+	"DROP USER",
+	"DROP VIEW",
+	"EXPLAIN MASKING",
+	"EXPLAIN RLS",
+	"IGNORE RLS",
+	"TRUNCATE TABLE",
+	"VACUUM",
+}
+
 func redshiftRole() *schema.Resource {
 	return &schema.Resource{
 		Description: `
@@ -23,11 +70,11 @@ Roles allow you to create a hierarchy of permissions, where a role can inherit p
 
 For more information, see [Redshift Roles Documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_roles-managing.html).
 `,
-		CreateContext: ResourceFunc(resourceRedshiftRoleCreate),
-		ReadContext:   ResourceFunc(resourceRedshiftRoleRead),
-		UpdateContext: ResourceFunc(resourceRedshiftRoleUpdate),
+		CreateContext: ResourceFunc(ResourceNormalizeIdentifiers(roleIdentifierAttrs, resourceRedshiftRoleCreate)),
+		ReadContext:   ResourceFunc(ResourceNormalizeIdentifiers(roleIdentifierAttrs, resourceRedshiftRoleRead)),
+		UpdateContext: ResourceFunc(ResourceNormalizeIdentifiers(roleIdentifierAttrs, resourceRedshiftRoleUpdate)),
 		DeleteContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftRoleDelete),
+			ResourceNormalizeIdentifiers(roleIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleDelete)),
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -35,12 +82,30 @@ For more information, see [Redshift Roles Documentation](https://docs.aws.amazon
 
 		Schema: map[string]*schema.Schema{
 			roleNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the role. Role names are case-insensitive and must be unique within the database, unless `enable_case_sensitive_identifier` is on.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			roleOwnerAttr: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the role. Role names are case-insensitive and must be unique within the database.",
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the role owner. Defaults to the user the provider is connected as.",
+			},
+			roleSystemPrivilegesAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(roleAllowedSystemPrivileges, true),
+					StateFunc: func(val interface{}) string {
+						return strings.ToUpper(val.(string))
+					},
 				},
+				Set:         schema.HashString,
+				Description: "The system privileges granted to the role, e.g. `CREATE USER` or `ACCESS SYSTEM TABLE`. See [Amazon Redshift system-defined roles](https://docs.aws.amazon.com/redshift/latest/dg/r_roles-default.html) for the available system privileges. If not set, the system privileges of the role are read back without being managed.",
 			},
 		},
 	}
@@ -49,7 +114,7 @@ For more information, see [Redshift Roles Documentation](https://docs.aws.amazon
 func resourceRedshiftRoleCreate(db *DBConnection, d *schema.ResourceData) error {
 	roleName := d.Get(roleNameAttr).(string)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -59,36 +124,52 @@ func resourceRedshiftRoleCreate(db *DBConnection, d *schema.ResourceData) error
 	log.Printf("[DEBUG] %s\n", query)
 
 	if _, err := tx.Exec(query); err != nil {
-		return fmt.Errorf("could not create redshift role: %w", err)
+		return fmt.Errorf("could not create redshift role: %w", unsupportedSyntaxError(db, "CREATE ROLE", err))
 	}
 
 	// Query SVV_ROLES to get the role info (similar to how datashares use SVV_DATASHARES)
 	// SVV_ROLES should have: role_name, role_owner, role_id
 	var roleId string
 	query = "SELECT role_name FROM SVV_ROLES WHERE role_name = $1"
-	log.Printf("[DEBUG] %s, $1=%s\n", query, strings.ToLower(roleName))
-	if err := tx.QueryRow(query, strings.ToLower(roleName)).Scan(&roleId); err != nil {
+	log.Printf("[DEBUG] %s, $1=%s\n", query, normalizeIdentifier(db, roleName))
+	if err := tx.QueryRow(query, normalizeIdentifier(db, roleName)).Scan(&roleId); err != nil {
 		return fmt.Errorf("could not verify role creation for %q: %w", roleName, err)
 	}
 
+	if v, ok := d.GetOk(roleOwnerAttr); ok {
+		if err := setRoleOwner(tx, roleName, v.(string)); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk(roleSystemPrivilegesAttr); ok {
+		if err := grantRoleSystemPrivileges(tx, roleName, v.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
 	// Use role name as ID (similar to datashare using share_id)
-	d.SetId(strings.ToLower(roleName))
+	d.SetId(normalizeIdentifier(db, roleName))
 
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	if err := waitUntilVisible(db, fmt.Sprintf("role %q", roleName), "SELECT EXISTS(SELECT 1 FROM SVV_ROLES WHERE role_name = $1)", d.Id()); err != nil {
+		return err
+	}
+
 	return resourceRedshiftRoleRead(db, d)
 }
 
 func resourceRedshiftRoleRead(db *DBConnection, d *schema.ResourceData) error {
-	var roleName string
+	var roleName, roleOwner string
 
 	// Query SVV_ROLES (similar to SVV_DATASHARES pattern)
-	query := "SELECT role_name FROM SVV_ROLES WHERE role_name = $1"
+	query := "SELECT role_name, COALESCE(role_owner, '') FROM SVV_ROLES WHERE role_name = $1"
 	log.Printf("[DEBUG] %s, $1=%s\n", query, d.Id())
 
-	err := db.QueryRow(query, d.Id()).Scan(&roleName)
+	err := db.QueryRow(query, d.Id()).Scan(&roleName, &roleOwner)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Printf("[WARN] Redshift Role (%s) not found", d.Id())
@@ -98,23 +179,55 @@ func resourceRedshiftRoleRead(db *DBConnection, d *schema.ResourceData) error {
 		return fmt.Errorf("error reading role: %w", err)
 	}
 
+	systemPrivileges, err := readRoleSystemPrivileges(db, roleName)
+	if err != nil {
+		return err
+	}
+
 	d.Set(roleNameAttr, roleName)
+	d.Set(roleOwnerAttr, roleOwner)
+	d.Set(roleSystemPrivilegesAttr, systemPrivileges)
 
 	return nil
 }
 
+func readRoleSystemPrivileges(db *DBConnection, roleName string) (*schema.Set, error) {
+	query := "SELECT system_privilege FROM SVV_SYSTEM_PRIVILEGES WHERE identity_type = 'role' AND identity_name = $1"
+	log.Printf("[DEBUG] %s, $1=%s\n", query, roleName)
+
+	rows, err := db.Query(query, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading role system privileges: %w", err)
+	}
+	defer rows.Close()
+
+	systemPrivileges := schema.NewSet(schema.HashString, nil)
+	for rows.Next() {
+		var systemPrivilege string
+		if err := rows.Scan(&systemPrivilege); err != nil {
+			return nil, fmt.Errorf("error reading role system privileges: %w", err)
+		}
+		systemPrivileges.Add(strings.ToUpper(strings.TrimSpace(systemPrivilege)))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading role system privileges: %w", err)
+	}
+
+	return systemPrivileges, nil
+}
+
 func resourceRedshiftRoleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
 	if d.HasChange(roleNameAttr) {
 		oldNameRaw, newNameRaw := d.GetChange(roleNameAttr)
 		oldName := oldNameRaw.(string)
 		newName := newNameRaw.(string)
 
-		tx, err := startTransaction(db.client)
-		if err != nil {
-			return err
-		}
-		defer deferredRollback(tx)
-
 		query := fmt.Sprintf("ALTER ROLE %s RENAME TO %s",
 			pq.QuoteIdentifier(oldName),
 			pq.QuoteIdentifier(newName))
@@ -123,20 +236,95 @@ func resourceRedshiftRoleUpdate(db *DBConnection, d *schema.ResourceData) error
 		if _, err := tx.Exec(query); err != nil {
 			return fmt.Errorf("error renaming role: %w", err)
 		}
+	}
+
+	if d.HasChange(roleOwnerAttr) {
+		if owner := d.Get(roleOwnerAttr).(string); owner != "" {
+			if err := setRoleOwner(tx, d.Get(roleNameAttr).(string), owner); err != nil {
+				return err
+			}
+		}
+	}
 
-		if err = tx.Commit(); err != nil {
-			return fmt.Errorf("could not commit transaction: %w", err)
+	if d.HasChange(roleSystemPrivilegesAttr) {
+		if err := setRoleSystemPrivileges(tx, d); err != nil {
+			return err
 		}
+	}
 
-		// Update the ID to the new name
-		d.SetId(strings.ToLower(newName))
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	// Update the ID to the new name
+	d.SetId(normalizeIdentifier(db, d.Get(roleNameAttr).(string)))
+
 	return resourceRedshiftRoleRead(db, d)
 }
 
+func setRoleOwner(tx *sql.Tx, roleName, owner string) error {
+	query := fmt.Sprintf("ALTER ROLE %s OWNER TO %s",
+		pq.QuoteIdentifier(roleName),
+		pq.QuoteIdentifier(owner))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error setting role owner: %w", err)
+	}
+	return nil
+}
+
+func setRoleSystemPrivileges(tx *sql.Tx, d *schema.ResourceData) error {
+	roleName := d.Get(roleNameAttr).(string)
+	oldRaw, newRaw := d.GetChange(roleSystemPrivilegesAttr)
+	removed := oldRaw.(*schema.Set).Difference(newRaw.(*schema.Set))
+	added := newRaw.(*schema.Set).Difference(oldRaw.(*schema.Set))
+
+	if err := revokeRoleSystemPrivileges(tx, roleName, removed); err != nil {
+		return err
+	}
+	return grantRoleSystemPrivileges(tx, roleName, added)
+}
+
+func grantRoleSystemPrivileges(tx *sql.Tx, roleName string, systemPrivileges *schema.Set) error {
+	if systemPrivileges.Len() == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("GRANT %s TO ROLE %s", systemPrivilegesList(systemPrivileges), pq.QuoteIdentifier(roleName))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error granting role system privileges: %w", err)
+	}
+	return nil
+}
+
+func revokeRoleSystemPrivileges(tx *sql.Tx, roleName string, systemPrivileges *schema.Set) error {
+	if systemPrivileges.Len() == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("REVOKE %s FROM ROLE %s", systemPrivilegesList(systemPrivileges), pq.QuoteIdentifier(roleName))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error revoking role system privileges: %w", err)
+	}
+	return nil
+}
+
+func systemPrivilegesList(systemPrivileges *schema.Set) string {
+	privileges := make([]string, 0, systemPrivileges.Len())
+	for _, p := range systemPrivileges.List() {
+		privileges = append(privileges, strings.ToUpper(p.(string)))
+	}
+	sort.Strings(privileges)
+	return strings.Join(privileges, ", ")
+}
+
 func resourceRedshiftRoleDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
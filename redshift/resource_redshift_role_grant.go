@@ -12,11 +12,15 @@ import (
 )
 
 const (
-	roleGrantRoleNameAttr    = "role_name"
-	roleGrantGrantToTypeAttr = "grant_to_type"
-	roleGrantGrantToNameAttr = "grant_to_name"
+	roleGrantRoleNameAttr        = "role_name"
+	roleGrantGrantToTypeAttr     = "grant_to_type"
+	roleGrantGrantToNameAttr     = "grant_to_name"
+	roleGrantWithAdminOptionAttr = "with_admin_option"
 )
 
+// roleGrantIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var roleGrantIdentifierAttrs = []string{roleGrantRoleNameAttr, roleGrantGrantToNameAttr}
+
 func redshiftRoleGrant() *schema.Resource {
 	return &schema.Resource{
 		Description: `
@@ -25,11 +29,22 @@ Grants a role to a user, group, or another role. This allows hierarchical role-b
 When a role is granted to another role, the recipient role inherits all privileges of the granted role. 
 This enables role inheritance chains where permissions can be organized hierarchically.
 
+No system view lists the grants of roles to groups, so a grant to a group can't be read back. It is kept in the state
+as long as both the role and the group exist: revoking the role from the group outside of Terraform is not detected,
+and a warning is shown while planning such a grant.
+
 For more information, see [GRANT documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html).
 `,
-		CreateContext: ResourceFunc(resourceRedshiftRoleGrantCreate),
-		ReadContext:   ResourceFunc(resourceRedshiftRoleGrantRead),
-		DeleteContext: ResourceFunc(resourceRedshiftRoleGrantDelete),
+		CreateContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleGrantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleGrantCreate)),
+		),
+		ReadContext: ResourceFunc(ResourceNormalizeIdentifiers(roleGrantIdentifierAttrs, resourceRedshiftRoleGrantRead)),
+		UpdateContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleGrantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleGrantUpdate)),
+		),
+		DeleteContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleGrantIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleGrantDelete)),
+		),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -37,24 +52,25 @@ For more information, see [GRANT documentation](https://docs.aws.amazon.com/reds
 
 		Schema: map[string]*schema.Schema{
 			roleGrantRoleNameAttr: {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the role to grant.",
-				StateFunc: func(val any) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the role to grant.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			roleGrantGrantToTypeAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "The type of principal to grant the role to. Valid values are: 'user', 'group', or 'role'.",
+				Description: "The type of principal to grant the role to. Valid values are: 'user', 'group', or 'role'. Grants to groups can't be read back, revoking the role from the group outside of Terraform is not detected.",
 				ValidateFunc: func(val any, key string) (warns []string, errs []error) {
 					v := strings.ToLower(val.(string))
 					if v != "user" && v != "group" && v != "role" {
 						errs = append(errs, fmt.Errorf("%q must be one of: 'user', 'group', 'role', got: %s", key, val))
 					}
+					if v == "group" {
+						warns, _ = warnRoleGrantToGroup(val, key)
+					}
 					return
 				},
 				StateFunc: func(val any) string {
@@ -62,13 +78,17 @@ For more information, see [GRANT documentation](https://docs.aws.amazon.com/reds
 				},
 			},
 			roleGrantGrantToNameAttr: {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the user, group, or role to grant this role to.",
-				StateFunc: func(val any) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the user, group, or role to grant this role to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			roleGrantWithAdminOptionAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the grantee can grant the role to other principals. Only supported when `grant_to_type` is `user`.",
 			},
 		},
 	}
@@ -78,29 +98,26 @@ func resourceRedshiftRoleGrantCreate(db *DBConnection, d *schema.ResourceData) e
 	roleName := d.Get(roleGrantRoleNameAttr).(string)
 	grantToType := strings.ToUpper(d.Get(roleGrantGrantToTypeAttr).(string))
 	grantToName := d.Get(roleGrantGrantToNameAttr).(string)
+	withAdminOption := d.Get(roleGrantWithAdminOptionAttr).(bool)
 
-	tx, err := startTransaction(db.client)
-	if err != nil {
+	if withAdminOption && grantToType != "USER" {
+		return fmt.Errorf("%q can only be used when %q is 'user'", roleGrantWithAdminOptionAttr, roleGrantGrantToTypeAttr)
+	}
+
+	if err := checkPrincipalType(db, "role", roleName); err != nil {
+		return err
+	}
+	if err := checkPrincipalType(db, strings.ToLower(grantToType), grantToName); err != nil {
 		return err
 	}
-	defer deferredRollback(tx)
 
-	// GRANT ROLE syntax in Redshift:
-	// - For USER: GRANT ROLE role TO username (no USER keyword)
-	// - For ROLE: GRANT ROLE role TO ROLE rolename (ROLE keyword required)
-	// - For GROUP: GRANT ROLE role TO GROUP groupname (GROUP keyword required)
-	var query string
-	if grantToType == "USER" {
-		query = fmt.Sprintf("GRANT ROLE %s TO %s",
-			pq.QuoteIdentifier(roleName),
-			pq.QuoteIdentifier(grantToName))
-	} else {
-		query = fmt.Sprintf("GRANT ROLE %s TO %s %s",
-			pq.QuoteIdentifier(roleName),
-			grantToType,
-			pq.QuoteIdentifier(grantToName))
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
 	}
+	defer deferredRollback(tx)
 
+	query := createRoleGrantQuery(roleName, grantToType, grantToName, withAdminOption)
 	log.Printf("[DEBUG] %s\n", query)
 
 	if _, err := tx.Exec(query); err != nil {
@@ -122,14 +139,14 @@ func resourceRedshiftRoleGrantRead(db *DBConnection, d *schema.ResourceData) err
 	grantToType := d.Get(roleGrantGrantToTypeAttr).(string) // Already lowercase from StateFunc
 	grantToName := d.Get(roleGrantGrantToNameAttr).(string)
 
-	var exists int
+	var adminOption bool
 	var query string
 
 	switch strings.ToUpper(grantToType) {
 	case "USER":
 		// Check SVV_USER_GRANTS for role grants to users
 		query = `
-			SELECT 1
+			SELECT COALESCE(admin_option, false)
 			FROM SVV_USER_GRANTS
 			WHERE LOWER(role_name) = LOWER($1)
 			AND LOWER(user_name) = LOWER($2)
@@ -138,21 +155,27 @@ func resourceRedshiftRoleGrantRead(db *DBConnection, d *schema.ResourceData) err
 		// Check SVV_ROLE_GRANTS for role grants to other roles
 		// Note: role_name is the grantee (child), granted_role_name is the granted role (parent)
 		query = `
-			SELECT 1
+			SELECT false
 			FROM SVV_ROLE_GRANTS
 			WHERE LOWER(granted_role_name) = LOWER($1)
 			AND LOWER(role_name) = LOWER($2)
 		`
 	case "GROUP":
-		// SVV_GROUP_GRANTS doesn't exist, trust the state
-		return nil
+		// No system view lists the grants of roles to groups, so the grant itself can't be read back.
+		// Only a dropped role or group is detected, the state is kept as long as both exist.
+		query = `
+			SELECT false
+			FROM SVV_ROLES r, pg_group g
+			WHERE LOWER(r.role_name) = LOWER($1)
+			AND LOWER(g.groname) = LOWER($2)
+		`
 	default:
 		return fmt.Errorf("unsupported grant_to_type: %s", grantToType)
 	}
 
 	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, roleName, grantToName)
 
-	err := db.QueryRow(query, roleName, grantToName).Scan(&exists)
+	err := db.QueryRow(query, roleName, grantToName).Scan(&adminOption)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Printf("[WARN] Role grant %s to %s %s not found", roleName, grantToType, grantToName)
@@ -162,9 +185,51 @@ func resourceRedshiftRoleGrantRead(db *DBConnection, d *schema.ResourceData) err
 		return fmt.Errorf("error reading role grant: %w", err)
 	}
 
+	d.Set(roleGrantWithAdminOptionAttr, adminOption)
+
 	return nil
 }
 
+func resourceRedshiftRoleGrantUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(roleGrantWithAdminOptionAttr) {
+		return resourceRedshiftRoleGrantRead(db, d)
+	}
+
+	roleName := d.Get(roleGrantRoleNameAttr).(string)
+	grantToType := strings.ToUpper(d.Get(roleGrantGrantToTypeAttr).(string))
+	grantToName := d.Get(roleGrantGrantToNameAttr).(string)
+	withAdminOption := d.Get(roleGrantWithAdminOptionAttr).(bool)
+
+	if grantToType != "USER" {
+		return fmt.Errorf("%q can only be used when %q is 'user'", roleGrantWithAdminOptionAttr, roleGrantGrantToTypeAttr)
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	// Granting the role again adds the admin option, revoking the admin option keeps the role itself
+	query := createRoleGrantQuery(roleName, grantToType, grantToName, true)
+	if !withAdminOption {
+		query = fmt.Sprintf("REVOKE ADMIN OPTION FOR ROLE %s FROM %s",
+			pq.QuoteIdentifier(roleName),
+			pq.QuoteIdentifier(grantToName))
+	}
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not update admin option of role grant: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftRoleGrantRead(db, d)
+}
+
 func resourceRedshiftRoleGrantDelete(db *DBConnection, d *schema.ResourceData) error {
 	// Parse ID to get the values to revoke
 	// ID format: "role:rolename:type:targetname"
@@ -177,28 +242,13 @@ func resourceRedshiftRoleGrantDelete(db *DBConnection, d *schema.ResourceData) e
 	grantToType := strings.ToUpper(parts[2])
 	grantToName := parts[3]
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
-	// REVOKE ROLE syntax in Redshift:
-	// - For USER: REVOKE ROLE role FROM username (no USER keyword)
-	// - For ROLE: REVOKE ROLE role FROM ROLE rolename (ROLE keyword required)
-	// - For GROUP: REVOKE ROLE role FROM GROUP groupname (GROUP keyword required)
-	var query string
-	if grantToType == "USER" {
-		query = fmt.Sprintf("REVOKE ROLE %s FROM %s",
-			pq.QuoteIdentifier(roleName),
-			pq.QuoteIdentifier(grantToName))
-	} else {
-		query = fmt.Sprintf("REVOKE ROLE %s FROM %s %s",
-			pq.QuoteIdentifier(roleName),
-			grantToType,
-			pq.QuoteIdentifier(grantToName))
-	}
-
+	query := revokeRoleGrantQuery(roleName, grantToType, grantToName)
 	log.Printf("[DEBUG] %s\n", query)
 
 	if _, err := tx.Exec(query); err != nil {
@@ -221,9 +271,47 @@ func resourceRedshiftRoleGrantDelete(db *DBConnection, d *schema.ResourceData) e
 	return nil
 }
 
+// createRoleGrantQuery builds the GRANT ROLE statement. The syntax in Redshift is:
+// - For USER: GRANT ROLE role TO username [WITH ADMIN OPTION] (no USER keyword)
+// - For ROLE: GRANT ROLE role TO ROLE rolename (ROLE keyword required)
+// - For GROUP: GRANT ROLE role TO GROUP groupname (GROUP keyword required)
+func createRoleGrantQuery(roleName, grantToType, grantToName string, withAdminOption bool) string {
+	if grantToType != "USER" {
+		return fmt.Sprintf("GRANT ROLE %s TO %s %s",
+			pq.QuoteIdentifier(roleName),
+			grantToType,
+			pq.QuoteIdentifier(grantToName))
+	}
+
+	query := fmt.Sprintf("GRANT ROLE %s TO %s",
+		pq.QuoteIdentifier(roleName),
+		pq.QuoteIdentifier(grantToName))
+	if withAdminOption {
+		query += " WITH ADMIN OPTION"
+	}
+	return query
+}
+
+// revokeRoleGrantQuery builds the REVOKE ROLE statement. The syntax in Redshift is:
+// - For USER: REVOKE ROLE role FROM username (no USER keyword)
+// - For ROLE: REVOKE ROLE role FROM ROLE rolename (ROLE keyword required)
+// - For GROUP: REVOKE ROLE role FROM GROUP groupname (GROUP keyword required)
+func revokeRoleGrantQuery(roleName, grantToType, grantToName string) string {
+	if grantToType != "USER" {
+		return fmt.Sprintf("REVOKE ROLE %s FROM %s %s",
+			pq.QuoteIdentifier(roleName),
+			grantToType,
+			pq.QuoteIdentifier(grantToName))
+	}
+
+	return fmt.Sprintf("REVOKE ROLE %s FROM %s",
+		pq.QuoteIdentifier(roleName),
+		pq.QuoteIdentifier(grantToName))
+}
+
 func generateRoleGrantID(roleName, grantToType, grantToName string) string {
 	return fmt.Sprintf("role:%s:%s:%s",
-		strings.ToLower(roleName),
+		roleName,
 		strings.ToLower(grantToType),
-		strings.ToLower(grantToName))
+		grantToName)
 }
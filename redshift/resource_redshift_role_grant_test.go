@@ -0,0 +1,252 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftRoleGrant_GroupDroppedOutOfBand(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+	groupName := generateRandomObjectName("tf_acc_group")
+
+	config := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_role_grant" "grant" {
+  role_name     = redshift_role.role.name
+  grant_to_type = "group"
+  grant_to_name = redshift_group.group.name
+}
+`, roleName, groupName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role_grant.grant", "id", generateRoleGrantID(roleName, "group", groupName)),
+					resource.TestCheckResourceAttr("redshift_role_grant.grant", "grant_to_type", "group"),
+				),
+			},
+			{
+				// The grant to the group is kept in the state
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// Grants to groups are not listed by Redshift, only a dropped group is detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("DROP GROUP %s", pq.QuoteIdentifier(groupName))); err != nil {
+						t.Fatalf("could not drop group: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRoleGrant_WithAdminOption(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+	userName := generateRandomObjectName("tf_acc_user")
+
+	config := func(withAdminOption bool) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_role_grant" "grant" {
+  role_name         = redshift_role.role.name
+  grant_to_type     = "user"
+  grant_to_name     = redshift_user.user.name
+  with_admin_option = %[3]t
+}
+`, roleName, userName, withAdminOption)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("redshift_role_grant.grant", "with_admin_option", "true"),
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("redshift_role_grant.grant", "with_admin_option", "false"),
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("redshift_role_grant.grant", "with_admin_option", "true"),
+			},
+			{
+				// Revoke the admin option outside of terraform, the plan must re-apply it
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					query := fmt.Sprintf("REVOKE ADMIN OPTION FOR ROLE %s FROM %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(userName))
+					if _, err := db.Exec(query); err != nil {
+						t.Fatalf("could not revoke admin option: %v", err)
+					}
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRoleGrant_GranteeTypeMismatch(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+	groupName := generateRandomObjectName("tf_acc_group")
+	userName := generateRandomObjectName("tf_acc_user")
+
+	config := func(role, grantToType, grantToName string) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_user" "user" {
+  name = %[3]q
+}
+
+resource "redshift_role_grant" "grant" {
+  role_name     = %[4]q
+  grant_to_type = %[5]q
+  grant_to_name = %[6]q
+
+  depends_on = [redshift_role.role, redshift_group.group, redshift_user.user]
+}
+`, roleName, groupName, userName, role, grantToType, grantToName)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config(roleName, "group", userName),
+				ExpectError: regexp.MustCompile(`group "` + userName + `" does not exist, but a user with that name does`),
+			},
+			{
+				Config:      config(groupName, "user", userName),
+				ExpectError: regexp.MustCompile(`role "` + groupName + `" does not exist, but a group with that name does`),
+			},
+		},
+	})
+}
+
+func TestCreateRoleGrantQuery(t *testing.T) {
+	tests := map[string]struct {
+		grantToType     string
+		withAdminOption bool
+		expected        string
+	}{
+		"user": {
+			grantToType: "USER",
+			expected:    `GRANT ROLE "analyst" TO "bob"`,
+		},
+		"user with admin option": {
+			grantToType:     "USER",
+			withAdminOption: true,
+			expected:        `GRANT ROLE "analyst" TO "bob" WITH ADMIN OPTION`,
+		},
+		"group": {
+			grantToType: "GROUP",
+			expected:    `GRANT ROLE "analyst" TO GROUP "bob"`,
+		},
+		"role": {
+			grantToType: "ROLE",
+			expected:    `GRANT ROLE "analyst" TO ROLE "bob"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := createRoleGrantQuery("analyst", tt.grantToType, "bob", tt.withAdminOption); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRevokeRoleGrantQuery(t *testing.T) {
+	tests := map[string]struct {
+		grantToType string
+		expected    string
+	}{
+		"user": {
+			grantToType: "USER",
+			expected:    `REVOKE ROLE "analyst" FROM "bob"`,
+		},
+		"group": {
+			grantToType: "GROUP",
+			expected:    `REVOKE ROLE "analyst" FROM GROUP "bob"`,
+		},
+		"role": {
+			grantToType: "ROLE",
+			expected:    `REVOKE ROLE "analyst" FROM ROLE "bob"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := revokeRoleGrantQuery("analyst", tt.grantToType, "bob"); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRoleGrantToGroupWarning(t *testing.T) {
+	validate := redshiftRoleGrant().Schema[roleGrantGrantToTypeAttr].ValidateFunc
+	for grantToType, expectWarning := range map[string]bool{"group": true, "GROUP": true, "user": false, "role": false} {
+		t.Run(grantToType, func(t *testing.T) {
+			warns, errs := validate(grantToType, roleGrantGrantToTypeAttr)
+			if len(errs) > 0 {
+				t.Fatalf("Expected no error but got %v", errs)
+			}
+			if expectWarning != (len(warns) > 0) {
+				t.Errorf("Expected warning %t but got %v", expectWarning, warns)
+			}
+		})
+	}
+}
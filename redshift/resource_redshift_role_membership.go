@@ -0,0 +1,323 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	roleMembershipUsersAttr     = "users"
+	roleMembershipGroupsAttr    = "groups"
+	roleMembershipRolesAttr     = "roles"
+	roleMembershipExclusiveAttr = "exclusive"
+)
+
+// roleMembershipIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var roleMembershipIdentifierAttrs = []string{roleGrantRoleNameAttr}
+
+// roleMembershipAttrs are the attributes holding the members of a role by grantee type.
+var roleMembershipAttrs = map[string]string{
+	"user":  roleMembershipUsersAttr,
+	"group": roleMembershipGroupsAttr,
+	"role":  roleMembershipRolesAttr,
+}
+
+func redshiftRoleMembership() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the users, groups and roles a role is granted to in one place, rather than with one ` + "`redshift_role_grant`" + ` per grantee. Allows either to exclusively manage the members of a role or to add members to a role granted elsewhere. Note: this resource conflicts with ` + "`redshift_role_grant`" + ` resources granting the same role, unless the membership isn't exclusive and they grant the role to other principals.
+`,
+		CreateContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleMembershipIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleMembershipCreate)),
+		),
+		ReadContext: ResourceFunc(ResourceNormalizeIdentifiers(roleMembershipIdentifierAttrs, resourceRedshiftRoleMembershipRead)),
+		UpdateContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleMembershipIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleMembershipUpdate)),
+		),
+		DeleteContext: ResourceFunc(
+			ResourceNormalizeIdentifiers(roleMembershipIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftRoleMembershipDelete)),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftRoleMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			roleGrantRoleNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the role to grant.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			roleMembershipUsersAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the users to grant the role to. The admin option of the grants isn't managed, use `redshift_role_grant` to grant a role with the admin option.",
+			},
+			roleMembershipGroupsAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: warnRoleGrantToGroup},
+				Description: "The names of the groups to grant the role to. As no system view lists the grants of roles to groups, they can't be read back: only groups which were dropped are removed from the state, revoking the role from a group outside of Terraform is not detected and `exclusive` doesn't apply to groups.",
+			},
+			roleMembershipRolesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the roles to grant the role to. These roles inherit all privileges of the granted role.",
+			},
+			roleMembershipExclusiveAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the listed users, groups and roles are the only members of the role. If `true`, the role is revoked from all other users and roles. By default other members, e.g. granted by `redshift_role_grant`, are left untouched and only the listed principals are tracked.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftRoleMembershipCreate(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleGrantRoleNameAttr).(string)
+	if err := checkPrincipalType(db, "role", roleName); err != nil {
+		return err
+	}
+
+	if err := setRoleMembers(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(generateRoleMembershipID(roleName))
+	return resourceRedshiftRoleMembershipRead(db, d)
+}
+
+func resourceRedshiftRoleMembershipRead(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleGrantRoleNameAttr).(string)
+
+	var count int
+	if err := db.QueryRow(principalCatalogQueries["role"], roleName).Scan(&count); err != nil {
+		return fmt.Errorf("could not read role %q: %w", roleName, err)
+	}
+	if count == 0 {
+		log.Printf("[WARN] Role %s not found, removing its membership from state", roleName)
+		d.SetId("")
+		return nil
+	}
+
+	grants, err := readRoleGrants(db, strings.ToLower(roleName))
+	if err != nil {
+		return err
+	}
+
+	exclusive := d.Get(roleMembershipExclusiveAttr).(bool)
+	for _, granteeType := range principalTypes {
+		attr := roleMembershipAttrs[granteeType]
+		configured := parseUserNames(d.Get(attr))
+
+		// Unless the membership is exclusive, only the configured members are tracked
+		members, err := roleMembers(db, grants, granteeType, configured)
+		if err != nil {
+			return err
+		}
+		if !exclusive {
+			members = intersectNames(db, members, configured)
+		}
+		d.Set(attr, configuredUserNames(members, configured))
+	}
+
+	return nil
+}
+
+func resourceRedshiftRoleMembershipUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setRoleMembers(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftRoleMembershipRead(db, d)
+}
+
+// setRoleMembers grants the role to the configured members which don't hold it yet and revokes it from
+// the members removed from the configuration or, for an exclusive membership, from all other principals.
+func setRoleMembers(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleGrantRoleNameAttr).(string)
+	exclusive := d.Get(roleMembershipExclusiveAttr).(bool)
+
+	grants, err := readRoleGrants(db, strings.ToLower(roleName))
+	if err != nil {
+		return err
+	}
+
+	queries := make([]string, 0)
+	for _, granteeType := range principalTypes {
+		attr := roleMembershipAttrs[granteeType]
+		oldRaw, newRaw := d.GetChange(attr)
+		oldNames := parseUserNames(oldRaw)
+		newNames := parseUserNames(newRaw)
+		granted, err := roleMembers(db, grants, granteeType, oldNames)
+		if err != nil {
+			return err
+		}
+
+		revokedNames := intersectNames(db, granted, subtractNames(db, oldNames, newNames))
+		if exclusive {
+			revokedNames = subtractNames(db, granted, newNames)
+		}
+		for _, name := range revokedNames {
+			queries = append(queries, revokeRoleGrantQuery(roleName, strings.ToUpper(granteeType), name))
+		}
+		for _, name := range subtractNames(db, newNames, granted) {
+			if err := checkPrincipalType(db, granteeType, name); err != nil {
+				return err
+			}
+			queries = append(queries, createRoleGrantQuery(roleName, strings.ToUpper(granteeType), name, false))
+		}
+	}
+
+	if len(queries) == 0 {
+		return nil
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not update members of role %q: %w", roleName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+func resourceRedshiftRoleMembershipDelete(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleGrantRoleNameAttr).(string)
+
+	grants, err := readRoleGrants(db, strings.ToLower(roleName))
+	if err != nil {
+		return err
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	// Only the members still holding the role are revoked, the role itself may be gone already
+	for _, granteeType := range principalTypes {
+		configured := parseUserNames(d.Get(roleMembershipAttrs[granteeType]))
+		members, err := roleMembers(db, grants, granteeType, configured)
+		if err != nil {
+			return err
+		}
+		for _, name := range intersectNames(db, members, configured) {
+			query := revokeRoleGrantQuery(roleName, strings.ToUpper(granteeType), name)
+			log.Printf("[DEBUG] %s\n", query)
+			if _, err := tx.Exec(query); err != nil {
+				return fmt.Errorf("could not revoke role %q: %w", roleName, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// resourceRedshiftRoleMembershipImport imports all members of the role with the name given as import ID.
+func resourceRedshiftRoleMembershipImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	db, err := meta.(*Client).Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	roleName := normalizeIdentifier(db, d.Id())
+	var count int
+	if err := db.QueryRow(principalCatalogQueries["role"], roleName).Scan(&count); err != nil {
+		return nil, fmt.Errorf("could not read role %q: %w", roleName, err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("role %q doesn't exist", roleName)
+	}
+
+	grants, err := readRoleGrants(db, strings.ToLower(roleName))
+	if err != nil {
+		return nil, err
+	}
+
+	// Grants to groups are not listed by Redshift, so only users and roles are imported
+	d.Set(roleGrantRoleNameAttr, roleName)
+	for _, granteeType := range principalTypes {
+		d.Set(roleMembershipAttrs[granteeType], roleGrantees(grants, granteeType))
+	}
+	d.Set(roleMembershipExclusiveAttr, false)
+	d.SetId(generateRoleMembershipID(roleName))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// roleMembers returns the members of the role of the given type. No system view lists the grants of roles
+// to groups, so they can't be read back: for groups, the known groups which still exist are returned.
+func roleMembers(db *DBConnection, grants []roleGrant, granteeType string, knownNames []string) ([]string, error) {
+	if granteeType == "group" {
+		return existingPrincipals(db, "group", knownNames)
+	}
+	return roleGrantees(grants, granteeType), nil
+}
+
+// roleGrantees returns the names of the grantees of the given type.
+func roleGrantees(grants []roleGrant, granteeType string) []string {
+	names := make([]string, 0)
+	for _, grant := range grants {
+		if grant.granteeType == granteeType {
+			names = append(names, grant.granteeName)
+		}
+	}
+	return names
+}
+
+// intersectNames returns the names which are also in others, compared like identifiers.
+func intersectNames(db *DBConnection, names, others []string) []string {
+	result := make([]string, 0)
+	for _, name := range names {
+		if containsName(db, others, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// subtractNames returns the names which are not in others, compared like identifiers.
+func subtractNames(db *DBConnection, names, others []string) []string {
+	result := make([]string, 0)
+	for _, name := range names {
+		if !containsName(db, others, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func containsName(db *DBConnection, names []string, name string) bool {
+	for _, n := range names {
+		if normalizeIdentifier(db, n) == normalizeIdentifier(db, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateRoleMembershipID(roleName string) string {
+	return fmt.Sprintf("role_membership:%s", roleName)
+}
@@ -0,0 +1,280 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftRoleMembership_Basic(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role_membership")
+	childRoleName := generateRandomObjectName("tf_acc_role_membership")
+	userName := generateRandomObjectName("tf_acc_role_membership")
+	otherUserName := generateRandomObjectName("tf_acc_role_membership")
+	groupName := generateRandomObjectName("tf_acc_role_membership")
+
+	config := func(members string) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_role" "child" {
+  name = %[2]q
+}
+
+resource "redshift_user" "user" {
+  name = %[3]q
+}
+
+resource "redshift_user" "other" {
+  name = %[4]q
+}
+
+resource "redshift_group" "group" {
+  name = %[5]q
+}
+
+resource "redshift_role_membership" "membership" {
+  role_name = redshift_role.role.name
+  %[6]s
+
+  depends_on = [redshift_role.child, redshift_user.user, redshift_user.other, redshift_group.group]
+}
+`, roleName, childRoleName, userName, otherUserName, groupName, members)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleMembershipDestroy(roleName),
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf(`
+  users  = [%[1]q, %[2]q]
+  groups = [%[3]q]
+  roles  = [%[4]q]`, userName, otherUserName, groupName, childRoleName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "id", generateRoleMembershipID(roleName)),
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "users.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_role_membership.membership", "users.*", userName),
+					resource.TestCheckTypeSetElemAttr("redshift_role_membership.membership", "users.*", otherUserName),
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role_membership.membership", "groups.*", groupName),
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role_membership.membership", "roles.*", childRoleName),
+				),
+			},
+			{
+				Config: config(fmt.Sprintf(`
+  users = [%[1]q]`, userName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role_membership.membership", "users.*", userName),
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "groups.#", "0"),
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "roles.#", "0"),
+					testAccCheckRedshiftRoleGrantees(roleName, []string{userName}),
+				),
+			},
+			{
+				// Revoke the role outside of terraform, the plan must grant it again
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					query := fmt.Sprintf("REVOKE ROLE %s FROM %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(userName))
+					if _, err := db.Exec(query); err != nil {
+						t.Fatalf("could not revoke role: %v", err)
+					}
+				},
+				Config: config(fmt.Sprintf(`
+  users = [%[1]q]`, userName)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:      "redshift_role_membership.membership",
+				ImportState:       true,
+				ImportStateId:     roleName,
+				ImportStateVerify: true,
+				Config: config(fmt.Sprintf(`
+  users = [%[1]q]`, userName)),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRoleMembership_Exclusive(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role_membership")
+	userName := generateRandomObjectName("tf_acc_role_membership")
+	otherUserName := generateRandomObjectName("tf_acc_role_membership")
+
+	config := func(exclusive bool) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_user" "other" {
+  name = %[3]q
+}
+
+resource "redshift_role_membership" "membership" {
+  role_name = redshift_role.role.name
+  users     = [redshift_user.user.name]
+  exclusive = %[4]t
+
+  depends_on = [redshift_user.other]
+}
+`, roleName, userName, otherUserName, exclusive)
+	}
+
+	grantToOther := func() {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("could not connect: %v", err)
+		}
+		query := fmt.Sprintf("GRANT ROLE %s TO %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(otherUserName))
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("could not grant role: %v", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleMembershipDestroy(roleName),
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("redshift_role_membership.membership", "users.#", "1"),
+			},
+			{
+				// Members granted outside of terraform are left untouched
+				PreConfig: grantToOther,
+				Config:    config(false),
+				PlanOnly:  true,
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role_membership.membership", "users.#", "1"),
+					testAccCheckRedshiftRoleGrantees(roleName, []string{userName}),
+				),
+			},
+			{
+				// Exclusive memberships revoke the role from other members
+				PreConfig:          grantToOther,
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				Check:  testAccCheckRedshiftRoleGrantees(roleName, []string{userName}),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRoleMembership_GranteeTypeMismatch(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role_membership")
+	userName := generateRandomObjectName("tf_acc_role_membership")
+
+	config := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_role_membership" "membership" {
+  role_name = redshift_role.role.name
+  groups    = [redshift_user.user.name]
+}
+`, roleName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`group "` + userName + `" does not exist, but a user with that name does`),
+			},
+		},
+	})
+}
+
+// testAccCheckRedshiftRoleGrantees checks that the role is granted to the given users only.
+func testAccCheckRedshiftRoleGrantees(roleName string, userNames []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+		grants, err := readRoleGrants(db, roleName)
+		if err != nil {
+			return err
+		}
+		if grantees := roleGrantees(grants, "user"); !reflect.DeepEqual(grantees, userNames) {
+			return fmt.Errorf("expected role %s to be granted to %v but was granted to %v", roleName, userNames, grantees)
+		}
+		return nil
+	}
+}
+
+func testAccCheckRedshiftRoleMembershipDestroy(roleName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+		grants, err := readRoleGrants(db, roleName)
+		if err != nil {
+			return err
+		}
+		if len(grants) > 0 {
+			return fmt.Errorf("role %s is still granted to %d principals", roleName, len(grants))
+		}
+		return nil
+	}
+}
+
+func TestIntersectAndSubtractNames(t *testing.T) {
+	db := testCaseSensitivityConnection(t, "intersect-names", false)
+	names := []string{"alice", "bob", "carol"}
+	others := []string{"Bob", "dave"}
+
+	if got, expected := intersectNames(db, names, others), []string{"bob"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected intersection %v but got %v", expected, got)
+	}
+	if got, expected := subtractNames(db, names, others), []string{"alice", "carol"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected difference %v but got %v", expected, got)
+	}
+	if got := subtractNames(db, nil, others); len(got) != 0 {
+		t.Errorf("Expected empty difference but got %v", got)
+	}
+
+	caseSensitiveDB := testCaseSensitivityConnection(t, "intersect-names-case-sensitive", true)
+	if got := intersectNames(caseSensitiveDB, names, others); len(got) != 0 {
+		t.Errorf("Expected names only differing in case to differ with case sensitive identifiers but got %v", got)
+	}
+}
@@ -0,0 +1,252 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftRole_Owner(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+	userName := generateRandomObjectName("tf_acc_role_owner")
+
+	configDefaultOwner := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+`, roleName)
+
+	configOwner := fmt.Sprintf(`
+resource "redshift_user" "owner" {
+  name = %[2]q
+}
+
+resource "redshift_role" "role" {
+  name  = %[1]q
+  owner = redshift_user.owner.name
+}
+`, roleName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configDefaultOwner,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_role.role", "name", roleName),
+					resource.TestCheckResourceAttrSet("redshift_role.role", "owner"),
+				),
+			},
+			{
+				Config: configOwner,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_role.role", "owner", userName),
+				),
+			},
+			{
+				ResourceName:      "redshift_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftRoleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_role" {
+			continue
+		}
+
+		exists, err := checkRoleExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error checking role: %w", err)
+		}
+
+		if exists {
+			return fmt.Errorf("role still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftRoleExists(role string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkRoleExists(client, role)
+		if err != nil {
+			return fmt.Errorf("error checking role: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("role not found")
+		}
+
+		return nil
+	}
+}
+
+func checkRoleExists(client *Client, role string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM SVV_ROLES WHERE role_name=$1", strings.ToLower(role)).Scan(&_rez)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading info about role: %w", err)
+	}
+
+	return true, nil
+}
+
+func TestAccRedshiftRole_SystemPrivileges(t *testing.T) {
+	roleName := generateRandomObjectName("tf_acc_role")
+
+	configCreate := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name              = %[1]q
+  system_privileges = ["create user", "ACCESS SYSTEM TABLE"]
+}
+`, roleName)
+
+	configUpdate := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name              = %[1]q
+  system_privileges = ["ACCESS SYSTEM TABLE", "DROP USER"]
+}
+`, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_role.role", "system_privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "CREATE USER"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "ACCESS SYSTEM TABLE"),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role.role", "system_privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "ACCESS SYSTEM TABLE"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "DROP USER"),
+				),
+			},
+			{
+				// Revoke a privilege outside of terraform, the plan must detect the drift
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("REVOKE DROP USER FROM ROLE %s", pq.QuoteIdentifier(roleName))); err != nil {
+						t.Fatalf("could not revoke system privilege: %v", err)
+					}
+				},
+				Config:             configUpdate,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestSystemPrivilegesList(t *testing.T) {
+	privileges := schema.NewSet(schema.HashString, []interface{}{"drop user", "ACCESS SYSTEM TABLE", "Create User"})
+	expected := "ACCESS SYSTEM TABLE, CREATE USER, DROP USER"
+	if got := systemPrivilegesList(privileges); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestAccRedshiftRole_CaseSensitiveIdentifier(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_PASSWORD", t)
+	userName := generateRandomObjectName("tf_acc_case_sensitive_user")
+	password := "TfAcc1" + generateRandomObjectName("pw")
+	roleName := generateRandomObjectName("TF_Acc_Role")
+
+	// enable_case_sensitive_identifier is set for a dedicated user only, so that
+	// tests running in parallel keep their case insensitive sessions
+	provider := Provider()
+	provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	db, err := provider.Meta().(*Client).Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect to database: %s", err)
+	}
+	for _, query := range []string{
+		fmt.Sprintf("CREATE USER %s PASSWORD '%s' CREATEUSER", pq.QuoteIdentifier(userName), pqQuoteLiteral(password)),
+		fmt.Sprintf("ALTER USER %s SET enable_case_sensitive_identifier TO true", pq.QuoteIdentifier(userName)),
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("Unable to prepare the case sensitive user: %s", err)
+		}
+	}
+	defer func() {
+		if _, err := db.Exec(fmt.Sprintf("DROP USER %s", pq.QuoteIdentifier(userName))); err != nil {
+			t.Errorf("Unable to drop the case sensitive user: %s", err)
+		}
+	}()
+
+	config := fmt.Sprintf(`
+provider "redshift" {
+  username = %[1]q
+  password = %[2]q
+}
+
+resource "redshift_role" "role" {
+  name = %[3]q
+}
+`, userName, password, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role.role", "name", roleName),
+					resource.TestCheckResourceAttr("redshift_role.role", "id", roleName),
+					func(s *terraform.State) error {
+						var found string
+						if err := db.QueryRow("SELECT role_name FROM SVV_ROLES WHERE role_name = $1", roleName).Scan(&found); err != nil {
+							return fmt.Errorf("role %q with preserved case not found: %w", roleName, err)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
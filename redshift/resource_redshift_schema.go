@@ -19,7 +19,9 @@ const (
 	schemaOwnerAttr           = "owner"
 	schemaQuotaAttr           = "quota"
 	schemaCascadeOnDeleteAttr = "cascade_on_delete"
+	schemaSkipDropAttr        = "skip_drop"
 	schemaExternalSchemaAttr  = "external_schema"
+	schemaCommentAttr         = "comment"
 	dataCatalogAttr           = "external_schema.0.data_catalog_source.0"
 	hiveMetastoreAttr         = "external_schema.0.hive_metastore_source.0"
 	rdsPostgresAttr           = "external_schema.0.rds_postgres_source.0"
@@ -27,47 +29,54 @@ const (
 	redshiftAttr              = "external_schema.0.redshift_source.0"
 )
 
+// schemaIdentifierAttrs are the attributes holding names normalized with normalizeIdentifier.
+var schemaIdentifierAttrs = []string{schemaNameAttr, schemaOwnerAttr}
+
 func redshiftSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: `
 A database contains one or more named schemas. Each schema in a database contains tables and other kinds of named objects. By default, a database has a single schema, which is named PUBLIC. You can use schemas to group database objects under a common name. Schemas are similar to file system directories, except that schemas cannot be nested.
 `,
-		CreateContext: ResourceFunc(resourceRedshiftSchemaCreate),
-		ReadContext:   ResourceFunc(resourceRedshiftSchemaRead),
-		UpdateContext: ResourceFunc(resourceRedshiftSchemaUpdate),
-		DeleteContext: ResourceFunc(
-			ResourceRetryOnPQErrors(resourceRedshiftSchemaDelete),
+		CreateContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceNormalizeIdentifiers(schemaIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftSchemaCreate)),
+		),
+		ReadContext:   ResourceFuncInDatabase(resourceDatabase, ResourceNormalizeIdentifiers(schemaIdentifierAttrs, resourceRedshiftSchemaRead)),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase, ResourceNormalizeIdentifiers(schemaIdentifierAttrs, resourceRedshiftSchemaUpdate)),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceNormalizeIdentifiers(schemaIdentifierAttrs, ResourceRetryOnPQErrors(resourceRedshiftSchemaDelete)),
 		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateInDatabase,
 		},
 		CustomizeDiff: forceNewIfListSizeChanged(schemaExternalSchemaAttr),
 		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("schema"),
 			schemaNameAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Name of the schema. The schema name can't be `PUBLIC`.",
+				Description: "Name of the schema. The schema name can't be `PUBLIC`. Changing the name renames the schema in place, keeping its objects.",
 				ValidateFunc: validation.StringNotInSlice([]string{
 					"public",
 				}, true),
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			schemaOwnerAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Name of the schema owner. Defaults to the `default_schema_owner` of the provider, or the connected user.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			schemaCommentAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Computed:    true,
-				Description: "Name of the schema owner.",
-				StateFunc: func(val interface{}) string {
-					return val.(string)
-				},
+				Description: "A comment on the schema. Comments are only read back when this attribute is set, comments set outside of Terraform don't cause a diff otherwise. Setting it to an empty string removes the comment.",
 			},
 			schemaQuotaAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      0,
-				Description:  "The maximum amount of disk space that the specified schema can use. GB is the default unit of measurement.",
+				Description:  "The maximum amount of disk space in GB that the specified schema can use, `0` (the default) means unlimited. The quota is stored in the state in MB, as read back from the system views.",
 				ValidateFunc: validation.IntAtLeast(0),
 				StateFunc: func(val interface{}) string {
 					return fmt.Sprintf("%d", val.(int)*1024)
@@ -82,6 +91,16 @@ A database contains one or more named schemas. Each schema in a database contain
 				Description: "Indicates to automatically drop all objects in the schema. The default action is TO NOT drop a schema if it contains any objects.",
 				ConflictsWith: []string{
 					schemaExternalSchemaAttr,
+					schemaSkipDropAttr,
+				},
+			},
+			schemaSkipDropAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Keeps the schema when the resource is destroyed, only removing it from the state, e.g. for schemas owned by another team. An existing schema with the same name is adopted as is instead of being created, differences to the configuration show up in the next plan.",
+				ConflictsWith: []string{
+					schemaCascadeOnDeleteAttr,
 				},
 			},
 			schemaExternalSchemaAttr: {
@@ -119,20 +138,82 @@ A database contains one or more named schemas. Each schema in a database contain
 								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
 								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
 							},
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"region": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "If the external database is defined in an Athena data catalog or the AWS Glue Data Catalog, the AWS Region in which the database is located. This parameter is required if the database is defined in an external Data Catalog.",
-										ForceNew:    true,
-									},
-									"iam_role_arns": {
-										Type:     schema.TypeList,
-										Required: true,
-										MinItems: 1,
-										MaxItems: 10,
-										Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
+							Elem: externalSchemaDataCatalogSource(),
+						},
+						"hive_metastore_source": {
+							Type:        schema.TypeList,
+							Description: "Configures the external schema from a Hive Metastore.",
+							Optional:    true,
+							MaxItems:    1,
+							ConflictsWith: []string{
+								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
+							},
+							Elem: externalSchemaHiveMetastoreSource(),
+						},
+						"rds_postgres_source": {
+							Type:        schema.TypeList,
+							Description: "Configures the external schema to reference data using a federated query to RDS POSTGRES or Aurora PostgreSQL.",
+							Optional:    true,
+							MaxItems:    1,
+							ConflictsWith: []string{
+								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
+							},
+							Elem: externalSchemaRdsPostgresSource(),
+						},
+						"rds_mysql_source": {
+							Type:        schema.TypeList,
+							Description: "Configures the external schema to reference data using a federated query to RDS MYSQL or Aurora MySQL.",
+							Optional:    true,
+							MaxItems:    1,
+							ConflictsWith: []string{
+								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
+							},
+							Elem: externalSchemaRdsMysqlSource(),
+						},
+						"redshift_source": {
+							Type:        schema.TypeList,
+							Description: "Configures the external schema to reference datashare database.",
+							Optional:    true,
+							MaxItems:    1,
+							ConflictsWith: []string{
+								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
+								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
+							},
+							Elem: externalSchemaRedshiftSource(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func externalSchemaDataCatalogSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If the external database is defined in an Athena data catalog or the AWS Glue Data Catalog, the AWS Region in which the database is located. This parameter is required if the database is defined in an external Data Catalog.",
+				ForceNew:    true,
+			},
+			"iam_role_arns": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 10,
+				Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
 	As a minimum, the IAM roles must have permission to perform a LIST operation on the Amazon S3 bucket to be accessed and a GET operation on the Amazon S3 objects the bucket contains.
   If the external database is defined in an Amazon Athena data catalog or the AWS Glue Data Catalog, the IAM role must have permission to access Athena unless catalog_role is specified.
   For more information, see https://docs.aws.amazon.com/redshift/latest/dg/c-spectrum-iam-policies.html.
@@ -145,82 +226,73 @@ A database contains one or more named schemas. Each schema in a database contain
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"catalog_role_arns": {
-										Type:     schema.TypeList,
-										Optional: true,
-										MinItems: 1,
-										MaxItems: 10,
-										Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization for the data catalog.
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"catalog_role_arns": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MinItems: 1,
+				MaxItems: 10,
+				Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization for the data catalog.
 	If this is not specified, Amazon Redshift uses the specified iam_role_arns. The catalog role must have permission to access the Data Catalog in AWS Glue or Athena.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/dg/c-spectrum-iam-policies.html.
 
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"create_external_database_if_not_exists": {
-										Type:     schema.TypeBool,
-										Optional: true,
-										Default:  false,
-										DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-											// If the old value is empty, and the new value is not, it means we are creating the resource.
-											// This must trigger diff in order to save proper value in state.
-											if old == "" && new != "" {
-												return false
-											}
-											return true
-										},
-										Description: `When enabled, creates an external database with the name specified by the database argument,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"create_external_database_if_not_exists": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// If the old value is empty, and the new value is not, it means we are creating the resource.
+					// This must trigger diff in order to save proper value in state.
+					if old == "" && new != "" {
+						return false
+					}
+					return true
+				},
+				Description: `When enabled, creates an external database with the name specified by the database argument,
 	if the specified external database doesn't exist. If the specified external database exists, the command makes no changes.
 	In this case, the command returns a message that the external database exists, rather than terminating with an error.
 
   To use create_external_database_if_not_exists with a Data Catalog enabled for AWS Lake Formation, you need CREATE_DATABASE permission on the Data Catalog.`,
-									},
-								},
-							},
-						},
-						"hive_metastore_source": {
-							Type:        schema.TypeList,
-							Description: "Configures the external schema from a Hive Metastore.",
-							Optional:    true,
-							MaxItems:    1,
-							ConflictsWith: []string{
-								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
-							},
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"hostname": {
-										Type:        schema.TypeString,
-										Description: "The hostname of the hive metastore database.",
-										Required:    true,
-										ForceNew:    true,
-									},
-									"port": {
-										Type:         schema.TypeInt,
-										Description:  "The port number of the hive metastore. The default port number is 9083.",
-										Optional:     true,
-										Default:      9083,
-										ValidateFunc: validation.IntBetween(1, 65535),
-										ForceNew:     true,
-									},
-									"iam_role_arns": {
-										Type:     schema.TypeList,
-										Required: true,
-										MinItems: 1,
-										MaxItems: 10,
-										Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
+			},
+		},
+	}
+}
+
+func externalSchemaHiveMetastoreSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname of the hive metastore database.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "The port number of the hive metastore. The default port number is 9083.",
+				Optional:     true,
+				Default:      9083,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				ForceNew:     true,
+			},
+			"iam_role_arns": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 10,
+				Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
 	As a minimum, the IAM roles must have permission to perform a LIST operation on the Amazon S3 bucket to be accessed and a GET operation on the Amazon S3 objects the bucket contains.
 	If the external database is defined in an Amazon Athena data catalog or the AWS Glue Data Catalog, the IAM role must have permission to access Athena unless catalog_role is specified.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/dg/c-spectrum-iam-policies.html.
@@ -233,54 +305,45 @@ A database contains one or more named schemas. Each schema in a database contain
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-								},
-							},
-						},
-						"rds_postgres_source": {
-							Type:        schema.TypeList,
-							Description: "Configures the external schema to reference data using a federated query to RDS POSTGRES or Aurora PostgreSQL.",
-							Optional:    true,
-							MaxItems:    1,
-							ConflictsWith: []string{
-								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
-							},
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"hostname": {
-										Type:        schema.TypeString,
-										Description: "The hostname of the head node of the PostgreSQL database replica set.",
-										Required:    true,
-										ForceNew:    true,
-									},
-									"port": {
-										Type:         schema.TypeInt,
-										Description:  "The port number of the PostgreSQL database. The default port number is 5432.",
-										Optional:     true,
-										Default:      5432,
-										ValidateFunc: validation.IntBetween(1, 65535),
-										ForceNew:     true,
-									},
-									"schema": {
-										Type:        schema.TypeString,
-										Description: "The name of the PostgreSQL schema. The default schema is 'public'",
-										Optional:    true,
-										Default:     "public",
-										ForceNew:    true,
-									},
-									"iam_role_arns": {
-										Type:     schema.TypeList,
-										Required: true,
-										MinItems: 1,
-										MaxItems: 10,
-										Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func externalSchemaRdsPostgresSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname of the head node of the PostgreSQL database replica set.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "The port number of the PostgreSQL database. The default port number is 5432.",
+				Optional:     true,
+				Default:      5432,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				ForceNew:     true,
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Description: "The name of the PostgreSQL schema. The default schema is 'public'",
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+			},
+			"iam_role_arns": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 10,
+				Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
 	As a minimum, the IAM roles must have permission to perform a LIST operation on the Amazon S3 bucket to be accessed and a GET operation on the Amazon S3 objects the bucket contains.
 	If the external database is defined in an Amazon Athena data catalog or the AWS Glue Data Catalog, the IAM role must have permission to access Athena unless catalog_role is specified.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/dg/c-spectrum-iam-policies.html.
@@ -293,55 +356,46 @@ A database contains one or more named schemas. Each schema in a database contain
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"secret_arn": {
-										Type: schema.TypeString,
-										Description: `The Amazon Resource Name (ARN) of a supported PostgreSQL database engine secret created using AWS Secrets Manager.
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secret_arn": {
+				Type: schema.TypeString,
+				Description: `The Amazon Resource Name (ARN) of a supported PostgreSQL database engine secret created using AWS Secrets Manager.
 	For information about how to create and retrieve an ARN for a secret, see https://docs.aws.amazon.com/secretsmanager/latest/userguide/manage_create-basic-secret.html
 	and https://docs.aws.amazon.com/secretsmanager/latest/userguide/manage_retrieve-secret.html in the AWS Secrets Manager User Guide.`,
-										Required: true,
-										ForceNew: true,
-									},
-								},
-							},
-						},
-						"rds_mysql_source": {
-							Type:        schema.TypeList,
-							Description: "Configures the external schema to reference data using a federated query to RDS MYSQL or Aurora MySQL.",
-							Optional:    true,
-							MaxItems:    1,
-							ConflictsWith: []string{
-								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.redshift_source", schemaExternalSchemaAttr),
-							},
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"hostname": {
-										Type:        schema.TypeString,
-										Description: "The hostname of the head node of the MySQL database replica set.",
-										Required:    true,
-										ForceNew:    true,
-									},
-									"port": {
-										Type:         schema.TypeInt,
-										Description:  "The port number of the MySQL database. The default port number is 3306.",
-										Optional:     true,
-										Default:      3306,
-										ValidateFunc: validation.IntBetween(1, 65535),
-										ForceNew:     true,
-									},
-									"iam_role_arns": {
-										Type:     schema.TypeList,
-										Required: true,
-										MinItems: 1,
-										MaxItems: 10,
-										Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func externalSchemaRdsMysqlSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname of the head node of the MySQL database replica set.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "The port number of the MySQL database. The default port number is 3306.",
+				Optional:     true,
+				Default:      3306,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				ForceNew:     true,
+			},
+			"iam_role_arns": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 10,
+				Description: `The Amazon Resource Name (ARN) for the IAM roles that your cluster uses for authentication and authorization.
 	As a minimum, the IAM roles must have permission to perform a LIST operation on the Amazon S3 bucket to be accessed and a GET operation on the Amazon S3 objects the bucket contains.
 	If the external database is defined in an Amazon Athena data catalog or the AWS Glue Data Catalog, the IAM role must have permission to access Athena unless catalog_role is specified.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/dg/c-spectrum-iam-policies.html.
@@ -354,47 +408,32 @@ A database contains one or more named schemas. Each schema in a database contain
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"secret_arn": {
-										Type: schema.TypeString,
-										Description: `The Amazon Resource Name (ARN) of a supported MySQL database engine secret created using AWS Secrets Manager.
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secret_arn": {
+				Type: schema.TypeString,
+				Description: `The Amazon Resource Name (ARN) of a supported MySQL database engine secret created using AWS Secrets Manager.
 	For information about how to create and retrieve an ARN for a secret, see https://docs.aws.amazon.com/secretsmanager/latest/userguide/manage_create-basic-secret.html
 	and https://docs.aws.amazon.com/secretsmanager/latest/userguide/manage_retrieve-secret.html in the AWS Secrets Manager User Guide.`,
-										Required: true,
-										ForceNew: true,
-									},
-								},
-							},
-						},
-						"redshift_source": {
-							Type:        schema.TypeList,
-							Description: "Configures the external schema to reference datashare database.",
-							Optional:    true,
-							MaxItems:    1,
-							ConflictsWith: []string{
-								fmt.Sprintf("%s.0.data_catalog_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.hive_metastore_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_postgres_source", schemaExternalSchemaAttr),
-								fmt.Sprintf("%s.0.rds_mysql_source", schemaExternalSchemaAttr),
-							},
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"schema": {
-										Type:        schema.TypeString,
-										Description: "The name of the datashare schema. The default schema is 'public'.",
-										Optional:    true,
-										Default:     "public",
-										ForceNew:    true,
-									},
-								},
-							},
-						},
-					},
-				},
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func externalSchemaRedshiftSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"schema": {
+				Type:        schema.TypeString,
+				Description: "The name of the datashare schema. The default schema is 'public'.",
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
 			},
 		},
 	}
@@ -408,22 +447,33 @@ func resourceRedshiftSchemaReadImpl(db *DBConnection, d *schema.ResourceData) er
 	var schemaOwner, schemaName, schemaType string
 
 	// Step 1: get basic schema info
+	// The owner is read from pg_namespace, so that owners changed outside of Terraform show up as a diff
 	err := db.QueryRow(`
 			SELECT
 				TRIM(svv_all_schemas.schema_name),
-				TRIM(pg_user_info.usename),
+				TRIM(COALESCE(pg_user.usename, '')),
 				TRIM(svv_all_schemas.schema_type)
 			FROM svv_all_schemas
 			INNER JOIN pg_namespace ON (svv_all_schemas.database_name = $1 AND svv_all_schemas.schema_name = pg_namespace.nspname)
-	LEFT JOIN pg_user_info
-		ON (svv_all_schemas.database_name = $1 AND pg_user_info.usesysid = svv_all_schemas.schema_owner)
+	LEFT JOIN pg_user
+		ON pg_user.usesysid = pg_namespace.nspowner
 	WHERE svv_all_schemas.database_name = $1
 	AND pg_namespace.oid = $2`, db.client.config.Database, d.Id()).Scan(&schemaName, &schemaOwner, &schemaType)
-	if err != nil {
-		return err
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[WARN] Redshift Schema (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("error reading schema: %w", err)
 	}
 	d.Set(schemaNameAttr, schemaName)
 	d.Set(schemaOwnerAttr, schemaOwner)
+
+	if err := readComment(db, d, schemaCommentAttr, "pg_namespace"); err != nil {
+		return err
+	}
+
 	switch schemaType {
 	case "local":
 		return resourceRedshiftSchemaReadLocal(db, d)
@@ -466,6 +516,25 @@ func resourceRedshiftSchemaReadLocal(db *DBConnection, d *schema.ResourceData) e
 }
 
 func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData) error {
+	sourceDbName, sourceType, sourceConfiguration, err := readExternalSchemaSource(db, d.Id())
+	if err != nil {
+		return err
+	}
+	externalSchemaConfiguration := map[string]interface{}{
+		"database_name": sourceDbName,
+		sourceType:      []map[string]interface{}{sourceConfiguration},
+	}
+
+	d.Set(schemaQuotaAttr, 0)
+	d.Set(schemaExternalSchemaAttr, []map[string]interface{}{externalSchemaConfiguration})
+
+	return nil
+}
+
+// readExternalSchemaSource reads the source database name, the source type and the
+// source configuration of the external schema with the given OID from svv_external_schemas.
+// The source type is the name of the matching source block, e.g. data_catalog_source.
+func readExternalSchemaSource(db *DBConnection, schemaOID string) (string, string, map[string]interface{}, error) {
 	var sourceType, sourceDbName, iamRole, catalogRole, region, sourceSchema, hostName, port, secretArn string
 	err := db.QueryRow(`
 	SELECT
@@ -488,44 +557,42 @@ func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData
 	FROM
 	  svv_external_schemas
 	WHERE
-	  esoid = $1`, d.Id()).Scan(&sourceType, &sourceDbName, &iamRole, &catalogRole, &region, &sourceSchema, &hostName, &port, &secretArn)
+	  esoid = $1`, schemaOID).Scan(&sourceType, &sourceDbName, &iamRole, &catalogRole, &region, &sourceSchema, &hostName, &port, &secretArn)
 
 	if err != nil {
-		return err
+		return "", "", nil, err
 	}
-	externalSchemaConfiguration := make(map[string]interface{})
 	sourceConfiguration := make(map[string]interface{})
-	externalSchemaConfiguration["database_name"] = &sourceDbName
 	switch sourceType {
 	case "data_catalog_source":
 		sourceConfiguration["region"] = &region
 		sourceConfiguration["iam_role_arns"], err = splitCsvAndTrim(iamRole)
 		if err != nil {
-			return fmt.Errorf("error parsing iam_role_arns: %w", err)
+			return "", "", nil, fmt.Errorf("error parsing iam_role_arns: %w", err)
 		}
 		sourceConfiguration["catalog_role_arns"], err = splitCsvAndTrim(catalogRole)
 		if err != nil {
-			return fmt.Errorf("error parsing catalog_role_arns: %w", err)
+			return "", "", nil, fmt.Errorf("error parsing catalog_role_arns: %w", err)
 		}
 	case "hive_metastore_source":
 		sourceConfiguration["hostname"] = &hostName
 		if port != "" {
 			portNum, err := strconv.Atoi(port)
 			if err != nil {
-				return fmt.Errorf("hive_metastore_source port was not an integer")
+				return "", "", nil, fmt.Errorf("hive_metastore_source port was not an integer")
 			}
 			sourceConfiguration["port"] = &portNum
 		}
 		sourceConfiguration["iam_role_arns"], err = splitCsvAndTrim(iamRole)
 		if err != nil {
-			return fmt.Errorf("error parsing iam_role_arns: %w", err)
+			return "", "", nil, fmt.Errorf("error parsing iam_role_arns: %w", err)
 		}
 	case "rds_postgres_source":
 		sourceConfiguration["hostname"] = &hostName
 		if port != "" {
 			portNum, err := strconv.Atoi(port)
 			if err != nil {
-				return fmt.Errorf("rds_postgres_source port was not an integer")
+				return "", "", nil, fmt.Errorf("rds_postgres_source port was not an integer")
 			}
 			sourceConfiguration["port"] = &portNum
 		}
@@ -534,7 +601,7 @@ func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData
 		}
 		sourceConfiguration["iam_role_arns"], err = splitCsvAndTrim(iamRole)
 		if err != nil {
-			return fmt.Errorf("error parsing iam_role_arns: %w", err)
+			return "", "", nil, fmt.Errorf("error parsing iam_role_arns: %w", err)
 		}
 		sourceConfiguration["secret_arn"] = &secretArn
 	case "rds_mysql_source":
@@ -542,13 +609,13 @@ func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData
 		if port != "" {
 			portNum, err := strconv.Atoi(port)
 			if err != nil {
-				return fmt.Errorf("rds_mysql_source port was not an integer")
+				return "", "", nil, fmt.Errorf("rds_mysql_source port was not an integer")
 			}
 			sourceConfiguration["port"] = &portNum
 		}
 		sourceConfiguration["iam_role_arns"], err = splitCsvAndTrim(iamRole)
 		if err != nil {
-			return fmt.Errorf("error parsing iam_role_arns: %w", err)
+			return "", "", nil, fmt.Errorf("error parsing iam_role_arns: %w", err)
 		}
 		sourceConfiguration["secret_arn"] = &secretArn
 	case "redshift_source":
@@ -556,18 +623,19 @@ func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData
 			sourceConfiguration["schema"] = &sourceSchema
 		}
 	default:
-		return fmt.Errorf(`unsupported source database type: %q`, sourceType)
+		return "", "", nil, fmt.Errorf(`unsupported source database type: %q`, sourceType)
 	}
-	externalSchemaConfiguration[sourceType] = []map[string]interface{}{sourceConfiguration}
 
-	d.Set(schemaQuotaAttr, 0)
-	d.Set(schemaExternalSchemaAttr, []map[string]interface{}{externalSchemaConfiguration})
-
-	return nil
+	return sourceDbName, sourceType, sourceConfiguration, nil
 }
 
 func resourceRedshiftSchemaDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	if d.Get(schemaSkipDropAttr).(bool) {
+		log.Printf("[INFO] Keeping schema %s as %s is set, only removing it from the state", d.Get(schemaNameAttr).(string), schemaSkipDropAttr)
+		return nil
+	}
+
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -588,42 +656,75 @@ func resourceRedshiftSchemaDelete(db *DBConnection, d *schema.ResourceData) erro
 }
 
 func resourceRedshiftSchemaCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	// Schemas which are not dropped on destroy are adopted when they exist already
+	if d.Get(schemaSkipDropAttr).(bool) {
+		schemaName := d.Get(schemaNameAttr).(string)
+		var schemaOID string
+		err := db.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", normalizeIdentifier(db, schemaName)).Scan(&schemaOID)
+		switch {
+		case err == nil:
+			log.Printf("[INFO] Adopting existing schema %s", schemaName)
+			d.SetId(schemaOID)
+			return resourceRedshiftSchemaReadImpl(db, d)
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("could not check whether schema %q exists: %w", schemaName, err)
+		}
+	}
+
+	if err := checkPrivilege(db, "database", db.client.config.Database, "create"); err != nil {
+		return err
+	}
+
+	tx, err := startCreateTransaction(db)
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
+	owner := schemaOwnerOrDefault(db, d)
 	if _, isExternal := d.GetOk(fmt.Sprintf("%s.0.%s", schemaExternalSchemaAttr, "database_name")); isExternal {
-		err = resourceRedshiftSchemaCreateExternal(tx, d)
+		err = resourceRedshiftSchemaCreateExternal(tx, d, owner)
 	} else {
-		err = resourceRedshiftSchemaCreateInternal(tx, d)
+		err = resourceRedshiftSchemaCreateInternal(tx, d, owner)
 	}
 	if err != nil {
 		return err
 	}
 
+	if err := setComment(tx, d, schemaCommentAttr, "SCHEMA", d.Get(schemaNameAttr).(string)); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	if err := waitUntilVisible(db, fmt.Sprintf("schema %q", d.Get(schemaNameAttr).(string)), "SELECT EXISTS(SELECT 1 FROM pg_namespace WHERE oid = $1)", d.Id()); err != nil {
+		return err
+	}
+
 	return resourceRedshiftSchemaReadImpl(db, d)
 }
 
-func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData) error {
+// schemaOwnerOrDefault returns the configured owner of the schema, falling back to
+// the default_schema_owner of the provider. An empty owner means the connected user.
+func schemaOwnerOrDefault(db *DBConnection, d *schema.ResourceData) string {
+	if v, ok := d.GetOk(schemaOwnerAttr); ok {
+		return v.(string)
+	}
+	return db.client.config.DefaultSchemaOwner
+}
+
+func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData, owner string) error {
 	schemaName := d.Get(schemaNameAttr).(string)
 	schemaQuota := d.Get(schemaQuotaAttr).(int)
 	var createOpts []string
 
-	if v, ok := d.GetOk(schemaOwnerAttr); ok {
-		createOpts = append(createOpts, fmt.Sprintf("AUTHORIZATION %s", pq.QuoteIdentifier(v.(string))))
+	if owner != "" {
+		createOpts = append(createOpts, fmt.Sprintf("AUTHORIZATION %s", pq.QuoteIdentifier(owner)))
 	}
 
-	quotaValue := "QUOTA UNLIMITED"
-	if schemaQuota > 0 {
-		quotaValue = fmt.Sprintf("QUOTA %d GB", schemaQuota)
-	}
-	createOpts = append(createOpts, quotaValue)
+	createOpts = append(createOpts, schemaQuotaClause(schemaQuota))
 
 	query := fmt.Sprintf("CREATE SCHEMA %s %s", pq.QuoteIdentifier(schemaName), strings.Join(createOpts, " "))
 
@@ -632,7 +733,7 @@ func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData) er
 	}
 
 	var schemaOID string
-	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", strings.ToLower(schemaName)).Scan(&schemaOID); err != nil {
+	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", schemaName).Scan(&schemaOID); err != nil {
 		return err
 	}
 
@@ -641,26 +742,26 @@ func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData) er
 	return nil
 }
 
-func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) error {
+func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData, owner string) error {
 	schemaName := d.Get(schemaNameAttr).(string)
 	query := fmt.Sprintf("CREATE EXTERNAL SCHEMA %s", pq.QuoteIdentifier(schemaName))
 	sourceDbName := d.Get(fmt.Sprintf("%s.0.%s", schemaExternalSchemaAttr, "database_name")).(string)
 	var configQuery string
 	if _, isDataCatalog := d.GetOk(dataCatalogAttr); isDataCatalog {
 		// data catalog source
-		configQuery = getDataCatalogConfigQueryPart(d, sourceDbName)
+		configQuery = getDataCatalogConfigQueryPart(d, dataCatalogAttr, sourceDbName)
 	} else if _, isHiveMetastore := d.GetOk(hiveMetastoreAttr); isHiveMetastore {
 		// hive metastore source
-		configQuery = getHiveMetastoreConfigQueryPart(d, sourceDbName)
+		configQuery = getHiveMetastoreConfigQueryPart(d, hiveMetastoreAttr, sourceDbName)
 	} else if _, isRdsPostgres := d.GetOk(rdsPostgresAttr); isRdsPostgres {
 		// rds postgres source
-		configQuery = getRdsPostgresConfigQueryPart(d, sourceDbName)
+		configQuery = getRdsPostgresConfigQueryPart(d, rdsPostgresAttr, sourceDbName)
 	} else if _, isRdsMysql := d.GetOk(rdsMysqlAttr); isRdsMysql {
 		// rds mysql source
-		configQuery = getRdsMysqlConfigQueryPart(d, sourceDbName)
+		configQuery = getRdsMysqlConfigQueryPart(d, rdsMysqlAttr, sourceDbName)
 	} else if _, isRedshift := d.GetOk(redshiftAttr); isRedshift {
 		// redshift source
-		configQuery = getRedshiftConfigQueryPart(d, sourceDbName)
+		configQuery = getRedshiftConfigQueryPart(d, redshiftAttr, sourceDbName)
 	} else {
 		return fmt.Errorf("can't create external schema: no source configuration found")
 	}
@@ -672,8 +773,8 @@ func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) er
 		return err
 	}
 
-	if v, ok := d.GetOk(schemaOwnerAttr); ok {
-		query = fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(v.(string)))
+	if owner != "" {
+		query = fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(owner))
 		log.Printf("[DEBUG] setting schema owner: %s\n", query)
 		if _, err := tx.Exec(query); err != nil {
 			return err
@@ -681,7 +782,7 @@ func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) er
 	}
 
 	var schemaOID string
-	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", strings.ToLower(schemaName)).Scan(&schemaOID); err != nil {
+	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", schemaName).Scan(&schemaOID); err != nil {
 		return err
 	}
 
@@ -690,18 +791,18 @@ func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) er
 	return nil
 }
 
-func getDataCatalogConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getDataCatalogConfigQueryPart(d *schema.ResourceData, sourceAttr, sourceDbName string) string {
 	query := fmt.Sprintf("FROM DATA CATALOG DATABASE '%s'", pqQuoteLiteral(sourceDbName))
-	if region, hasRegion := d.GetOk(fmt.Sprintf("%s.%s", dataCatalogAttr, "region")); hasRegion {
+	if region, hasRegion := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "region")); hasRegion {
 		query = fmt.Sprintf("%s REGION '%s'", query, pqQuoteLiteral(region.(string)))
 	}
-	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", dataCatalogAttr, "iam_role_arns")).([]interface{})
+	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "iam_role_arns")).([]interface{})
 	var iamRoleArns []string
 	for _, arn := range iamRoleArnsRaw {
 		iamRoleArns = append(iamRoleArns, arn.(string))
 	}
 	query = fmt.Sprintf("%s IAM_ROLE '%s'", query, pqQuoteLiteral(strings.Join(iamRoleArns, ",")))
-	if catalogRoleArnsRaw, hasCatalogRoleArns := d.GetOk(fmt.Sprintf("%s.%s", dataCatalogAttr, "catalog_role_arns")); hasCatalogRoleArns {
+	if catalogRoleArnsRaw, hasCatalogRoleArns := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "catalog_role_arns")); hasCatalogRoleArns {
 		var catalogRoleArns []string
 		for _, arn := range catalogRoleArnsRaw.([]interface{}) {
 			catalogRoleArns = append(catalogRoleArns, arn.(string))
@@ -710,20 +811,20 @@ func getDataCatalogConfigQueryPart(d *schema.ResourceData, sourceDbName string)
 			query = fmt.Sprintf("%s CATALOG_ROLE '%s'", query, pqQuoteLiteral(strings.Join(catalogRoleArns, ",")))
 		}
 	}
-	if d.Get(fmt.Sprintf("%s.%s", dataCatalogAttr, "create_external_database_if_not_exists")).(bool) {
+	if d.Get(fmt.Sprintf("%s.%s", sourceAttr, "create_external_database_if_not_exists")).(bool) {
 		query = fmt.Sprintf("%s CREATE EXTERNAL DATABASE IF NOT EXISTS", query)
 	}
 	return query
 }
 
-func getHiveMetastoreConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getHiveMetastoreConfigQueryPart(d *schema.ResourceData, sourceAttr, sourceDbName string) string {
 	query := fmt.Sprintf("FROM HIVE METASTORE DATABASE '%s'", pqQuoteLiteral(sourceDbName))
-	hostName := d.Get(fmt.Sprintf("%s.%s", hiveMetastoreAttr, "hostname")).(string)
+	hostName := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "hostname")).(string)
 	query = fmt.Sprintf("%s URI '%s'", query, pqQuoteLiteral(hostName))
-	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", hiveMetastoreAttr, "port")); portIsSet {
+	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "port")); portIsSet {
 		query = fmt.Sprintf("%s PORT %d", query, port.(int))
 	}
-	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", hiveMetastoreAttr, "iam_role_arns")).([]interface{})
+	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "iam_role_arns")).([]interface{})
 	var iamRoleArns []string
 	for _, arn := range iamRoleArnsRaw {
 		iamRoleArns = append(iamRoleArns, arn.(string))
@@ -732,55 +833,55 @@ func getHiveMetastoreConfigQueryPart(d *schema.ResourceData, sourceDbName string
 	return query
 }
 
-func getRdsPostgresConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRdsPostgresConfigQueryPart(d *schema.ResourceData, sourceAttr, sourceDbName string) string {
 	query := fmt.Sprintf("FROM POSTGRES DATABASE '%s'", pqQuoteLiteral(sourceDbName))
-	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", rdsPostgresAttr, "schema")); sourceSchemaIsSet {
+	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "schema")); sourceSchemaIsSet {
 		query = fmt.Sprintf("%s SCHEMA '%s'", query, pqQuoteLiteral(sourceSchema.(string)))
 	}
-	hostName := d.Get(fmt.Sprintf("%s.%s", rdsPostgresAttr, "hostname")).(string)
+	hostName := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "hostname")).(string)
 	query = fmt.Sprintf("%s URI '%s'", query, pqQuoteLiteral(hostName))
-	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", rdsPostgresAttr, "port")); portIsSet {
+	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "port")); portIsSet {
 		query = fmt.Sprintf("%s PORT %d", query, port.(int))
 	}
-	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", rdsPostgresAttr, "iam_role_arns")).([]interface{})
+	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "iam_role_arns")).([]interface{})
 	var iamRoleArns []string
 	for _, arn := range iamRoleArnsRaw {
 		iamRoleArns = append(iamRoleArns, arn.(string))
 	}
 	query = fmt.Sprintf("%s IAM_ROLE '%s'", query, pqQuoteLiteral(strings.Join(iamRoleArns, ",")))
-	secretArn := d.Get(fmt.Sprintf("%s.%s", rdsPostgresAttr, "secret_arn")).(string)
+	secretArn := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "secret_arn")).(string)
 	query = fmt.Sprintf("%s SECRET_ARN '%s'", query, pqQuoteLiteral(secretArn))
 	return query
 }
 
-func getRdsMysqlConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRdsMysqlConfigQueryPart(d *schema.ResourceData, sourceAttr, sourceDbName string) string {
 	query := fmt.Sprintf("FROM MYSQL DATABASE '%s'", pqQuoteLiteral(sourceDbName))
-	hostName := d.Get(fmt.Sprintf("%s.%s", rdsMysqlAttr, "hostname")).(string)
+	hostName := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "hostname")).(string)
 	query = fmt.Sprintf("%s URI '%s'", query, pqQuoteLiteral(hostName))
-	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", rdsMysqlAttr, "port")); portIsSet {
+	if port, portIsSet := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "port")); portIsSet {
 		query = fmt.Sprintf("%s PORT %d", query, port.(int))
 	}
-	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", rdsMysqlAttr, "iam_role_arns")).([]interface{})
+	iamRoleArnsRaw := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "iam_role_arns")).([]interface{})
 	var iamRoleArns []string
 	for _, arn := range iamRoleArnsRaw {
 		iamRoleArns = append(iamRoleArns, arn.(string))
 	}
 	query = fmt.Sprintf("%s IAM_ROLE '%s'", query, pqQuoteLiteral(strings.Join(iamRoleArns, ",")))
-	secretArn := d.Get(fmt.Sprintf("%s.%s", rdsMysqlAttr, "secret_arn")).(string)
+	secretArn := d.Get(fmt.Sprintf("%s.%s", sourceAttr, "secret_arn")).(string)
 	query = fmt.Sprintf("%s SECRET_ARN '%s'", query, pqQuoteLiteral(secretArn))
 	return query
 }
 
-func getRedshiftConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRedshiftConfigQueryPart(d *schema.ResourceData, sourceAttr, sourceDbName string) string {
 	query := fmt.Sprintf("FROM REDSHIFT DATABASE '%s'", pqQuoteLiteral(sourceDbName))
-	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", redshiftAttr, "schema")); sourceSchemaIsSet {
+	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", sourceAttr, "schema")); sourceSchemaIsSet {
 		query = fmt.Sprintf("%s SCHEMA '%s'", query, pqQuoteLiteral(sourceSchema.(string)))
 	}
 	return query
 }
 
 func resourceRedshiftSchemaUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -798,6 +899,10 @@ func resourceRedshiftSchemaUpdate(db *DBConnection, d *schema.ResourceData) erro
 		return err
 	}
 
+	if err := setComment(tx, d, schemaCommentAttr, "SCHEMA", d.Get(schemaNameAttr).(string)); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -834,8 +939,12 @@ func setSchemaOwner(tx *sql.Tx, _ *DBConnection, d *schema.ResourceData) error {
 	schemaName := d.Get(schemaNameAttr).(string)
 	schemaOwner := d.Get(schemaOwnerAttr).(string)
 
-	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(schemaOwner)))
-	return err
+	query := fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(schemaOwner))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error updating schema owner: %w", err)
+	}
+	return nil
 }
 
 func setSchemaQuota(tx *sql.Tx, d *schema.ResourceData) error {
@@ -846,11 +955,14 @@ func setSchemaQuota(tx *sql.Tx, d *schema.ResourceData) error {
 	schemaName := d.Get(schemaNameAttr).(string)
 	schemaQuota := d.Get(schemaQuotaAttr).(int)
 
-	quotaValue := "UNLIMITED"
-	if schemaQuota > 0 {
-		quotaValue = fmt.Sprintf("%d GB", schemaQuota)
-	}
-
-	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s QUOTA %s", pq.QuoteIdentifier(schemaName), quotaValue))
+	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s %s", pq.QuoteIdentifier(schemaName), schemaQuotaClause(schemaQuota)))
 	return err
 }
+
+// schemaQuotaClause returns the QUOTA clause for a quota in GB, 0 means unlimited.
+func schemaQuotaClause(quota int) string {
+	if quota > 0 {
+		return fmt.Sprintf("QUOTA %d GB", quota)
+	}
+	return "QUOTA UNLIMITED"
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftSchema_Basic(t *testing.T) {
@@ -637,3 +640,449 @@ resource "redshift_user" "schema_test_user1" {
   name = "schema_test_user1"
 }
 `
+
+func TestAccRedshiftSchema_Comment(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_schema_comment")
+
+	config := func(comment string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name    = %[1]q
+  comment = %[2]q
+}
+`, name, comment)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Managed by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "comment", "Managed by Terraform"),
+					testAccCheckRedshiftComment("redshift_schema.schema", "pg_namespace", "Managed by Terraform"),
+				),
+			},
+			{
+				Config: config("It's updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "comment", "It's updated"),
+					testAccCheckRedshiftComment("redshift_schema.schema", "pg_namespace", "It's updated"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "comment", ""),
+					testAccCheckRedshiftComment("redshift_schema.schema", "pg_namespace", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_DefaultOwner(t *testing.T) {
+	defaultOwner := generateRandomObjectName("tf_acc_schema_default_owner")
+	owner := generateRandomObjectName("tf_acc_schema_owner")
+	defaultSchemaName := generateRandomObjectName("tf_acc_schema_default_owner")
+	ownedSchemaName := generateRandomObjectName("tf_acc_schema_owner")
+
+	users := fmt.Sprintf(`
+resource "redshift_user" "default_owner" {
+  name = %[1]q
+}
+
+resource "redshift_user" "owner" {
+  name = %[2]q
+}
+`, defaultOwner, owner)
+
+	// The users must exist before the provider is configured with the default owner
+	config := users + fmt.Sprintf(`
+provider "redshift" {
+  default_schema_owner = %[1]q
+}
+
+resource "redshift_schema" "default_owner" {
+  name = %[3]q
+
+  depends_on = [redshift_user.default_owner]
+}
+
+resource "redshift_schema" "owner" {
+  name  = %[4]q
+  owner = redshift_user.owner.name
+}
+`, defaultOwner, owner, defaultSchemaName, ownedSchemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: users,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(defaultSchemaName),
+					resource.TestCheckResourceAttr("redshift_schema.default_owner", "owner", defaultOwner),
+					resource.TestCheckResourceAttr("redshift_schema.owner", "owner", owner),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestSchemaOwnerOrDefault(t *testing.T) {
+	tests := map[string]struct {
+		owner        string
+		defaultOwner string
+		expected     string
+	}{
+		"no owner": {
+			expected: "",
+		},
+		"default owner": {
+			defaultOwner: "admin",
+			expected:     "admin",
+		},
+		"owner overrides default": {
+			owner:        "john",
+			defaultOwner: "admin",
+			expected:     "john",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				schemaNameAttr: "my_schema",
+			}
+			if tt.owner != "" {
+				raw[schemaOwnerAttr] = tt.owner
+			}
+			d := schema.TestResourceDataRaw(t, redshiftSchema().Schema, raw)
+			db := &DBConnection{client: &Client{config: Config{DefaultSchemaOwner: tt.defaultOwner}}}
+			if got := schemaOwnerOrDefault(db, d); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchemaQuotaClause(t *testing.T) {
+	for quota, expected := range map[int]string{
+		0:  "QUOTA UNLIMITED",
+		15: "QUOTA 15 GB",
+	} {
+		if got := schemaQuotaClause(quota); got != expected {
+			t.Errorf("Expected %q for quota %d but got %q", expected, quota, got)
+		}
+	}
+}
+
+func TestAccRedshiftSchema_DroppedOutOfBand(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_schema_dropped")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+`, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftSchemaExists(schemaName),
+			},
+			{
+				// A schema dropped outside of terraform is created again
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("DROP SCHEMA %s", pq.QuoteIdentifier(schemaName))); err != nil {
+						t.Fatalf("could not drop schema: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:  "redshift_schema.schema",
+				ImportState:   true,
+				ImportStateId: "0",
+				ExpectError:   regexp.MustCompile("Cannot import non-existent remote object"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_InDatabase(t *testing.T) {
+	dbName := generateRandomObjectName("tf_acc_schema_database")
+	schemaName := generateRandomObjectName("tf_acc_schema_in_database")
+	userName := generateRandomObjectName("tf_acc_schema_in_database_user")
+
+	config := fmt.Sprintf(`
+resource "redshift_database" "db" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[3]q
+}
+
+resource "redshift_schema" "schema" {
+  database = redshift_database.db.name
+  name     = %[2]q
+}
+
+resource "redshift_grant" "usage" {
+  database    = redshift_database.db.name
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+`, dbName, schemaName, userName)
+
+	checkSchemaInDatabase := func(database string, expected bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			client, err := testAccProvider.Meta().(*Client).ForDatabase(database)
+			if err != nil {
+				return err
+			}
+			exists, err := checkSchemaExists(client, schemaName)
+			if err != nil {
+				return fmt.Errorf("error checking schema: %w", err)
+			}
+			if exists != expected {
+				return fmt.Errorf("expected schema %s to exist in database %s: %t", schemaName, database, expected)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					checkSchemaInDatabase(dbName, true),
+					func(s *terraform.State) error {
+						return checkSchemaInDatabase(testAccProvider.Meta().(*Client).config.Database, false)(s)
+					},
+					resource.TestCheckResourceAttr("redshift_schema.schema", "database", dbName),
+					resource.TestCheckResourceAttr("redshift_grant.usage", "privileges.#", "1"),
+				),
+			},
+			{
+				ResourceName: "redshift_schema.schema",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%s", dbName, s.RootModule().Resources["redshift_schema.schema"].Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_OwnerChangedOutOfBand(t *testing.T) {
+	me := strings.ToLower(permanentUsername(os.Getenv("REDSHIFT_USER")))
+	owner := generateRandomObjectName("tf_acc_schema_drift_owner")
+	schemaName := generateRandomObjectName("tf_acc_schema_drift")
+	mySchemaName := generateRandomObjectName("tf_acc_schema_my")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "owner" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name  = %[2]q
+  owner = redshift_user.owner.name
+}
+
+resource "redshift_schema" "mine" {
+  name  = %[3]q
+  owner = %[4]q
+}
+`, owner, schemaName, mySchemaName, strings.ToUpper(me))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "owner", owner),
+					resource.TestCheckResourceAttr("redshift_schema.mine", "owner", me),
+				),
+			},
+			{
+				// The connecting user as owner must not cause a diff
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					db, err := testAccProvider.Meta().(*Client).Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(me))); err != nil {
+						t.Fatalf("could not change the schema owner: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_schema.schema", "owner", owner),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_RenameInPlace(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_schema_rename")
+	newSchemaName := generateRandomObjectName("tf_acc_schema_renamed")
+	config := `
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+`
+	var schemaOID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, schemaName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					func(s *terraform.State) error {
+						schemaOID = s.RootModule().Resources["redshift_schema.schema"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Renaming the schema must keep the schema and its objects
+				PreConfig: func() {
+					db, err := testAccProvider.Meta().(*Client).Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("CREATE TABLE %s.keep_me (id int)", pq.QuoteIdentifier(schemaName))); err != nil {
+						t.Fatalf("could not create table: %v", err)
+					}
+				},
+				Config: fmt.Sprintf(config, newSchemaName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(newSchemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", "name", newSchemaName),
+					resource.TestCheckResourceAttrPtr("redshift_schema.schema", "id", &schemaOID),
+					func(s *terraform.State) error {
+						db, err := testAccProvider.Meta().(*Client).Connect()
+						if err != nil {
+							return err
+						}
+						var count int
+						if err := db.QueryRow("SELECT COUNT(*) FROM pg_tables WHERE schemaname = $1 AND tablename = 'keep_me'", newSchemaName).Scan(&count); err != nil {
+							return err
+						}
+						if count != 1 {
+							return fmt.Errorf("table keep_me was not kept in the renamed schema %s", newSchemaName)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_SkipDrop(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_schema_skip_drop")
+	config := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name      = %[1]q
+  skip_drop = true
+  %[2]s
+}
+`, schemaName, attributes)
+	}
+
+	exec := func(query string) {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("could not connect: %v", err)
+		}
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("could not execute %q: %v", query, err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		// The schema is kept on destroy
+		CheckDestroy: func(s *terraform.State) error {
+			exists, err := checkSchemaExists(testAccProvider.Meta().(*Client), schemaName)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("schema %s was dropped on destroy", schemaName)
+			}
+			exec(fmt.Sprintf("DROP SCHEMA %s", pq.QuoteIdentifier(schemaName)))
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// An existing schema is adopted
+				PreConfig: func() {
+					exec(fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(schemaName)))
+				},
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaNameAttr, schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaSkipDropAttr, "true"),
+				),
+			},
+			{
+				Config:      config("cascade_on_delete = true"),
+				ExpectError: regexp.MustCompile(`"skip_drop": conflicts with cascade_on_delete`),
+			},
+		},
+	})
+}
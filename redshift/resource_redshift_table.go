@@ -0,0 +1,723 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	tableSchemaAttr          = "schema"
+	tableNameAttr            = "name"
+	tableColumnAttr          = "column"
+	tableColumnNameAttr      = "name"
+	tableColumnTypeAttr      = "type"
+	tableColumnNullableAttr  = "nullable"
+	tableColumnDefaultAttr   = "default"
+	tableColumnEncodeAttr    = "encode"
+	tableDistStyleAttr       = "dist_style"
+	tableDistKeyAttr         = "dist_key"
+	tableSortKeyAttr         = "sort_key"
+	tableOwnerAttr           = "owner"
+	tableAnalyzeOnChangeAttr = "analyze_on_change"
+	tableVacuumOnChangeAttr  = "vacuum_on_change"
+)
+
+var tableAllowedDistStyles = []string{
+	"AUTO",
+	"EVEN",
+	"KEY",
+	"ALL",
+}
+
+// See https://docs.aws.amazon.com/redshift/latest/dg/r_PG_CLASS_INFO.html
+var tableDistStyleCodes = map[int]string{
+	0:  "EVEN",
+	1:  "KEY",
+	8:  "ALL",
+	10: "AUTO",
+	11: "AUTO",
+	12: "AUTO",
+}
+
+// tableColumnTypeAliases maps the type names accepted by CREATE TABLE
+// to the names reported by the system views.
+var tableColumnTypeAliases = map[string]string{
+	"int":                         "integer",
+	"int4":                        "integer",
+	"integer":                     "integer",
+	"int2":                        "smallint",
+	"smallint":                    "smallint",
+	"int8":                        "bigint",
+	"bigint":                      "bigint",
+	"varchar":                     "character varying",
+	"nvarchar":                    "character varying",
+	"text":                        "character varying",
+	"character varying":           "character varying",
+	"char":                        "character",
+	"nchar":                       "character",
+	"bpchar":                      "character",
+	"character":                   "character",
+	"bool":                        "boolean",
+	"boolean":                     "boolean",
+	"float":                       "double precision",
+	"float8":                      "double precision",
+	"double precision":            "double precision",
+	"float4":                      "real",
+	"real":                        "real",
+	"decimal":                     "numeric",
+	"numeric":                     "numeric",
+	"timestamp":                   "timestamp without time zone",
+	"timestamp without time zone": "timestamp without time zone",
+	"timestamptz":                 "timestamp with time zone",
+	"timestamp with time zone":    "timestamp with time zone",
+	"time":                        "time without time zone",
+	"time without time zone":      "time without time zone",
+	"timetz":                      "time with time zone",
+	"time with time zone":         "time with time zone",
+	"varbyte":                     "binary varying",
+	"varbinary":                   "binary varying",
+	"binary varying":              "binary varying",
+}
+
+// tableColumnTypeDefaultParameters are the parameters Redshift uses when none are given.
+var tableColumnTypeDefaultParameters = map[string]string{
+	"character varying": "(256)",
+	"character":         "(1)",
+	"numeric":           "(18,0)",
+}
+
+var tableColumnTypeWhitespaceRegexp = regexp.MustCompile(`\s+`)
+
+type tableColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Default  string
+	Encode   string
+}
+
+func redshiftTable() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a Redshift table. Columns can be added and dropped, the size of ` + "`varchar`" + ` columns can be increased and
+the encoding, distribution and sort keys can be altered in place. Other changes to existing columns, or adding
+columns in between existing ones, require the table to be re-created, which drops its data.
+`,
+		CreateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftTableCreate),
+		ReadContext:   ResourceFuncInDatabase(resourceDatabase, resourceRedshiftTableRead),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftTableUpdate),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftTableDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateInDatabase,
+		},
+		CustomizeDiff: resourceRedshiftTableCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("table"),
+			tableSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The schema of the table.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tableNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the table. Changing it renames the table.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tableColumnAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The columns of the table, in order. New columns can only be appended at the end.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableColumnNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column.",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						tableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The data type of the column, e.g. `varchar(256)` or `bigint`.",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnType(old) == normalizeColumnType(new)
+							},
+						},
+						tableColumnNullableAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the column accepts NULL values.",
+						},
+						tableColumnDefaultAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The default value expression of the column. It is not read back from the database.",
+						},
+						tableColumnEncodeAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The compression encoding of the column, e.g. `az64` or `zstd`. Defaults to the encoding chosen by Redshift.",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+					},
+				},
+			},
+			tableDistStyleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The distribution style of the table (one of: " + strings.Join(tableAllowedDistStyles, ", ") + ").",
+				ValidateFunc: validation.StringInSlice(tableAllowedDistStyles, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
+			tableDistKeyAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The column used as distribution key. Implies `dist_style` `KEY`. Removing it sets the distribution style to `AUTO` unless another `dist_style` is set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tableSortKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The columns of the compound sort key, in order. Without sort key, the table uses `SORTKEY AUTO` and the sort key picked by Redshift isn't tracked. Removing it sets the sort key back to `AUTO`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+			},
+			tableOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The owner of the table. Defaults to the user the provider is connected as.",
+			},
+			tableAnalyzeOnChangeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run `ANALYZE` on the table after its columns, distribution or sort keys were changed. It runs outside of a transaction and its duration grows with the size of the table.",
+			},
+			tableVacuumOnChangeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run `VACUUM` on the table after its columns, distribution or sort keys were changed. It runs outside of a transaction, can take a long time on large tables and only one `VACUUM` can run on a cluster at a time.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftTableCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	distStyle := strings.ToUpper(d.Get(tableDistStyleAttr).(string))
+	if distKey := d.Get(tableDistKeyAttr).(string); distKey != "" && distStyle != "" && distStyle != "KEY" && d.NewValueKnown(tableDistStyleAttr) {
+		return fmt.Errorf("`%s` can only be used with `%s` KEY", tableDistKeyAttr, tableDistStyleAttr)
+	}
+
+	if d.Id() == "" || !d.HasChange(tableColumnAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(tableColumnAttr)
+	if tableColumnsRequireReplacement(expandTableColumns(oldRaw.([]interface{})), expandTableColumns(newRaw.([]interface{}))) {
+		return d.ForceNew(tableColumnAttr)
+	}
+	return nil
+}
+
+func resourceRedshiftTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(tableSchemaAttr).(string)
+	tableName := d.Get(tableNameAttr).(string)
+
+	if err := checkPrivilege(db, "schema", schemaName, "create"); err != nil {
+		return err
+	}
+
+	var sortKey []string
+	for _, col := range d.Get(tableSortKeyAttr).([]interface{}) {
+		sortKey = append(sortKey, col.(string))
+	}
+
+	tx, err := startCreateTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := createTableQuery(
+		schemaName,
+		tableName,
+		expandTableColumns(d.Get(tableColumnAttr).([]interface{})),
+		d.Get(tableDistStyleAttr).(string),
+		d.Get(tableDistKeyAttr).(string),
+		sortKey,
+	)
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not create table: %w", err)
+	}
+
+	if owner, ok := d.GetOk(tableOwnerAttr); ok {
+		if err := setTableOwner(tx, schemaName, tableName, owner.(string)); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(schemaName, tableName))
+
+	return resourceRedshiftTableRead(db, d)
+}
+
+func resourceRedshiftTableRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, tableName, err := parseTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var owner string
+	var distStyleCode int
+	query := `
+  SELECT t.tableowner, cl.reldiststyle
+  FROM pg_tables t
+  JOIN pg_namespace nsp ON nsp.nspname = t.schemaname
+  JOIN pg_class_info cl ON cl.relname = t.tablename AND cl.relnamespace = nsp.oid
+  WHERE t.schemaname = $1 AND t.tablename = $2
+`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, schemaName, tableName)
+	if err := db.QueryRow(query, schemaName, tableName).Scan(&owner, &distStyleCode); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Redshift table (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading table: %w", err)
+	}
+
+	query = `
+  SELECT column_name, data_type, is_nullable, COALESCE(encoding, ''), distkey, COALESCE(sortkey, 0)
+  FROM SVV_REDSHIFT_COLUMNS
+  WHERE database_name = current_database() AND schema_name = $1 AND table_name = $2
+  ORDER BY ordinal_position
+`
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, schemaName, tableName)
+	rows, err := db.Query(query, schemaName, tableName)
+	if err != nil {
+		return fmt.Errorf("error reading table columns: %w", err)
+	}
+	defer rows.Close()
+
+	configured := map[string]tableColumn{}
+	for _, column := range expandTableColumns(d.Get(tableColumnAttr).([]interface{})) {
+		configured[column.Name] = column
+	}
+
+	var columns []interface{}
+	var distKey string
+	sortKeyColumns := map[int]string{}
+	for rows.Next() {
+		var name, dataType, isNullable, encoding string
+		var isDistKey bool
+		var sortKeyPosition int
+		if err := rows.Scan(&name, &dataType, &isNullable, &encoding, &isDistKey, &sortKeyPosition); err != nil {
+			return fmt.Errorf("error reading table columns: %w", err)
+		}
+
+		column := map[string]interface{}{
+			tableColumnNameAttr:     name,
+			tableColumnTypeAttr:     dataType,
+			tableColumnNullableAttr: strings.EqualFold(isNullable, "yes") || strings.EqualFold(isNullable, "true"),
+			tableColumnEncodeAttr:   strings.ToLower(strings.TrimSpace(encoding)),
+		}
+		// Keep the configured spelling of the type and the default, which can't be reliably compared
+		if c, ok := configured[name]; ok {
+			if normalizeColumnType(c.Type) == normalizeColumnType(dataType) {
+				column[tableColumnTypeAttr] = c.Type
+			}
+			column[tableColumnDefaultAttr] = c.Default
+		}
+		columns = append(columns, column)
+
+		if isDistKey {
+			distKey = name
+		}
+		if sortKeyPosition > 0 {
+			sortKeyColumns[sortKeyPosition] = name
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading table columns: %w", err)
+	}
+
+	// Without sort key, the table uses SORTKEY AUTO and the columns picked by Redshift aren't tracked
+	sortKey := make([]string, 0, len(sortKeyColumns))
+	if len(d.Get(tableSortKeyAttr).([]interface{})) > 0 {
+		for i := 1; i <= len(sortKeyColumns); i++ {
+			sortKey = append(sortKey, sortKeyColumns[i])
+		}
+	}
+
+	distStyle := tableDistStyleCodes[distStyleCode]
+	if distStyle != "KEY" {
+		distKey = ""
+	}
+
+	d.Set(tableSchemaAttr, schemaName)
+	d.Set(tableNameAttr, tableName)
+	d.Set(tableOwnerAttr, owner)
+	d.Set(tableDistStyleAttr, distStyle)
+	d.Set(tableDistKeyAttr, distKey)
+	d.Set(tableSortKeyAttr, sortKey)
+	if err := d.Set(tableColumnAttr, columns); err != nil {
+		return fmt.Errorf("error setting table columns: %w", err)
+	}
+
+	return nil
+}
+
+func resourceRedshiftTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(tableSchemaAttr).(string)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if d.HasChange(tableNameAttr) {
+		oldName, newName := d.GetChange(tableNameAttr)
+		query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tableIdentifier(schemaName, oldName.(string)), pq.QuoteIdentifier(newName.(string)))
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not rename table: %w", err)
+		}
+	}
+
+	tableName := d.Get(tableNameAttr).(string)
+	table := tableIdentifier(schemaName, tableName)
+
+	if d.HasChange(tableOwnerAttr) {
+		if owner := d.Get(tableOwnerAttr).(string); owner != "" {
+			if err := setTableOwner(tx, schemaName, tableName, owner); err != nil {
+				return err
+			}
+		}
+	}
+
+	// ALTER COLUMN, ALTER DISTSTYLE and ALTER SORTKEY can't run inside a transaction block,
+	// they are executed after the transaction was committed.
+	var alterQueries []string
+	if d.HasChange(tableColumnAttr) {
+		oldRaw, newRaw := d.GetChange(tableColumnAttr)
+		queries, nonTransactionalQueries := alterTableColumnsQueries(table, expandTableColumns(oldRaw.([]interface{})), expandTableColumns(newRaw.([]interface{})))
+		for _, query := range queries {
+			log.Printf("[DEBUG] %s\n", query)
+			if _, err := tx.Exec(query); err != nil {
+				return fmt.Errorf("could not alter table columns: %w", err)
+			}
+		}
+		alterQueries = append(alterQueries, nonTransactionalQueries...)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(schemaName, tableName))
+
+	if d.HasChanges(tableDistStyleAttr, tableDistKeyAttr) {
+		alterQueries = append(alterQueries, alterTableDistStyleQuery(table, d.Get(tableDistStyleAttr).(string), d.Get(tableDistKeyAttr).(string)))
+	}
+
+	if d.HasChange(tableSortKeyAttr) {
+		var sortKey []string
+		for _, col := range d.Get(tableSortKeyAttr).([]interface{}) {
+			sortKey = append(sortKey, col.(string))
+		}
+		alterQueries = append(alterQueries, alterTableSortKeyQuery(table, sortKey))
+	}
+
+	if err := execWithoutTransaction(db, alterQueries...); err != nil {
+		return err
+	}
+
+	if d.HasChanges(tableColumnAttr, tableDistStyleAttr, tableDistKeyAttr, tableSortKeyAttr) {
+		maintenanceQueries := tableMaintenanceQueries(schemaName, tableName, d.Get(tableAnalyzeOnChangeAttr).(bool), d.Get(tableVacuumOnChangeAttr).(bool))
+		if err := execWithoutTransaction(db, maintenanceQueries...); err != nil {
+			return err
+		}
+	}
+
+	return resourceRedshiftTableRead(db, d)
+}
+
+func resourceRedshiftTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, tableName, err := parseTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s", tableIdentifier(schemaName, tableName))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("could not drop table: %w", err)
+	}
+
+	return nil
+}
+
+func setTableOwner(tx *sql.Tx, schemaName, tableName, owner string) error {
+	query := fmt.Sprintf("ALTER TABLE %s OWNER TO %s", tableIdentifier(schemaName, tableName), pq.QuoteIdentifier(owner))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error setting table owner: %w", err)
+	}
+	return nil
+}
+
+func expandTableColumns(raw []interface{}) []tableColumn {
+	columns := make([]tableColumn, 0, len(raw))
+	for _, c := range raw {
+		column := c.(map[string]interface{})
+		columns = append(columns, tableColumn{
+			Name:     strings.ToLower(column[tableColumnNameAttr].(string)),
+			Type:     column[tableColumnTypeAttr].(string),
+			Nullable: column[tableColumnNullableAttr].(bool),
+			Default:  column[tableColumnDefaultAttr].(string),
+			Encode:   strings.ToLower(column[tableColumnEncodeAttr].(string)),
+		})
+	}
+	return columns
+}
+
+func tableIdentifier(schemaName, tableName string) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(tableName))
+}
+
+func generateTableID(schemaName, tableName string) string {
+	return fmt.Sprintf("%s.%s", strings.ToLower(schemaName), strings.ToLower(tableName))
+}
+
+func parseTableID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid table ID %q, expected <schema>.<table>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// normalizeColumnType returns the name of a column type as reported by the system views,
+// so that e.g. `varchar` and `character varying(256)` are considered equal.
+func normalizeColumnType(columnType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(tableColumnTypeWhitespaceRegexp.ReplaceAllString(columnType, " ")))
+
+	base, parameters := normalized, ""
+	if idx := strings.Index(normalized, "("); idx != -1 {
+		base = strings.TrimSpace(normalized[:idx])
+		parameters = strings.ReplaceAll(normalized[idx:], " ", "")
+	}
+
+	if alias, ok := tableColumnTypeAliases[base]; ok {
+		base = alias
+	}
+	if parameters == "" {
+		parameters = tableColumnTypeDefaultParameters[base]
+	}
+	// numeric(10) is numeric(10,0)
+	if base == "numeric" && !strings.Contains(parameters, ",") {
+		parameters = strings.TrimSuffix(parameters, ")") + ",0)"
+	}
+	// varchar(max) is varchar(65535)
+	if base == "character varying" && parameters == "(max)" {
+		parameters = "(65535)"
+	}
+
+	return base + parameters
+}
+
+func isVarcharColumnType(columnType string) bool {
+	return strings.HasPrefix(normalizeColumnType(columnType), "character varying")
+}
+
+// tableColumnsRequireReplacement returns true if the columns can't be changed from old to new with ALTER TABLE.
+// Columns can be dropped or appended at the end, varchar columns can be resized
+// and the encoding of any column can be changed.
+func tableColumnsRequireReplacement(oldColumns, newColumns []tableColumn) bool {
+	oldByName := map[string]tableColumn{}
+	for _, column := range oldColumns {
+		oldByName[column.Name] = column
+	}
+	newByName := map[string]tableColumn{}
+	for _, column := range newColumns {
+		newByName[column.Name] = column
+	}
+
+	var keptOld []string
+	for _, column := range oldColumns {
+		if _, ok := newByName[column.Name]; ok {
+			keptOld = append(keptOld, column.Name)
+		}
+	}
+
+	var keptNew []string
+	added := false
+	for _, column := range newColumns {
+		oldColumn, existed := oldByName[column.Name]
+		if !existed {
+			added = true
+			continue
+		}
+		// Existing columns can't follow a new one, ADD COLUMN always appends
+		if added {
+			return true
+		}
+		keptNew = append(keptNew, column.Name)
+
+		if oldColumn.Nullable != column.Nullable || oldColumn.Default != column.Default {
+			return true
+		}
+		if normalizeColumnType(oldColumn.Type) != normalizeColumnType(column.Type) &&
+			!(isVarcharColumnType(oldColumn.Type) && isVarcharColumnType(column.Type)) {
+			return true
+		}
+	}
+
+	if len(keptOld) != len(keptNew) {
+		return true
+	}
+	for i := range keptOld {
+		if keptOld[i] != keptNew[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// alterTableColumnsQueries returns the statements altering the columns from old to new.
+// The first list can run inside a transaction, the second one can't.
+func alterTableColumnsQueries(table string, oldColumns, newColumns []tableColumn) ([]string, []string) {
+	var queries, nonTransactionalQueries []string
+
+	newByName := map[string]tableColumn{}
+	for _, column := range newColumns {
+		newByName[column.Name] = column
+	}
+	oldByName := map[string]tableColumn{}
+	for _, column := range oldColumns {
+		oldByName[column.Name] = column
+		if _, ok := newByName[column.Name]; !ok {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, pq.QuoteIdentifier(column.Name)))
+		}
+	}
+
+	for _, column := range newColumns {
+		oldColumn, existed := oldByName[column.Name]
+		if !existed {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, columnDefinition(column)))
+			continue
+		}
+		if normalizeColumnType(oldColumn.Type) != normalizeColumnType(column.Type) {
+			nonTransactionalQueries = append(nonTransactionalQueries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table, pq.QuoteIdentifier(column.Name), column.Type))
+		}
+		if column.Encode != "" && oldColumn.Encode != column.Encode {
+			nonTransactionalQueries = append(nonTransactionalQueries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ENCODE %s", table, pq.QuoteIdentifier(column.Name), column.Encode))
+		}
+	}
+
+	return queries, nonTransactionalQueries
+}
+
+func columnDefinition(column tableColumn) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column.Name), column.Type)
+	if column.Default != "" {
+		definition = fmt.Sprintf("%s DEFAULT %s", definition, column.Default)
+	}
+	if column.Encode != "" {
+		definition = fmt.Sprintf("%s ENCODE %s", definition, column.Encode)
+	}
+	if !column.Nullable {
+		definition = fmt.Sprintf("%s NOT NULL", definition)
+	}
+	return definition
+}
+
+func createTableQuery(schemaName, tableName string, columns []tableColumn, distStyle, distKey string, sortKey []string) string {
+	definitions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		definitions = append(definitions, columnDefinition(column))
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s (%s)", tableIdentifier(schemaName, tableName), strings.Join(definitions, ", "))
+	if distStyle != "" {
+		query = fmt.Sprintf("%s DISTSTYLE %s", query, strings.ToUpper(distStyle))
+	}
+	if distKey != "" {
+		query = fmt.Sprintf("%s DISTKEY(%s)", query, pq.QuoteIdentifier(distKey))
+	}
+	if len(sortKey) > 0 {
+		query = fmt.Sprintf("%s SORTKEY(%s)", query, quotedIdentifiers(sortKey))
+	}
+	return query
+}
+
+func alterTableDistStyleQuery(table, distStyle, distKey string) string {
+	if distKey != "" {
+		return fmt.Sprintf("ALTER TABLE %s ALTER DISTSTYLE KEY DISTKEY %s", table, pq.QuoteIdentifier(distKey))
+	}
+	// The distribution key was removed, KEY can't be used without it
+	if distStyle == "" || strings.EqualFold(distStyle, "KEY") {
+		distStyle = "AUTO"
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER DISTSTYLE %s", table, strings.ToUpper(distStyle))
+}
+
+func alterTableSortKeyQuery(table string, sortKey []string) string {
+	if len(sortKey) == 0 {
+		return fmt.Sprintf("ALTER TABLE %s ALTER SORTKEY AUTO", table)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER SORTKEY (%s)", table, quotedIdentifiers(sortKey))
+}
+
+func quotedIdentifiers(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = pq.QuoteIdentifier(identifier)
+	}
+	return strings.Join(quoted, ", ")
+}
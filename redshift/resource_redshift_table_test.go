@@ -0,0 +1,523 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftTable_Basic(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_table_schema")
+	tableName := generateRandomObjectName("tf_acc_table")
+	userName := generateRandomObjectName("tf_acc_table_owner")
+
+	configCreate := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  schema = redshift_schema.schema.name
+  name   = %[2]q
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    nullable = false
+  }
+
+  column {
+    name   = "label"
+    type   = "varchar(64)"
+    encode = "zstd"
+  }
+
+  dist_key = "id"
+  sort_key = ["id"]
+}
+`, schemaName, tableName)
+
+	configUpdate := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_user" "owner" {
+  name = %[3]q
+}
+
+resource "redshift_table" "table" {
+  schema = redshift_schema.schema.name
+  name   = %[2]q
+  owner  = redshift_user.owner.name
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    nullable = false
+  }
+
+  column {
+    name   = "label"
+    type   = "varchar(128)"
+    encode = "zstd"
+  }
+
+  column {
+    name    = "created_at"
+    type    = "timestamp"
+    default = "sysdate"
+  }
+
+  dist_style = "even"
+  sort_key   = ["id", "created_at"]
+
+  analyze_on_change = true
+}
+`, schemaName, tableName, userName)
+
+	// Removing the sort key sets it back to AUTO
+	configRemoveSortKey := strings.Replace(configUpdate, `sort_key   = ["id", "created_at"]`, "", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "id", generateTableID(schemaName, tableName)),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.name", "id"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.nullable", "false"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.type", "varchar(64)"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.encode", "zstd"),
+					resource.TestCheckResourceAttr("redshift_table.table", "dist_style", "KEY"),
+					resource.TestCheckResourceAttr("redshift_table.table", "dist_key", "id"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sort_key.#", "1"),
+					resource.TestCheckResourceAttrSet("redshift_table.table", "owner"),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.#", "3"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.type", "varchar(128)"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.2.name", "created_at"),
+					resource.TestCheckResourceAttr("redshift_table.table", "dist_style", "EVEN"),
+					resource.TestCheckResourceAttr("redshift_table.table", "dist_key", ""),
+					resource.TestCheckResourceAttr("redshift_table.table", "sort_key.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sort_key.1", "created_at"),
+					resource.TestCheckResourceAttr("redshift_table.table", "owner", userName),
+				),
+			},
+			{
+				Config: configRemoveSortKey,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "dist_style", "EVEN"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sort_key.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "redshift_table.table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"column.2.default", "analyze_on_change", "vacuum_on_change"},
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_table" {
+			continue
+		}
+
+		schemaName, tableName, err := parseTableID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		exists, err := checkTableExists(client, schemaName, tableName)
+		if err != nil {
+			return fmt.Errorf("error checking table: %w", err)
+		}
+
+		if exists {
+			return fmt.Errorf("table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftTableExists(schemaName, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkTableExists(client, schemaName, tableName)
+		if err != nil {
+			return fmt.Errorf("error checking table: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("table not found")
+		}
+
+		return nil
+	}
+}
+
+func checkTableExists(client *Client, schemaName, tableName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM pg_tables WHERE schemaname = $1 AND tablename = $2", schemaName, tableName).Scan(&_rez)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading info about table: %w", err)
+	}
+
+	return true, nil
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	tests := map[string]string{
+		"varchar":                     "character varying(256)",
+		"VARCHAR(64)":                 "character varying(64)",
+		"character varying( 64 )":     "character varying(64)",
+		"varchar(max)":                "character varying(65535)",
+		"text":                        "character varying(256)",
+		"int":                         "integer",
+		"int8":                        "bigint",
+		"decimal(10)":                 "numeric(10,0)",
+		"numeric":                     "numeric(18,0)",
+		"numeric(10, 2)":              "numeric(10,2)",
+		"timestamp":                   "timestamp without time zone",
+		"timestamptz":                 "timestamp with time zone",
+		"bool":                        "boolean",
+		"double  precision":           "double precision",
+		"char":                        "character(1)",
+		"super":                       "super",
+		"timestamp without time zone": "timestamp without time zone",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := normalizeColumnType(input); got != expected {
+				t.Errorf("Expected %q but got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestTableColumnsRequireReplacement(t *testing.T) {
+	id := tableColumn{Name: "id", Type: "bigint", Nullable: false}
+	label := tableColumn{Name: "label", Type: "varchar(64)", Nullable: true}
+	createdAt := tableColumn{Name: "created_at", Type: "timestamp", Nullable: true}
+
+	tests := map[string]struct {
+		old      []tableColumn
+		new      []tableColumn
+		expected bool
+	}{
+		"unchanged": {
+			old: []tableColumn{id, label},
+			new: []tableColumn{id, label},
+		},
+		"appended column": {
+			old: []tableColumn{id, label},
+			new: []tableColumn{id, label, createdAt},
+		},
+		"dropped column": {
+			old: []tableColumn{id, label, createdAt},
+			new: []tableColumn{id, createdAt},
+		},
+		"resized varchar": {
+			old: []tableColumn{id, label},
+			new: []tableColumn{id, {Name: "label", Type: "varchar(128)", Nullable: true}},
+		},
+		"changed encoding": {
+			old: []tableColumn{id, label},
+			new: []tableColumn{id, {Name: "label", Type: "varchar(64)", Nullable: true, Encode: "zstd"}},
+		},
+		"inserted column": {
+			old:      []tableColumn{id, label},
+			new:      []tableColumn{id, createdAt, label},
+			expected: true,
+		},
+		"reordered columns": {
+			old:      []tableColumn{id, label},
+			new:      []tableColumn{label, id},
+			expected: true,
+		},
+		"changed type": {
+			old:      []tableColumn{id, label},
+			new:      []tableColumn{{Name: "id", Type: "integer", Nullable: false}, label},
+			expected: true,
+		},
+		"changed nullable": {
+			old:      []tableColumn{id, label},
+			new:      []tableColumn{id, {Name: "label", Type: "varchar(64)", Nullable: false}},
+			expected: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tableColumnsRequireReplacement(tt.old, tt.new); got != tt.expected {
+				t.Errorf("Expected %t but got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAlterTableColumnsQueries(t *testing.T) {
+	table := tableIdentifier("public", "events")
+	oldColumns := []tableColumn{
+		{Name: "id", Type: "bigint"},
+		{Name: "label", Type: "varchar(64)", Nullable: true, Encode: "lzo"},
+		{Name: "legacy", Type: "integer", Nullable: true},
+	}
+	newColumns := []tableColumn{
+		{Name: "id", Type: "bigint"},
+		{Name: "label", Type: "varchar(128)", Nullable: true, Encode: "zstd"},
+		{Name: "created_at", Type: "timestamp", Default: "sysdate", Nullable: false},
+	}
+
+	queries, nonTransactionalQueries := alterTableColumnsQueries(table, oldColumns, newColumns)
+
+	expected := []string{
+		`ALTER TABLE "public"."events" DROP COLUMN "legacy"`,
+		`ALTER TABLE "public"."events" ADD COLUMN "created_at" timestamp DEFAULT sysdate NOT NULL`,
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("Expected %v but got %v", expected, queries)
+	}
+
+	expectedNonTransactional := []string{
+		`ALTER TABLE "public"."events" ALTER COLUMN "label" TYPE varchar(128)`,
+		`ALTER TABLE "public"."events" ALTER COLUMN "label" ENCODE zstd`,
+	}
+	if !reflect.DeepEqual(nonTransactionalQueries, expectedNonTransactional) {
+		t.Errorf("Expected %v but got %v", expectedNonTransactional, nonTransactionalQueries)
+	}
+}
+
+func TestCreateTableQuery(t *testing.T) {
+	columns := []tableColumn{
+		{Name: "id", Type: "bigint", Encode: "az64"},
+		{Name: "label", Type: "varchar(64)", Nullable: true, Default: "'none'"},
+	}
+
+	expected := `CREATE TABLE "public"."events" ("id" bigint ENCODE az64 NOT NULL, "label" varchar(64) DEFAULT 'none') DISTSTYLE KEY DISTKEY("id") SORTKEY("id", "label")`
+	if got := createTableQuery("public", "events", columns, "key", "id", []string{"id", "label"}); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	expected = `CREATE TABLE "public"."events" ("id" bigint ENCODE az64 NOT NULL, "label" varchar(64) DEFAULT 'none')`
+	if got := createTableQuery("public", "events", columns, "", "", nil); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestAlterTableDistStyleQuery(t *testing.T) {
+	table := tableIdentifier("public", "events")
+	tests := map[string]struct {
+		distStyle string
+		distKey   string
+		expected  string
+	}{
+		"key":         {distStyle: "KEY", distKey: "id", expected: `ALTER TABLE "public"."events" ALTER DISTSTYLE KEY DISTKEY "id"`},
+		"even":        {distStyle: "even", expected: `ALTER TABLE "public"."events" ALTER DISTSTYLE EVEN`},
+		"unset":       {expected: `ALTER TABLE "public"."events" ALTER DISTSTYLE AUTO`},
+		"removed key": {distStyle: "KEY", expected: `ALTER TABLE "public"."events" ALTER DISTSTYLE AUTO`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := alterTableDistStyleQuery(table, tt.distStyle, tt.distKey); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAlterTableSortKeyQuery(t *testing.T) {
+	table := tableIdentifier("public", "events")
+	tests := map[string]struct {
+		sortKey  []string
+		expected string
+	}{
+		"columns": {sortKey: []string{"id", "created_at"}, expected: `ALTER TABLE "public"."events" ALTER SORTKEY ("id", "created_at")`},
+		"removed": {expected: `ALTER TABLE "public"."events" ALTER SORTKEY AUTO`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := alterTableSortKeyQuery(table, tt.sortKey); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+const recordingDriverName = "redshift-test-recording"
+
+func init() {
+	sql.Register(recordingDriverName, recordingDriver{})
+}
+
+// recordedStatement is a statement executed through the recording driver.
+type recordedStatement struct {
+	query         string
+	inTransaction bool
+}
+
+var (
+	recordedStatementsLock sync.Mutex
+	recordedStatements     []recordedStatement
+)
+
+// recordingDriver records the executed statements and whether they ran in a transaction.
+// Queries return no rows.
+type recordingDriver struct{}
+
+func (recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{}, nil
+}
+
+type recordingConn struct {
+	inTransaction bool
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.inTransaction = true
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	c.inTransaction = false
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	c.inTransaction = false
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	recordedStatementsLock.Lock()
+	defer recordedStatementsLock.Unlock()
+	recordedStatements = append(recordedStatements, recordedStatement{query: query, inTransaction: c.inTransaction})
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return recordingRows{}, nil
+}
+
+type recordingRows struct{}
+
+func (recordingRows) Columns() []string {
+	return nil
+}
+
+func (recordingRows) Close() error {
+	return nil
+}
+
+func (recordingRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func TestTableUpdateMaintenance(t *testing.T) {
+	config := NewConfig(recordingDriverName, "host=table-update-maintenance", "db", 1)
+	config.retrievedUsername = "admin"
+	db, err := config.NewClient().Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		analyze  bool
+		vacuum   bool
+		expected []string
+	}{
+		"no flags": {},
+		"analyze only": {
+			analyze:  true,
+			expected: []string{`ANALYZE "public"."events"`},
+		},
+		"vacuum and analyze": {
+			analyze:  true,
+			vacuum:   true,
+			expected: []string{`VACUUM "public"."events"`, `ANALYZE "public"."events"`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+				tableSchemaAttr: "public",
+				tableNameAttr:   "events",
+				tableColumnAttr: []interface{}{
+					map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "bigint"},
+				},
+				tableAnalyzeOnChangeAttr: tt.analyze,
+				tableVacuumOnChangeAttr:  tt.vacuum,
+			})
+
+			recordedStatementsLock.Lock()
+			recordedStatements = nil
+			recordedStatementsLock.Unlock()
+
+			if err := resourceRedshiftTableUpdate(db, d); err != nil {
+				t.Fatal(err)
+			}
+
+			var maintenance []string
+			for _, statement := range recordedStatements {
+				if !strings.HasPrefix(statement.query, "ANALYZE") && !strings.HasPrefix(statement.query, "VACUUM") {
+					continue
+				}
+				if statement.inTransaction {
+					t.Errorf("Expected %q to run outside of a transaction", statement.query)
+				}
+				maintenance = append(maintenance, statement.query)
+			}
+			if !reflect.DeepEqual(maintenance, tt.expected) {
+				t.Errorf("Expected maintenance statements %q but got %q", tt.expected, maintenance)
+			}
+		})
+	}
+}
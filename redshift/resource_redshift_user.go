@@ -2,13 +2,16 @@ package redshift
 
 import (
 	"context"
+	"crypto/md5"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -24,12 +27,27 @@ const (
 	userSyslogAccessAttr   = "syslog_access"
 	userSuperuserAttr      = "superuser"
 	userSessionTimeoutAttr = "session_timeout"
+	userSearchPathAttr     = "search_path"
+	userQueryGroupAttr     = "query_group"
+	userExternalIDAttr     = "external_id"
+
+	userPasswordHashedAttr     = "password_hashed"
+	userPasswordSecretArnAttr  = "password_secret_arn"
+	userPasswordSecretHashAttr = "password_secret_hash"
+	userPasswordDisabledAttr   = "password_disabled"
 
 	// defaults
 	defaultUserSyslogAccess          = "RESTRICTED"
 	defaultUserSuperuserSyslogAccess = "UNRESTRICTED"
 )
 
+// Query priorities accepted by CHANGE_USER_PRIORITY.
+// See https://docs.aws.amazon.com/redshift/latest/dg/r_CHANGE_USER_PRIORITY.html
+// Hashed passwords accepted by CREATE USER, the MD5 hash of the password concatenated with the user name
+// or the SHA-256 digest of the password concatenated with the salt.
+// See https://docs.aws.amazon.com/redshift/latest/dg/r_CREATE_USER.html
+var userPasswordHashRegexp = regexp.MustCompile(`^(md5[0-9a-fA-F]{32}|sha256\|[0-9a-fA-F]{64}\|[^|]+)$`)
+
 // When authenticating using temporary credentials obtained by GetClusterCredentials,
 // the resulting username is prefixed with either "IAM:"" or "IAMA:"
 // This regexp is designed to match either prefix.
@@ -58,12 +76,48 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, p interface{}) error {
 			isSuperuser := d.Get(userSuperuserAttr).(bool)
 
-			isPasswordKnown := d.NewValueKnown(userPasswordAttr)
+			isPasswordKnown := d.NewValueKnown(userPasswordAttr) && d.NewValueKnown(userPasswordSecretArnAttr)
 			password, hasPassword := d.GetOk(userPasswordAttr)
-			if isSuperuser && isPasswordKnown && (!hasPassword || password.(string) == "") {
+			_, hasPasswordSecret := d.GetOk(userPasswordSecretArnAttr)
+			_, hasPasswordHash := d.GetOk(userPasswordHashedAttr)
+			if isSuperuser && isPasswordKnown && (!hasPassword || password.(string) == "") && !hasPasswordSecret && !hasPasswordHash {
 				return fmt.Errorf("users that are superusers must define a password")
 			}
 
+			// A disabled password can't be combined with a password to set
+			if disabled := d.GetRawConfig().GetAttr(userPasswordDisabledAttr); !disabled.IsNull() && disabled.IsKnown() && disabled.True() {
+				for _, attr := range []string{userPasswordAttr, userPasswordHashedAttr, userPasswordSecretArnAttr} {
+					if !d.GetRawConfig().GetAttr(attr).IsNull() {
+						return fmt.Errorf("%q can't be true when %q is set", userPasswordDisabledAttr, attr)
+					}
+				}
+			}
+			// An explicitly enabled password needs a password to set
+			if disabled := d.GetRawConfig().GetAttr(userPasswordDisabledAttr); !disabled.IsNull() && disabled.IsKnown() && disabled.False() &&
+				isPasswordKnown && (!hasPassword || password.(string) == "") && !hasPasswordSecret && !hasPasswordHash {
+				return fmt.Errorf("%q can only be false when a password is set with %q, %q or %q", userPasswordDisabledAttr, userPasswordAttr, userPasswordHashedAttr, userPasswordSecretArnAttr)
+			}
+			// Without a configured value, the password is disabled unless one is set.
+			// A password disabled outside of Terraform is set again.
+			if d.GetRawConfig().GetAttr(userPasswordDisabledAttr).IsNull() {
+				if !isPasswordKnown || !d.NewValueKnown(userPasswordHashedAttr) {
+					if err := d.SetNewComputed(userPasswordDisabledAttr); err != nil {
+						return err
+					}
+				} else if hasAnyPassword := (hasPassword && password.(string) != "") || hasPasswordSecret || hasPasswordHash; d.Get(userPasswordDisabledAttr).(bool) == hasAnyPassword {
+					if err := d.SetNew(userPasswordDisabledAttr, !hasAnyPassword); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Redshift can't remove the external ID of a user
+			if oldExternalID, newExternalID := d.GetChange(userExternalIDAttr); oldExternalID.(string) != "" && newExternalID.(string) == "" && d.NewValueKnown(userExternalIDAttr) {
+				if err := d.ForceNew(userExternalIDAttr); err != nil {
+					return err
+				}
+			}
+
 			isSyslogAccessKnown := d.NewValueKnown(userSyslogAccessAttr)
 			syslogAccess, hasSyslogAccess := d.GetOk(userSyslogAccessAttr)
 			if isSuperuser && isSyslogAccessKnown && hasSyslogAccess && syslogAccess != defaultUserSuperuserSyslogAccess {
@@ -77,7 +131,7 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 			userNameAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The name of the user account to create. The user name can't be `PUBLIC`.",
+				Description: "The name of the user account to create. The user name can't be `PUBLIC`. Renaming the user keeps its ID and the objects it owns. As Redshift clears the MD5 password of a renamed user, the password managed by `password`, `password_hashed` or `password_secret_arn` is set again.",
 				ValidateFunc: validation.StringNotInSlice([]string{
 					"public",
 				}, true),
@@ -88,11 +142,57 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 				Sensitive:   true,
 				Description: "Sets the user's password. Users can change their own passwords, unless the password is disabled. To disable password, omit this parameter or set it to `null`. Can also be a hashed password rather than the plaintext password. Please refer to the Redshift [CREATE USER documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_CREATE_USER.html) for information on creating a password hash.",
 			},
+			userExternalIDAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{userPasswordAttr, userPasswordHashedAttr, userPasswordSecretArnAttr},
+				Description:   "The identifier of the user in the identity provider the user is federated from, e.g. IAM Identity Center, set with `EXTERNALID`. Users with an external ID have their password disabled and can only log in through the identity provider. Removing it recreates the user, as Redshift can't remove the external ID. The external ID is only read back when this attribute is set.",
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+			},
+			userPasswordHashedAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{userPasswordAttr, userPasswordSecretArnAttr, userExternalIDAttr},
+				Description:   "Sets the user's password from a hash instead of the plaintext password, either `md5` followed by the MD5 hash of the password concatenated with the user name, or `sha256|<digest>|<salt>`. As the MD5 hash includes the user name, it must be recomputed when renaming the user. Changes of the password outside of Terraform are detected by comparing the hash with the one stored by Redshift.",
+				ValidateFunc:  validation.StringMatch(userPasswordHashRegexp, "must be `md5` followed by 32 hexadecimal digits or `sha256|<64 hexadecimal digits>|<salt>`"),
+			},
+			userPasswordSecretArnAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{userPasswordAttr, userPasswordHashedAttr, userExternalIDAttr},
+				Description:   "The ARN of an AWS Secrets Manager secret holding the user's password, fetched with the AWS configuration of the provider when the user is created or renamed, or when the password in the database no longer matches the one last set. The secret is either the password itself or a JSON object with a `password` key, like the secrets managed by Redshift. The password is never written to the state.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := secretArnRegion(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q: %w", key, err))
+					}
+					return
+				},
+			},
+			userPasswordDisabledAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Disables the user's password with `PASSWORD DISABLE`, e.g. to only allow logins with IAM credentials. Setting it to `true` conflicts with `password`, `password_hashed` and `password_secret_arn`. Setting it to `false` requires a password to be set with `password`, `password_hashed` or `password_secret_arn` and sets the password again if it was disabled outside of Terraform. By default the password is disabled unless one is set, which is also set again when disabled outside of Terraform. The state is read from `pg_shadow`, which is only readable by superusers.",
+			},
+			userPasswordSecretHashAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The MD5 hash, as stored by Redshift, of the password last set from `password_secret_arn`. Used to detect password changes, e.g. by a rotation of the secret.",
+			},
 			userValidUntilAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "infinity",
-				Description: "Sets a date and time after which the user's password is no longer valid. By default the password has no time limit.",
+				Description: "Sets a date and time after which the user's password is no longer valid. By default the password has no time limit. The value is stored in UTC RFC3339 format, e.g. `2038-01-04T12:00:00Z`.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := normalizeTimestamp(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q: %w", key, err))
+					}
+					return
+				},
+				DiffSuppressFunc: suppressEquivalentTimestamps,
 			},
 			userCreateDBAttr: {
 				Type:        schema.TypeBool,
@@ -104,7 +204,7 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      -1,
-				Description:  "The maximum number of database connections the user is permitted to have open concurrently. The limit isn't enforced for superusers.",
+				Description:  "The maximum number of database connections the user is permitted to have open concurrently, `-1` (the default) means `UNLIMITED`. The limit isn't enforced for superusers.",
 				ValidateFunc: validation.IntAtLeast(-1),
 			},
 			userSyslogAccessAttr: {
@@ -132,20 +232,74 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      0,
-				Description:  "The maximum time in seconds that a session remains inactive or idle. The range is 60 seconds (one minute) to 1,728,000 seconds (20 days). If no session timeout is set for the user, the cluster setting applies.",
-				ValidateFunc: validation.All(validation.IntAtLeast(60), validation.IntAtMost(1728000)),
+				Description:  "The maximum time in seconds that a session remains inactive or idle. The range is 60 seconds (one minute) to 1,728,000 seconds (20 days), `0` (the default) resets the timeout. If no session timeout is set for the user, the cluster setting applies.",
+				ValidateFunc: validateUserSessionTimeout,
+			},
+			userSearchPathAttr: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(val interface{}) string {
+						return strings.ToLower(val.(string))
+					},
+				},
+				Description: "The default schema search path of the user's sessions, in order of precedence, taking precedence over the search path of the database. Use `$user` to refer to the schema with the same name as the user. An empty list resets the search path.",
+			},
+			userQueryGroupAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The default query group of the user's sessions, set with `ALTER USER ... SET query_group`. Workload management routes the queries of the user to the queue the query group is assigned to. Removing it resets the query group. The query group is only read back when this attribute is set, so a query group set with `redshift_user_parameter` doesn't cause a diff. Don't manage the query group of a user with both.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 		},
 	}
 }
 
 func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
+	userName := d.Get(userNameAttr).(string)
+	query := createUserQuery(d, false)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error creating user %s: %w", userName, err)
+	}
+
+	var usesysid string
+	if err := tx.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&usesysid); err != nil {
+		return fmt.Errorf("user does not exist in pg_user_info table: %w", err)
+	}
+
+	d.SetId(usesysid)
+
+	if err := setUserPasswordFromSecret(tx, db, d); err != nil {
+		return err
+	}
+
+	if err := setUserSearchPath(tx, d); err != nil {
+		return err
+	}
+
+	if err := setUserQueryGroup(tx, d); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftUserReadImpl(db, d)
+}
+
+// createUserQuery returns the CREATE USER statement for the configured user.
+// With redactPassword set, the password is replaced by a placeholder so the
+// statement can be shown to humans.
+func createUserQuery(d *schema.ResourceData, redactPassword bool) string {
 	stringOpts := []struct {
 		hclKey string
 		sqlKey string
@@ -153,13 +307,13 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		{userPasswordAttr, "PASSWORD"},
 		{userValidUntilAttr, "VALID UNTIL"},
 		{userSyslogAccessAttr, "SYSLOG ACCESS"},
+		{userExternalIDAttr, "EXTERNALID"},
 	}
 
 	intOpts := []struct {
 		hclKey string
 		sqlKey string
 	}{
-		{userConnLimitAttr, "CONNECTION LIMIT"},
 		{userSessionTimeoutAttr, "SESSION TIMEOUT"},
 	}
 
@@ -177,7 +331,14 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		v, ok := d.GetOk(opt.hclKey)
 		if !ok {
 			if opt.hclKey == userPasswordAttr {
-				createOpts = append(createOpts, "PASSWORD DISABLE")
+				if hash, hasPasswordHash := d.GetOk(userPasswordHashedAttr); hasPasswordHash {
+					if redactPassword {
+						hash = redactedValue
+					}
+					createOpts = append(createOpts, fmt.Sprintf("PASSWORD '%s'", pqQuoteLiteral(hash.(string))))
+				} else {
+					createOpts = append(createOpts, "PASSWORD DISABLE")
+				}
 			}
 
 			if opt.hclKey == userSyslogAccessAttr {
@@ -195,6 +356,9 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		if val != "" {
 			switch opt.hclKey {
 			case userPasswordAttr:
+				if redactPassword {
+					val = redactedValue
+				}
 				createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
 			case userValidUntilAttr:
 				switch {
@@ -211,6 +375,8 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		}
 	}
 
+	createOpts = append(createOpts, userConnLimitClause(d.Get(userConnLimitAttr).(int)))
+
 	for _, opt := range intOpts {
 		val := d.Get(opt.hclKey).(int)
 		if opt.hclKey == userSessionTimeoutAttr && val != 0 {
@@ -231,24 +397,7 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 
 	userName := d.Get(userNameAttr).(string)
 	createStr := strings.Join(createOpts, " ")
-	query := fmt.Sprintf("CREATE USER %s WITH %s", pq.QuoteIdentifier(userName), createStr)
-
-	if _, err := tx.Exec(query); err != nil {
-		return fmt.Errorf("error creating user %s: %w", userName, err)
-	}
-
-	var usesysid string
-	if err := tx.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&usesysid); err != nil {
-		return fmt.Errorf("user does not exist in pg_user_info table: %w", err)
-	}
-
-	d.SetId(usesysid)
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("could not commit transaction: %w", err)
-	}
-
-	return resourceRedshiftUserReadImpl(db, d)
+	return fmt.Sprintf("CREATE USER %s WITH %s", pq.QuoteIdentifier(userName), createStr)
 }
 
 func resourceRedshiftUserRead(db *DBConnection, d *schema.ResourceData) error {
@@ -325,28 +474,74 @@ func resourceRedshiftUserReadImpl(db *DBConnection, d *schema.ResourceData) erro
 	d.Set(userValidUntilAttr, userValidUntil)
 	d.Set(userSessionTimeoutAttr, userSessionTimeoutNumber)
 
+	var rawConfig string
+	query := "SELECT COALESCE(array_to_string(useconfig, '|'), '') FROM pg_user WHERE usesysid = $1"
+	log.Printf("[DEBUG] read user settings: %s\n", query)
+	if err := db.QueryRow(query, useSysID).Scan(&rawConfig); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error reading user settings: %w", err)
+	}
+	d.Set(userSearchPathAttr, parseSearchPathSetting(rawConfig))
+	// The query group is only read back when it's managed by the resource, it may be set with redshift_user_parameter
+	if d.Get(userQueryGroupAttr).(string) != "" {
+		d.Set(userQueryGroupAttr, parseParameterSettings(rawConfig)[userQueryGroupAttr])
+	}
+
+	readUserPasswordHash(db, d)
+	readUserPasswordDisabled(db, d)
+
+	if err := readUserExternalID(db, d); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 const redshiftDataApiInfinityDateString = "2038-01-19 03:14:04"
 
-var redshiftDataApiDatetimeRegexp = regexp.MustCompile(`^\d+-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`)
-var correctDatetimeRegexp = regexp.MustCompile(`^\d+-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?\+00$`)
+// Layouts of the timestamps returned by Redshift or accepted in the configuration.
+// Timestamps without a time zone offset (e.g. returned by the Redshift Data API) are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
 
-func validateAndAdjustValidUntil(validUntil string) (string, error) {
-	if validUntil == "infinity" {
-		return validUntil, nil
-	} else if validUntil == redshiftDataApiInfinityDateString {
+// normalizeTimestamp converts a timestamp into UTC RFC3339 format.
+// The special value `infinity` is kept as is.
+func normalizeTimestamp(value string) (string, error) {
+	if strings.ToLower(value) == "infinity" || value == redshiftDataApiInfinityDateString {
 		// The Redshift Data API translates the `infinity` to a date in 2038 (see https://en.wikipedia.org/wiki/Year_2038_problem)
 		return "infinity", nil
-	} else if redshiftDataApiDatetimeRegexp.MatchString(validUntil) {
-		// The Redshift Data API returns the datetime without the timezone offset, so we need to add it
-		validUntil += "+00"
 	}
-	if !correctDatetimeRegexp.MatchString(validUntil) {
-		return "", fmt.Errorf(`received invalid date format for valid_until: %q, expected format is "YYYY-MM-DD HH:MM:SS+00"`, validUntil)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf(`received invalid timestamp %q, expected format is RFC3339 or "YYYY-MM-DD HH:MM:SS+00"`, value)
+}
+
+// suppressEquivalentTimestamps suppresses diffs between timestamps which denote the same point in time.
+func suppressEquivalentTimestamps(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	oldTimestamp, err := normalizeTimestamp(oldValue)
+	if err != nil {
+		return false
+	}
+	newTimestamp, err := normalizeTimestamp(newValue)
+	if err != nil {
+		return false
+	}
+	return oldTimestamp == newTimestamp
+}
+
+func validateAndAdjustValidUntil(validUntil string) (string, error) {
+	normalized, err := normalizeTimestamp(validUntil)
+	if err != nil {
+		return "", fmt.Errorf("invalid valid_until: %w", err)
 	}
-	return validUntil, nil
+	return normalized, nil
 }
 
 func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error {
@@ -358,7 +553,7 @@ func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error
 	}
 	newOwnerName := permanentUsername(rawUsername)
 
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -460,7 +655,7 @@ func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error
 }
 
 func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client)
+	tx, err := startTransaction(db)
 	if err != nil {
 		return err
 	}
@@ -470,7 +665,7 @@ func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error
 		return err
 	}
 
-	if err := setUserPassword(tx, d); err != nil {
+	if err := setUserPassword(tx, db, d); err != nil {
 		return err
 	}
 
@@ -493,7 +688,19 @@ func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error
 		return err
 	}
 
-	if err := setUserSessionTimeout(tx, d); err != nil {
+	if err := setUserSessionTimeout(tx, db, d); err != nil {
+		return err
+	}
+
+	if err := setUserSearchPath(tx, d); err != nil {
+		return err
+	}
+
+	if err := setUserQueryGroup(tx, d); err != nil {
+		return err
+	}
+
+	if err := setUserExternalID(tx, d); err != nil {
 		return err
 	}
 
@@ -517,6 +724,8 @@ func setUserName(tx *sql.Tx, d *schema.ResourceData) error {
 		return fmt.Errorf("error setting user name to an empty string")
 	}
 
+	// Redshift clears the MD5 password of a renamed user as the hash includes the user name,
+	// setUserPassword sets it again afterwards.
 	query := fmt.Sprintf("ALTER USER %s RENAME TO %s", pq.QuoteIdentifier(oldValue), pq.QuoteIdentifier(newValue))
 	if _, err := tx.Exec(query); err != nil {
 		return fmt.Errorf("error updating User NAME: %w", err)
@@ -525,13 +734,22 @@ func setUserName(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserPassword(tx *sql.Tx, d *schema.ResourceData) error {
-	if !d.HasChange(userPasswordAttr) && !d.HasChange(userNameAttr) {
+func setUserPassword(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChanges(userPasswordAttr, userNameAttr, userPasswordSecretArnAttr, userPasswordHashedAttr, userPasswordDisabledAttr) {
 		return nil
 	}
 
+	// The MD5 hash of the password includes the user name, so renaming the user requires the password again
+	if _, hasPasswordSecret := d.GetOk(userPasswordSecretArnAttr); hasPasswordSecret {
+		return setUserPasswordFromSecret(tx, db, d)
+	}
+	d.Set(userPasswordSecretHashAttr, "")
+
 	userName := d.Get(userNameAttr).(string)
 	password := d.Get(userPasswordAttr).(string)
+	if hash, hasPasswordHash := d.GetOk(userPasswordHashedAttr); hasPasswordHash {
+		password = hash.(string)
+	}
 
 	passwdTok := "PASSWORD DISABLE"
 	if password != "" {
@@ -545,6 +763,107 @@ func setUserPassword(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+// setUserPasswordFromSecret sets the password fetched from the configured secret and keeps its hash
+// to detect changes. The statement is not logged as it contains the password.
+func setUserPasswordFromSecret(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
+	arn, hasPasswordSecret := d.GetOk(userPasswordSecretArnAttr)
+	if !hasPasswordSecret {
+		return nil
+	}
+
+	region, err := secretArnRegion(arn.(string))
+	if err != nil {
+		return err
+	}
+	cfg, err := db.client.config.AwsConfig()
+	if err != nil {
+		return fmt.Errorf("could not load AWS configuration to read the password secret: %w", err)
+	}
+	cfg.Region = region
+	password, err := getSecretPassword(db.context(), cfg, secretsManagerApi.endpoint(region), arn.(string))
+	if err != nil {
+		return err
+	}
+
+	userName := d.Get(userNameAttr).(string)
+	if _, err := tx.Exec(userPasswordQuery(userName, password)); err != nil {
+		return fmt.Errorf("error setting user password from secret: %w", err)
+	}
+	d.Set(userPasswordSecretHashAttr, userPasswordHash(userName, password))
+	return nil
+}
+
+func userPasswordQuery(userName, password string) string {
+	return fmt.Sprintf("ALTER USER %s PASSWORD '%s'", pq.QuoteIdentifier(userName), pqQuoteLiteral(password))
+}
+
+// userPasswordHash returns the hash of the password as Redshift stores it in pg_shadow, i.e. `md5` followed
+// by the MD5 hash of the password concatenated with the user name.
+func userPasswordHash(userName, password string) string {
+	hash := md5.Sum([]byte(password + userName))
+	return "md5" + hex.EncodeToString(hash[:])
+}
+
+// readUserPasswordHash compares the hash of the password stored by Redshift with the configured hash or
+// the hash of the password last set from the secret, to detect passwords changed outside of Terraform.
+// The hash is only readable by superusers, without it changes are not detected.
+func readUserPasswordHash(db *DBConnection, d *schema.ResourceData) {
+	configuredHash := d.Get(userPasswordHashedAttr).(string)
+	secretHash := d.Get(userPasswordSecretHashAttr).(string)
+	if _, hasPasswordSecret := d.GetOk(userPasswordSecretArnAttr); !hasPasswordSecret {
+		secretHash = ""
+	}
+	if configuredHash == "" && secretHash == "" {
+		return
+	}
+
+	userName := d.Get(userNameAttr).(string)
+	var passwordHash sql.NullString
+	if err := db.QueryRow("SELECT passwd FROM pg_shadow WHERE usesysid = $1", d.Id()).Scan(&passwordHash); err != nil {
+		log.Printf("[WARN] could not read the password hash of user %s, changes of the password are not detected: %v", userName, err)
+		return
+	}
+
+	if configuredHash != "" && passwordHashDiffers(passwordHash.String, configuredHash) {
+		d.Set(userPasswordHashedAttr, passwordHash.String)
+	}
+	// The secret ARN is cleared from the state to set the password from the secret again
+	if secretHash != "" && passwordHashDiffers(passwordHash.String, secretHash) {
+		log.Printf("[WARN] the password of user %s differs from the one last set from the secret, it will be set again", userName)
+		d.Set(userPasswordSecretArnAttr, "")
+	}
+}
+
+// readUserPasswordDisabled reads whether the password of the user is disabled, e.g. with `PASSWORD DISABLE`
+// outside of Terraform. Without access to pg_shadow the state is kept as is.
+func readUserPasswordDisabled(db *DBConnection, d *schema.ResourceData) {
+	var passwordDisabled bool
+	if err := db.QueryRow("SELECT passwd IS NULL FROM pg_shadow WHERE usesysid = $1", d.Id()).Scan(&passwordDisabled); err != nil {
+		log.Printf("[WARN] could not read whether the password of user %s is disabled: %v", d.Get(userNameAttr).(string), err)
+		return
+	}
+	d.Set(userPasswordDisabledAttr, passwordDisabled)
+}
+
+// passwordHashDiffers reports whether the hash stored by Redshift differs from the expected hash.
+// Hashes of different schemes can't be compared and are not reported as different.
+func passwordHashDiffers(storedHash, expectedHash string) bool {
+	for _, scheme := range []string{"md5", "sha256|"} {
+		if strings.HasPrefix(storedHash, scheme) && strings.HasPrefix(expectedHash, scheme) {
+			return !strings.EqualFold(storedHash, expectedHash)
+		}
+	}
+	return false
+}
+
+// userConnLimitClause returns the CONNECTION LIMIT clause, -1 is the sentinel for UNLIMITED.
+func userConnLimitClause(connLimit int) string {
+	if connLimit == -1 {
+		return "CONNECTION LIMIT UNLIMITED"
+	}
+	return fmt.Sprintf("CONNECTION LIMIT %d", connLimit)
+}
+
 func setUserConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 	if !d.HasChange(userConnLimitAttr) {
 		return nil
@@ -552,7 +871,7 @@ func setUserConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 
 	connLimit := d.Get(userConnLimitAttr).(int)
 	userName := d.Get(userNameAttr).(string)
-	query := fmt.Sprintf("ALTER USER %s CONNECTION LIMIT %d", pq.QuoteIdentifier(userName), connLimit)
+	query := fmt.Sprintf("ALTER USER %s %s", pq.QuoteIdentifier(userName), userConnLimitClause(connLimit))
 	if _, err := tx.Exec(query); err != nil {
 		return fmt.Errorf("error updating user CONNECTION LIMIT: %w", err)
 	}
@@ -560,7 +879,21 @@ func setUserConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserSessionTimeout(tx *sql.Tx, d *schema.ResourceData) error {
+const (
+	userSessionTimeoutMin = 60
+	userSessionTimeoutMax = 1728000
+)
+
+// validateUserSessionTimeout accepts 0 to reset the session timeout or a timeout in the range allowed by Redshift.
+func validateUserSessionTimeout(val interface{}, key string) ([]string, []error) {
+	timeout := val.(int)
+	if timeout == 0 || (timeout >= userSessionTimeoutMin && timeout <= userSessionTimeoutMax) {
+		return nil, nil
+	}
+	return nil, []error{fmt.Errorf("%s must be between %d and %d seconds, or 0 to reset the session timeout, got %d", key, userSessionTimeoutMin, userSessionTimeoutMax, timeout)}
+}
+
+func setUserSessionTimeout(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
 	if !d.HasChange(userSessionTimeoutAttr) {
 		return nil
 	}
@@ -574,7 +907,7 @@ func setUserSessionTimeout(tx *sql.Tx, d *schema.ResourceData) error {
 		query = fmt.Sprintf("ALTER USER %s SESSION TIMEOUT %d", pq.QuoteIdentifier(userName), sessionTimeout)
 	}
 	if _, err := tx.Exec(query); err != nil {
-		return fmt.Errorf("error updating user SESSION TIMEOUT: %w", err)
+		return fmt.Errorf("error updating user SESSION TIMEOUT: %w", unsupportedSyntaxError(db, "SESSION TIMEOUT", err))
 	}
 
 	return nil
@@ -663,6 +996,90 @@ func setUserSyslogAccess(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+func setUserSearchPath(tx *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(userSearchPathAttr) {
+		return nil
+	}
+
+	query := userSearchPathQuery(d.Get(userNameAttr).(string), getUserSearchPath(d))
+	log.Printf("[DEBUG] changing user search_path: %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error updating user search_path: %w", err)
+	}
+
+	return nil
+}
+
+func getUserSearchPath(d *schema.ResourceData) []string {
+	var searchPath []string
+	for _, schemaName := range d.Get(userSearchPathAttr).([]interface{}) {
+		searchPath = append(searchPath, schemaName.(string))
+	}
+	return searchPath
+}
+
+// userSearchPathQuery returns the statement setting the default search path of the user.
+// An empty search path resets it.
+func userSearchPathQuery(userName string, searchPath []string) string {
+	if len(searchPath) == 0 {
+		return fmt.Sprintf("ALTER USER %s RESET search_path", pq.QuoteIdentifier(userName))
+	}
+	return fmt.Sprintf("ALTER USER %s SET search_path TO %s", pq.QuoteIdentifier(userName), quoteSearchPath(searchPath))
+}
+
+func setUserQueryGroup(tx *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(userQueryGroupAttr) {
+		return nil
+	}
+
+	query := userQueryGroupQuery(d.Get(userNameAttr).(string), d.Get(userQueryGroupAttr).(string))
+	log.Printf("[DEBUG] changing user query_group: %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error updating user query_group: %w", err)
+	}
+
+	return nil
+}
+
+// userQueryGroupQuery returns the statement setting the default query group of the user.
+// An empty query group resets it.
+func userQueryGroupQuery(userName, queryGroup string) string {
+	if queryGroup == "" {
+		return fmt.Sprintf("ALTER USER %s RESET query_group", pq.QuoteIdentifier(userName))
+	}
+	return fmt.Sprintf("ALTER USER %s SET query_group TO '%s'", pq.QuoteIdentifier(userName), pqQuoteLiteral(queryGroup))
+}
+
+// readUserExternalID reads the external ID of the user, only if it is managed by the resource.
+func readUserExternalID(db *DBConnection, d *schema.ResourceData) error {
+	if d.Get(userExternalIDAttr).(string) == "" {
+		return nil
+	}
+
+	var externalID string
+	query := "SELECT COALESCE(external_user_id, '') FROM svv_user_info WHERE user_id = $1"
+	log.Printf("[DEBUG] read user external ID: %s\n", query)
+	if err := db.QueryRow(query, d.Id()).Scan(&externalID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error reading user external ID: %w", err)
+	}
+	d.Set(userExternalIDAttr, externalID)
+	return nil
+}
+
+func setUserExternalID(tx *sql.Tx, d *schema.ResourceData) error {
+	externalID := d.Get(userExternalIDAttr).(string)
+	if !d.HasChange(userExternalIDAttr) || externalID == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER USER %s EXTERNALID %s", pq.QuoteIdentifier(d.Get(userNameAttr).(string)), pq.QuoteIdentifier(externalID))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error updating user EXTERNALID: %w", err)
+	}
+	return nil
+}
+
 func getDefaultSyslogAccess(d *schema.ResourceData) string {
 	if d.Get(userSuperuserAttr).(bool) {
 		return defaultUserSuperuserSyslogAccess
@@ -0,0 +1,186 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	userParameterUserAttr      = "user"
+	userParameterParameterAttr = "parameter"
+	userParameterValueAttr     = "value"
+)
+
+func redshiftUserParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the default value of a configuration parameter for a user, set with ` + "`ALTER USER ... SET`" + `, e.g. the ` + "`query_group`" + ` or ` + "`statement_timeout`" + ` of a service account.
+The value applies to new sessions of the user and overrides the database default.
+Removing the resource resets the parameter.
+
+Note: the ` + "`search_path`" + ` and ` + "`query_group`" + ` parameters conflict with the attributes of the same name of the ` + "`redshift_user`" + ` resource.
+`,
+		CreateContext: ResourceFunc(resourceRedshiftUserParameterCreate),
+		ReadContext:   ResourceFunc(resourceRedshiftUserParameterRead),
+		UpdateContext: ResourceFunc(resourceRedshiftUserParameterUpdate),
+		DeleteContext: ResourceFunc(
+			ResourceRetryOnPQErrors(resourceRedshiftUserParameterDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			userParameterUserAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the user.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			userParameterParameterAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the configuration parameter, e.g. `query_group`, `search_path` or `statement_timeout`.",
+				ValidateFunc: validation.StringMatch(parameterNameRegexp, "must be a valid configuration parameter name"),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			userParameterValueAttr: {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The value of the configuration parameter. Parameters which take a list of values, like `search_path`, " +
+					"are given as a comma separated list, e.g. `$user, public`.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				StateFunc: func(val interface{}) string {
+					return normalizeParameterValue(val.(string))
+				},
+			},
+		},
+	}
+}
+
+func resourceRedshiftUserParameterCreate(db *DBConnection, d *schema.ResourceData) error {
+	userName := d.Get(userParameterUserAttr).(string)
+	parameter := strings.ToLower(d.Get(userParameterParameterAttr).(string))
+
+	if err := setUserParameter(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(generateParameterID(userName, parameter))
+
+	return resourceRedshiftUserParameterRead(db, d)
+}
+
+func resourceRedshiftUserParameterRead(db *DBConnection, d *schema.ResourceData) error {
+	userName, parameter, err := parseParameterID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var rawConfig string
+	query := "SELECT COALESCE(array_to_string(useconfig, '|'), '') FROM pg_user WHERE usename = $1"
+	log.Printf("[DEBUG] read user settings: %s\n", query)
+	if err := db.QueryRow(query, userName).Scan(&rawConfig); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Redshift user (%s) not found", userName)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading user settings: %w", err)
+	}
+
+	value, ok := parseParameterSettings(rawConfig)[parameter]
+	if !ok {
+		log.Printf("[WARN] Parameter %s is not set for Redshift user (%s)", parameter, userName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(userParameterUserAttr, userName)
+	d.Set(userParameterParameterAttr, parameter)
+	d.Set(userParameterValueAttr, normalizeParameterValue(value))
+
+	return nil
+}
+
+func resourceRedshiftUserParameterUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setUserParameter(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftUserParameterRead(db, d)
+}
+
+func resourceRedshiftUserParameterDelete(db *DBConnection, d *schema.ResourceData) error {
+	userName, parameter, err := parseParameterID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := fmt.Sprintf("ALTER USER %s RESET %s", pq.QuoteIdentifier(userName), pq.QuoteIdentifier(parameter))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		// The parameter is gone together with the user
+		if isPqErrorWithCode(err, pqErrorCodeUndefinedObject) {
+			log.Printf("[WARN] Redshift user (%s) does not exist, parameter already removed: %v", userName, err)
+			return nil
+		}
+		return fmt.Errorf("could not reset user parameter: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func setUserParameter(db *DBConnection, d *schema.ResourceData) error {
+	query := buildUserParameterQuery(
+		d.Get(userParameterUserAttr).(string),
+		strings.ToLower(d.Get(userParameterParameterAttr).(string)),
+		d.Get(userParameterValueAttr).(string),
+	)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not set user parameter: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildUserParameterQuery returns the ALTER USER statement setting the parameter.
+func buildUserParameterQuery(userName, parameter, value string) string {
+	return fmt.Sprintf("ALTER USER %s SET %s TO %s",
+		pq.QuoteIdentifier(userName),
+		pq.QuoteIdentifier(parameter),
+		quoteParameterValue(parameter, value))
+}
@@ -0,0 +1,76 @@
+package redshift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRedshiftUserParameter_Basic(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_user_parameter")
+
+	config := func(queryGroup, statementTimeout string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_user_parameter" "query_group" {
+  user      = redshift_user.user.name
+  parameter = "query_group"
+  value     = %[2]q
+}
+
+resource "redshift_user_parameter" "statement_timeout" {
+  user      = redshift_user.user.name
+  parameter = "statement_timeout"
+  value     = %[3]q
+}
+`, userName, queryGroup, statementTimeout)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("etl", "60000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user_parameter.query_group", "id", generateParameterID(userName, "query_group")),
+					resource.TestCheckResourceAttr("redshift_user_parameter.query_group", userParameterValueAttr, "etl"),
+					resource.TestCheckResourceAttr("redshift_user_parameter.statement_timeout", userParameterValueAttr, "60000"),
+				),
+			},
+			{
+				Config: config("reporting", "120000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user_parameter.query_group", userParameterValueAttr, "reporting"),
+					resource.TestCheckResourceAttr("redshift_user_parameter.statement_timeout", userParameterValueAttr, "120000"),
+				),
+			},
+			{
+				ResourceName:      "redshift_user_parameter.query_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBuildUserParameterQuery(t *testing.T) {
+	for _, tc := range []struct {
+		parameter string
+		value     string
+		expected  string
+	}{
+		{"query_group", "etl", `ALTER USER "etl_user" SET "query_group" TO 'etl'`},
+		{"query_group", "etl, reports", `ALTER USER "etl_user" SET "query_group" TO 'etl, reports'`},
+		{"search_path", "etl, public", `ALTER USER "etl_user" SET "search_path" TO 'etl', 'public'`},
+	} {
+		if got := buildUserParameterQuery("etl_user", tc.parameter, tc.value); got != tc.expected {
+			t.Errorf("Expected %q but got %q", tc.expected, got)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"regexp"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 const testAccRedshiftUserLoginConfig = `
@@ -185,7 +187,7 @@ resource "redshift_user" "update_user" {
 					testAccCheckRedshiftUserExists("update_user"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "name", "update_user"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "connection_limit", "-1"),
-					resource.TestCheckResourceAttr("redshift_user.update_user", "valid_until", "2038-01-04 12:00:00+00"),
+					resource.TestCheckResourceAttr("redshift_user.update_user", "valid_until", "2038-01-04T12:00:00Z"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "syslog_access", "RESTRICTED"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "create_database", "false"),
 				),
@@ -210,7 +212,7 @@ resource "redshift_user" "update_user" {
 					testAccCheckRedshiftUserExists("update_user"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "name", "update_user"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "connection_limit", "-1"),
-					resource.TestCheckResourceAttr("redshift_user.update_user", "valid_until", "2038-01-04 12:00:00+00"),
+					resource.TestCheckResourceAttr("redshift_user.update_user", "valid_until", "2038-01-04T12:00:00Z"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "syslog_access", "RESTRICTED"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "create_database", "false"),
 				),
@@ -527,14 +529,14 @@ func testAccCheckRedshiftUserCanLogin(user string, password string) resource.Tes
 		if !ok {
 			sslMode = "require"
 		}
-		config := NewPqConfig(os.Getenv("REDSHIFT_HOST"), database, user, password, portNum, sslMode,
-			defaultProviderMaxOpenConnections)
+		config := NewPqConfig(os.Getenv("REDSHIFT_HOST"), database, user, password, portNum, SSLConfig{Mode: sslMode},
+			"UTC", defaultProviderApplicationName, defaultProviderConnectTimeout, 0, defaultProviderMaxOpenConnections, net.KeepAliveConfig{Enable: true})
 
 		client := config.NewClient()
-		if err != nil {
+		defer client.Close()
+		if _, err := client.Connect(); err != nil {
 			return fmt.Errorf("user is unable to login: %w", err)
 		}
-		defer client.Close()
 		return nil
 	}
 }
@@ -558,27 +560,27 @@ func Test_validateAndAdjustValidUntil(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "adds suffix to Redshift Data API datetime",
+			name: "treats Redshift Data API datetime as UTC",
 			args: args{
 				validUntil: "2025-08-06 17:22:56",
 			},
-			want:    "2025-08-06 17:22:56+00",
+			want:    "2025-08-06T17:22:56Z",
 			wantErr: false,
 		},
 		{
-			name: "does not add suffix to correct datetime",
+			name: "normalizes UTC datetime",
 			args: args{
 				validUntil: "2025-08-06 17:22:56+00",
 			},
-			want:    "2025-08-06 17:22:56+00",
+			want:    "2025-08-06T17:22:56Z",
 			wantErr: false,
 		},
 		{
-			name: "does not add suffix to correct datetime with sub-second check",
+			name: "normalizes UTC datetime with sub-second precision",
 			args: args{
 				validUntil: "2025-08-06 17:22:56.0+00",
 			},
-			want:    "2025-08-06 17:22:56.0+00",
+			want:    "2025-08-06T17:22:56Z",
 			wantErr: false,
 		},
 		{
@@ -590,12 +592,20 @@ func Test_validateAndAdjustValidUntil(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "returns error for invalid timezone",
+			name: "converts datetime of other timezone to UTC",
 			args: args{
 				validUntil: "2025-08-06 17:22:56+01",
 			},
-			want:    "",
-			wantErr: true,
+			want:    "2025-08-06T16:22:56Z",
+			wantErr: false,
+		},
+		{
+			name: "converts RFC3339 datetime to UTC",
+			args: args{
+				validUntil: "2025-08-06T17:22:56-05:30",
+			},
+			want:    "2025-08-06T22:52:56Z",
+			wantErr: false,
 		},
 		{
 			name: "returns error for invalid datetime",
@@ -619,3 +629,767 @@ func Test_validateAndAdjustValidUntil(t *testing.T) {
 		})
 	}
 }
+
+func Test_suppressEquivalentTimestamps(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   string
+		read     string
+		suppress bool
+	}{
+		{"same instant in UTC", "2038-01-04 12:00:00+00", "2038-01-04T12:00:00Z", true},
+		{"same instant in other timezone", "2038-01-04 13:00:00+01", "2038-01-04T12:00:00Z", true},
+		{"same instant read without offset", "2038-01-04T12:00:00Z", "2038-01-04 12:00:00", true},
+		{"different instants", "2038-01-04 12:00:00+01", "2038-01-04T12:00:00Z", false},
+		{"infinity", "INFINITY", "infinity", true},
+		{"infinity and datetime", "infinity", "2038-01-04T12:00:00Z", false},
+		{"invalid value", "foo", "foo", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressEquivalentTimestamps(userValidUntilAttr, tt.stored, tt.read, nil); got != tt.suppress {
+				t.Errorf("suppressEquivalentTimestamps() got = %v, want %v", got, tt.suppress)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftUser_ConnectionLimitDrift(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_conn_limit")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name             = %[1]q
+  connection_limit = 3
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "3"),
+			},
+			{
+				// The limit changed outside of terraform must be reconciled
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s CONNECTION LIMIT UNLIMITED", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "3"),
+			},
+		},
+	})
+}
+
+func TestUserConnLimitClause(t *testing.T) {
+	tests := map[int]string{
+		-1: "CONNECTION LIMIT UNLIMITED",
+		0:  "CONNECTION LIMIT 0",
+		10: "CONNECTION LIMIT 10",
+	}
+	for connLimit, expected := range tests {
+		if got := userConnLimitClause(connLimit); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+}
+
+func TestAccRedshiftUser_ResetSessionTimeout(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_session_timeout")
+
+	config := func(sessionTimeout int) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name            = %[1]q
+  session_timeout = %[2]d
+}
+`, name, sessionTimeout)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(3600),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "session_timeout", "3600"),
+			},
+			{
+				Config: config(0),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "session_timeout", "0"),
+			},
+			{
+				Config:      config(30),
+				ExpectError: regexp.MustCompile("session_timeout must be between 60 and 1728000 seconds, or 0 to reset the session timeout, got 30"),
+			},
+		},
+	})
+}
+
+func TestValidateUserSessionTimeout(t *testing.T) {
+	tests := map[int]bool{
+		0:       false,
+		59:      true,
+		60:      false,
+		3600:    false,
+		1728000: false,
+		1728001: true,
+		-1:      true,
+	}
+	for timeout, wantErr := range tests {
+		if _, errs := validateUserSessionTimeout(timeout, userSessionTimeoutAttr); (len(errs) > 0) != wantErr {
+			t.Errorf("validateUserSessionTimeout(%d) errors = %v, wantErr %v", timeout, errs, wantErr)
+		}
+	}
+}
+
+func TestAccRedshiftUser_ValidUntilDrift(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_valid_until")
+
+	config := func(validUntil string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name        = %[1]q
+  valid_until = %[2]q
+}
+`, name, validUntil)
+	}
+
+	alterValidUntil := func(validUntil string) func() {
+		return func() {
+			client := testAccProvider.Meta().(*Client)
+			db, err := client.Connect()
+			if err != nil {
+				t.Fatalf("could not connect: %v", err)
+			}
+			if _, err := db.Exec(fmt.Sprintf("ALTER USER %s VALID UNTIL '%s'", pq.QuoteIdentifier(name), validUntil)); err != nil {
+				t.Fatalf("could not alter user: %v", err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("2038-01-04T12:00:00Z"),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "valid_until", "2038-01-04T12:00:00Z"),
+			},
+			{
+				// The same point in time in another notation is not a change
+				Config:   config("2038-01-04 14:00:00+02"),
+				PlanOnly: true,
+			},
+			{
+				// The expiration changed outside of terraform must be reconciled
+				PreConfig:          alterValidUntil("2030-01-01 00:00:00+00"),
+				Config:             config("2038-01-04T12:00:00Z"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("2038-01-04T12:00:00Z"),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "valid_until", "2038-01-04T12:00:00Z"),
+			},
+			{
+				// Removing the attribute clears the expiration
+				Config: fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+`, name),
+				Check: resource.TestCheckResourceAttr("redshift_user.user", "valid_until", "infinity"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_PasswordSecret(t *testing.T) {
+	secretArn := getEnvOrSkip("REDSHIFT_USER_PASSWORD_SECRET_ARN", t)
+	name := generateRandomObjectName("tf_acc_user_password_secret")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name                = %[1]q
+  password_secret_arn = %[2]q
+}
+`, name, secretArn)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", "password_secret_arn", secretArn),
+					resource.TestCheckResourceAttrSet("redshift_user.user", "password_secret_hash"),
+					resource.TestCheckNoResourceAttr("redshift_user.user", "password"),
+				),
+			},
+			{
+				// A password changed outside of terraform is set again from the secret
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s PASSWORD 'Changed0utOfBand'", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "password_secret_arn", secretArn),
+			},
+		},
+	})
+}
+
+func TestUserPasswordHash(t *testing.T) {
+	expected := "md5b29a4eabfe9c6228a66e9707049f4441"
+	if got := userPasswordHash("john", "ez5Kiey1"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
+
+func TestAccRedshiftUser_PasswordHashed(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_password_hashed")
+	passwordHash := userPasswordHash(name, "Passw0rdHashed")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name            = %[1]q
+  password_hashed = %[2]q
+}
+`, name, passwordHash)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", "password_hashed", passwordHash),
+					resource.TestCheckNoResourceAttr("redshift_user.user", "password"),
+				),
+			},
+			{
+				// A password changed outside of terraform is detected by its hash
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s PASSWORD 'Changed0utOfBand'", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "password_hashed", passwordHash),
+			},
+		},
+	})
+}
+
+func TestUserPasswordHashRegexp(t *testing.T) {
+	for _, tc := range []struct {
+		hash  string
+		valid bool
+	}{
+		{"md5b29a4eabfe9c6228a66e9707049f4441", true},
+		{"MD5B29A4EABFE9C6228A66E9707049F4441", false},
+		{"md5b29a4eabfe9c6228a66e9707049f444", false},
+		{"sha256|c0e3d0a2bd0f1fca8eb8cf1e7d9fbd0e3a8d1f3ecbe3e0a5a3f1c6f3e0f5e0c2|Ndx5KEZL", true},
+		{"sha256|ez5Kiey1", false},
+		{"sha256|c0e3d0a2bd0f1fca8eb8cf1e7d9fbd0e3a8d1f3ecbe3e0a5a3f1c6f3e0f5e0c2|", false},
+		{"ez5Kiey1", false},
+	} {
+		if got := userPasswordHashRegexp.MatchString(tc.hash); got != tc.valid {
+			t.Errorf("Expected %q to be valid: %t but got %t", tc.hash, tc.valid, got)
+		}
+	}
+}
+
+func TestPasswordHashDiffers(t *testing.T) {
+	for _, tc := range []struct {
+		stored, expected string
+		differs          bool
+	}{
+		{"md5b29a4eabfe9c6228a66e9707049f4441", "md5b29a4eabfe9c6228a66e9707049f4441", false},
+		{"md5b29a4eabfe9c6228a66e9707049f4441", "md5B29A4EABFE9C6228A66E9707049F4441", false},
+		{"md5b29a4eabfe9c6228a66e9707049f4441", "md5f491e1c2ef4081c7e977c5ee2293e7cc", true},
+		{"sha256|aa|salt", "sha256|bb|salt", true},
+		{"sha256|aa|salt", "md5b29a4eabfe9c6228a66e9707049f4441", false},
+		{"", "md5b29a4eabfe9c6228a66e9707049f4441", false},
+	} {
+		if got := passwordHashDiffers(tc.stored, tc.expected); got != tc.differs {
+			t.Errorf("Expected %q and %q to differ: %t but got %t", tc.stored, tc.expected, tc.differs, got)
+		}
+	}
+}
+
+func TestCreateUserQueryWithPasswordHashed(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+		userNameAttr:           "john",
+		userPasswordHashedAttr: "md5b29a4eabfe9c6228a66e9707049f4441",
+	})
+
+	if query := createUserQuery(d, false); !strings.Contains(query, "PASSWORD 'md5b29a4eabfe9c6228a66e9707049f4441'") {
+		t.Errorf("Expected the hashed password in %q", query)
+	}
+	if query := createUserQuery(d, true); !strings.Contains(query, "PASSWORD '<redacted>'") || strings.Contains(query, "md5b29a") {
+		t.Errorf("Expected the hashed password to be redacted in %q", query)
+	}
+}
+
+func TestAccRedshiftUser_SearchPath(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_search_path")
+
+	configSet := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name        = %[1]q
+  search_path = ["$user", "Public"]
+}
+`, name)
+
+	configReset := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configSet,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.0", "$user"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.1", "public"),
+				),
+			},
+			{
+				// A search path changed outside of terraform is detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s SET search_path TO public", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             configSet,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: configReset,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "0"),
+			},
+		},
+	})
+}
+
+func TestUserSearchPathQuery(t *testing.T) {
+	tests := map[string]struct {
+		searchPath []string
+		expected   string
+	}{
+		"set": {
+			searchPath: []string{"$user", "Public", "my schema"},
+			expected:   `ALTER USER "john" SET search_path TO "$user", "public", "my schema"`,
+		},
+		"reset": {
+			searchPath: nil,
+			expected:   `ALTER USER "john" RESET search_path`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := userSearchPathQuery("john", tt.searchPath); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftUser_FlagsDrift(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_flags_drift")
+
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name     = %[1]q
+  password = "Foobarbaz1"
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckRedshiftUserFlags(name, false, false, "RESTRICTED"),
+			},
+			{
+				// Flags changed outside of terraform are detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s CREATEDB CREATEUSER", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// and reverted in place
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserFlags(name, false, false, "RESTRICTED"),
+					resource.TestCheckResourceAttr("redshift_user.user", "create_database", "false"),
+					resource.TestCheckResourceAttr("redshift_user.user", "superuser", "false"),
+					resource.TestCheckResourceAttr("redshift_user.user", "syslog_access", "RESTRICTED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftUserFlags(userName string, createDB, superuser bool, syslogAccess string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var actualCreateDB, actualSuperuser bool
+		var actualSyslogAccess string
+		err = db.QueryRow("SELECT createdb, superuser, syslog_access FROM svv_user_info WHERE user_name = $1", userName).Scan(&actualCreateDB, &actualSuperuser, &actualSyslogAccess)
+		if err != nil {
+			return fmt.Errorf("error reading flags of user %s: %w", userName, err)
+		}
+
+		if actualCreateDB != createDB || actualSuperuser != superuser || actualSyslogAccess != syslogAccess {
+			return fmt.Errorf("expected user %s to have createdb %t, superuser %t and syslog access %s, got %t, %t and %s",
+				userName, createDB, superuser, syslogAccess, actualCreateDB, actualSuperuser, actualSyslogAccess)
+		}
+
+		return nil
+	}
+}
+
+func TestAccRedshiftUser_RenameKeepsPasswordAndOwnership(t *testing.T) {
+	userName := generateRandomObjectName("tf_acc_user_rename")
+	newUserName := generateRandomObjectName("tf_acc_user_renamed")
+	schemaName := generateRandomObjectName("tf_acc_user_rename_schema")
+	config := `
+resource "redshift_user" "user" {
+  name     = %[1]q
+  password = "Foobarbaz1"
+}
+
+resource "redshift_schema" "schema" {
+  name  = %[2]q
+  owner = redshift_user.user.name
+}
+`
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, userName, schemaName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserCanLogin(userName, "Foobarbaz1"),
+					func(s *terraform.State) error {
+						userID = s.RootModule().Resources["redshift_user.user"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, newUserName, schemaName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(newUserName),
+					resource.TestCheckResourceAttr("redshift_user.user", "name", newUserName),
+					resource.TestCheckResourceAttrPtr("redshift_user.user", "id", &userID),
+					testAccCheckRedshiftUserCanLogin(newUserName, "Foobarbaz1"),
+					resource.TestCheckResourceAttr("redshift_schema.schema", "owner", newUserName),
+				),
+			},
+			{
+				Config:   fmt.Sprintf(config, newUserName, schemaName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_QueryGroup(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_query_group")
+
+	configSet := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name        = %[1]q
+  query_group = "tf_acc_reporting"
+}
+`, name)
+
+	configReset := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configSet,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", "query_group", "tf_acc_reporting"),
+				),
+			},
+			{
+				// A query group changed outside of terraform is detected
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s SET query_group TO 'tf_acc_adhoc'", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             configSet,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: configReset,
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "query_group", ""),
+			},
+		},
+	})
+}
+
+func TestUserQueryGroupQuery(t *testing.T) {
+	tests := map[string]struct {
+		queryGroup string
+		expected   string
+	}{
+		"set": {
+			queryGroup: "reporting",
+			expected:   `ALTER USER "john" SET query_group TO 'reporting'`,
+		},
+		"quoted": {
+			queryGroup: "john's",
+			expected:   `ALTER USER "john" SET query_group TO 'john''s'`,
+		},
+		"reset": {
+			queryGroup: "",
+			expected:   `ALTER USER "john" RESET query_group`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := userQueryGroupQuery("john", tt.queryGroup); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCreateUserQueryWithExternalID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+		userNameAttr:       "AzureAD:john",
+		userExternalIDAttr: "f0e1d2c3",
+	})
+
+	query := createUserQuery(d, false)
+	if !strings.Contains(query, "PASSWORD DISABLE") || !strings.Contains(query, `EXTERNALID "f0e1d2c3"`) {
+		t.Errorf("Expected a disabled password and the external ID in %q", query)
+	}
+}
+
+func TestAccRedshiftUser_ExternalID(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_external_id")
+
+	config := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  %[2]s
+}
+`, name, attributes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`external_id = "tf_acc_external_id"` + "\n  password = \"Foobarbaz1\""),
+				ExpectError: regexp.MustCompile(`"external_id": conflicts with password`),
+			},
+			{
+				Config: config(`external_id = "tf_acc_external_id"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", userExternalIDAttr, "tf_acc_external_id"),
+					resource.TestCheckNoResourceAttr("redshift_user.user", userPasswordAttr),
+				),
+			},
+			{
+				Config: config(`external_id = "tf_acc_other_external_id"`),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", userExternalIDAttr, "tf_acc_other_external_id"),
+			},
+			{
+				Config:   config(`external_id = "tf_acc_other_external_id"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestRedshiftUserPasswordDisabledWithPassword(t *testing.T) {
+	diagnostics := redshiftUser().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		userNameAttr:             "user",
+		userPasswordAttr:         "Foobarbaz1",
+		userPasswordDisabledAttr: false,
+	}))
+	if diagnostics.HasError() {
+		t.Errorf("Expected an enabled password to be valid with a password but got %v", diagnostics)
+	}
+}
+
+func TestAccRedshiftUser_PasswordDisabled(t *testing.T) {
+	name := generateRandomObjectName("tf_acc_user_password_disabled")
+
+	config := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  %[2]s
+}
+`, name, attributes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`password_disabled = false`),
+				ExpectError: regexp.MustCompile(`"password_disabled" can only be false when a password is set`),
+			},
+			{
+				Config:      config(`password = "Foobarbaz1"` + "\n  password_disabled = true"),
+				ExpectError: regexp.MustCompile(`"password_disabled" can't be true when "password" is set`),
+			},
+			{
+				Config: config(`password = "Foobarbaz1"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(name),
+					resource.TestCheckResourceAttr("redshift_user.user", userPasswordDisabledAttr, "false"),
+					testAccCheckRedshiftUserCanLogin(name, "Foobarbaz1"),
+				),
+			},
+			{
+				// A password disabled outside of terraform is set again
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s PASSWORD DISABLE", pq.QuoteIdentifier(name))); err != nil {
+						t.Fatalf("could not alter user: %v", err)
+					}
+				},
+				Config:             config(`password = "Foobarbaz1"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(`password = "Foobarbaz1"` + "\n  password_disabled = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", userPasswordDisabledAttr, "false"),
+					testAccCheckRedshiftUserCanLogin(name, "Foobarbaz1"),
+				),
+			},
+			{
+				Config: config(`password_disabled = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", userPasswordDisabledAttr, "true"),
+					resource.TestCheckNoResourceAttr("redshift_user.user", userPasswordAttr),
+				),
+			},
+			{
+				Config:   config(`password_disabled = true`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
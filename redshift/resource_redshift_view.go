@@ -0,0 +1,276 @@
+package redshift
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	viewSchemaAttr              = "schema"
+	viewNameAttr                = "name"
+	viewQueryAttr               = "query"
+	viewWithNoSchemaBindingAttr = "with_no_schema_binding"
+	viewOwnerAttr               = "owner"
+	viewDefinitionHashAttr      = "definition_hash"
+)
+
+var (
+	viewDefinitionWhitespaceRegexp = regexp.MustCompile(`\s+`)
+	viewDefinitionPrefixRegexp     = regexp.MustCompile(`^create\s+(or\s+replace\s+)?view\s+\S+\s+as\s+`)
+	viewDefinitionSuffixRegexp     = regexp.MustCompile(`\s*with\s+no\s+schema\s+binding$`)
+)
+
+func redshiftView() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a Redshift view. Late-binding views, which are not bound to the underlying tables, can be created by setting ` + "`with_no_schema_binding`" + `.
+`,
+		CreateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftViewCreate),
+		ReadContext:   ResourceFuncInDatabase(resourceDatabase, resourceRedshiftViewRead),
+		UpdateContext: ResourceFuncInDatabase(resourceDatabase, resourceRedshiftViewUpdate),
+		DeleteContext: ResourceFuncInDatabase(resourceDatabase,
+			ResourceRetryOnPQErrors(resourceRedshiftViewDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateInDatabase,
+		},
+
+		Schema: map[string]*schema.Schema{
+			resourceDatabaseAttr: resourceDatabaseSchema("view"),
+			viewSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The schema of the view.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			viewNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the view. Changing it renames the view.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			viewQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SELECT statement defining the view. Differences in whitespace, case and a trailing semicolon are ignored. Late-binding views must reference schema qualified tables. Redshift rewrites the definitions of views, so the configured statement is kept in the state and changes outside of Terraform are detected with `definition_hash` instead.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeViewDefinition(old) == normalizeViewDefinition(new)
+				},
+			},
+			viewWithNoSchemaBindingAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create a late-binding view, which is not bound to the underlying database objects.",
+			},
+			viewOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The owner of the view. Defaults to the user the provider is connected as.",
+			},
+			viewDefinitionHashAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 hash of the definition of the view as stored by Redshift. If the definition changes outside of Terraform, it is read back as `query` and the configured query is applied again.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(viewSchemaAttr).(string)
+	viewName := d.Get(viewNameAttr).(string)
+
+	if err := checkPrivilege(db, "schema", schemaName, "create"); err != nil {
+		return err
+	}
+
+	tx, err := startCreateTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := createOrReplaceView(tx, d); err != nil {
+		return err
+	}
+
+	if owner, ok := d.GetOk(viewOwnerAttr); ok {
+		if err := setViewOwner(tx, schemaName, viewName, owner.(string)); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(schemaName, viewName))
+
+	return resourceRedshiftViewRead(db, d)
+}
+
+func resourceRedshiftViewRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, viewName, err := parseTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var owner, definition string
+	query := "SELECT viewowner, definition FROM pg_views WHERE schemaname = $1 AND viewname = $2"
+	log.Printf("[DEBUG] %s, $1=%s, $2=%s\n", query, schemaName, viewName)
+	if err := db.QueryRow(query, schemaName, viewName).Scan(&owner, &definition); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[WARN] Redshift view (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading view: %w", err)
+	}
+
+	// Late-binding views keep their full CREATE statement as definition
+	withNoSchemaBinding := viewDefinitionSuffixRegexp.MatchString(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(definition)), ";"))
+
+	d.Set(viewSchemaAttr, schemaName)
+	d.Set(viewNameAttr, viewName)
+	d.Set(viewOwnerAttr, owner)
+	d.Set(viewWithNoSchemaBindingAttr, withNoSchemaBinding)
+
+	// The definition is rewritten by Redshift, e.g. with qualified column names, so it can't be compared with the
+	// configured query. It is only read back when the view is imported or was changed outside of Terraform.
+	definitionHash := viewDefinitionHash(definition)
+	previousHash := d.Get(viewDefinitionHashAttr).(string)
+	if previousHash == "" && d.Get(viewQueryAttr).(string) == "" || previousHash != "" && previousHash != definitionHash {
+		log.Printf("[WARN] Definition of Redshift view (%s) differs from the applied one", d.Id())
+		d.Set(viewQueryAttr, strings.TrimSpace(definition))
+	}
+	d.Set(viewDefinitionHashAttr, definitionHash)
+
+	return nil
+}
+
+func resourceRedshiftViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(viewSchemaAttr).(string)
+
+	tx, err := startTransaction(db)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if d.HasChange(viewNameAttr) {
+		oldName, newName := d.GetChange(viewNameAttr)
+		query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tableIdentifier(schemaName, oldName.(string)), pq.QuoteIdentifier(newName.(string)))
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not rename view: %w", err)
+		}
+	}
+
+	viewName := d.Get(viewNameAttr).(string)
+
+	if d.HasChanges(viewQueryAttr, viewWithNoSchemaBindingAttr) {
+		if err := createOrReplaceView(tx, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange(viewOwnerAttr) {
+		if owner := d.Get(viewOwnerAttr).(string); owner != "" {
+			if err := setViewOwner(tx, schemaName, viewName, owner); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(schemaName, viewName))
+	// The definition of the replaced view is the applied one
+	d.Set(viewDefinitionHashAttr, "")
+
+	return resourceRedshiftViewRead(db, d)
+}
+
+func resourceRedshiftViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, viewName, err := parseTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DROP VIEW IF EXISTS %s", tableIdentifier(schemaName, viewName))
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("could not drop view: %w", err)
+	}
+
+	return nil
+}
+
+func createOrReplaceView(tx *sql.Tx, d *schema.ResourceData) error {
+	query := createViewQuery(
+		d.Get(viewSchemaAttr).(string),
+		d.Get(viewNameAttr).(string),
+		d.Get(viewQueryAttr).(string),
+		d.Get(viewWithNoSchemaBindingAttr).(bool),
+	)
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not create view: %w", err)
+	}
+	return nil
+}
+
+func createViewQuery(schemaName, viewName, selectQuery string, withNoSchemaBinding bool) string {
+	query := fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", tableIdentifier(schemaName, viewName), strings.TrimSuffix(strings.TrimSpace(selectQuery), ";"))
+	if withNoSchemaBinding {
+		query = fmt.Sprintf("%s WITH NO SCHEMA BINDING", query)
+	}
+	return query
+}
+
+// Redshift uses ALTER TABLE to change the owner of views
+func setViewOwner(tx *sql.Tx, schemaName, viewName, owner string) error {
+	query := fmt.Sprintf("ALTER TABLE %s OWNER TO %s", tableIdentifier(schemaName, viewName), pq.QuoteIdentifier(owner))
+	log.Printf("[DEBUG] %s\n", query)
+
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("error setting view owner: %w", err)
+	}
+	return nil
+}
+
+// viewDefinitionHash returns the hash of the definition of a view as stored in pg_views.
+func viewDefinitionHash(definition string) string {
+	hash := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(hash[:])
+}
+
+// normalizeViewDefinition returns the SELECT statement of a view definition with collapsed whitespace,
+// in lower case and without trailing semicolon. The CREATE VIEW prefix and the WITH NO SCHEMA BINDING
+// suffix, which late-binding views keep in their definition, are removed.
+func normalizeViewDefinition(definition string) string {
+	normalized := strings.ToLower(strings.TrimSpace(viewDefinitionWhitespaceRegexp.ReplaceAllString(definition, " ")))
+	normalized = strings.TrimSpace(strings.TrimSuffix(normalized, ";"))
+	normalized = viewDefinitionPrefixRegexp.ReplaceAllString(normalized, "")
+	normalized = viewDefinitionSuffixRegexp.ReplaceAllString(normalized, "")
+	return strings.TrimSpace(normalized)
+}
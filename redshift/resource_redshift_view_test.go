@@ -0,0 +1,270 @@
+package redshift
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftView_Basic(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_view_schema")
+	viewName := generateRandomObjectName("tf_acc_view")
+	newViewName := generateRandomObjectName("tf_acc_view_renamed")
+
+	config := func(name, query string, withNoSchemaBinding bool) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "source" {
+  schema = redshift_schema.schema.name
+  name   = "source"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+
+  column {
+    name = "label"
+    type = "varchar(64)"
+  }
+}
+
+resource "redshift_view" "view" {
+  schema                 = redshift_schema.schema.name
+  name                   = %[2]q
+  query                  = %[3]q
+  with_no_schema_binding = %[4]t
+
+  depends_on = [redshift_table.source]
+}
+`, schemaName, name, query, withNoSchemaBinding)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(viewName, fmt.Sprintf("SELECT id FROM %s.source", schemaName), true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, viewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "id", generateTableID(schemaName, viewName)),
+					resource.TestCheckResourceAttr("redshift_view.view", "with_no_schema_binding", "true"),
+					resource.TestCheckResourceAttrSet("redshift_view.view", "owner"),
+				),
+			},
+			{
+				// Only whitespace changes, no diff expected
+				Config:   config(viewName, fmt.Sprintf("SELECT   id\n  FROM %s.source;", schemaName), true),
+				PlanOnly: true,
+			},
+			{
+				Config: config(newViewName, fmt.Sprintf("SELECT id, label FROM %s.source", schemaName), true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, newViewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "id", generateTableID(schemaName, newViewName)),
+					resource.TestCheckResourceAttr("redshift_view.view", "name", newViewName),
+				),
+			},
+			{
+				ResourceName:            "redshift_view.view",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"query"},
+			},
+		},
+	})
+}
+
+// TestAccRedshiftView_StandardView checks that the definition of a view bound to its tables, which Redshift rewrites,
+// doesn't cause a diff, while a change of the view outside of Terraform does.
+func TestAccRedshiftView_StandardView(t *testing.T) {
+	schemaName := generateRandomObjectName("tf_acc_view_schema")
+	viewName := generateRandomObjectName("tf_acc_view")
+	query := fmt.Sprintf("SELECT id, label FROM %s.source WHERE id > 0", schemaName)
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "source" {
+  schema = redshift_schema.schema.name
+  name   = "source"
+
+  column {
+    name = "id"
+    type = "bigint"
+  }
+
+  column {
+    name = "label"
+    type = "varchar(64)"
+  }
+}
+
+resource "redshift_view" "view" {
+  schema = redshift_schema.schema.name
+  name   = %[2]q
+  query  = %[3]q
+
+  depends_on = [redshift_table.source]
+}
+`, schemaName, viewName, query)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, viewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "query", query),
+					resource.TestCheckResourceAttr("redshift_view.view", "with_no_schema_binding", "false"),
+					resource.TestCheckResourceAttrSet("redshift_view.view", "definition_hash"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// The view is changed outside of Terraform
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("could not connect: %v", err)
+					}
+					if _, err := db.Exec(createViewQuery(schemaName, viewName, fmt.Sprintf("SELECT id FROM %s.source", schemaName), false)); err != nil {
+						t.Fatalf("could not replace view: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("redshift_view.view", "query", query),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_view" {
+			continue
+		}
+
+		schemaName, viewName, err := parseTableID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		exists, err := checkViewExists(client, schemaName, viewName)
+		if err != nil {
+			return fmt.Errorf("error checking view: %w", err)
+		}
+
+		if exists {
+			return fmt.Errorf("view still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftViewExists(schemaName, viewName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkViewExists(client, schemaName, viewName)
+		if err != nil {
+			return fmt.Errorf("error checking view: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("view not found")
+		}
+
+		return nil
+	}
+}
+
+func checkViewExists(client *Client, schemaName, viewName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM pg_views WHERE schemaname = $1 AND viewname = $2", schemaName, viewName).Scan(&_rez)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading info about view: %w", err)
+	}
+
+	return true, nil
+}
+
+func TestNormalizeViewDefinition(t *testing.T) {
+	tests := map[string]struct {
+		definition string
+		expected   string
+	}{
+		"whitespace": {
+			definition: "  SELECT id,\n\tname   FROM public.users ",
+			expected:   "select id, name from public.users",
+		},
+		"trailing semicolon": {
+			definition: "SELECT id FROM public.users;",
+			expected:   "select id from public.users",
+		},
+		"late-binding view definition": {
+			definition: "create view \"public\".\"v\" as SELECT id FROM public.users with no schema binding;",
+			expected:   "select id from public.users",
+		},
+		"create or replace": {
+			definition: "CREATE OR REPLACE VIEW public.v AS SELECT 1",
+			expected:   "select 1",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeViewDefinition(tt.definition); got != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCreateViewQuery(t *testing.T) {
+	expected := `CREATE OR REPLACE VIEW "public"."v" AS SELECT id FROM public.users WITH NO SCHEMA BINDING`
+	if got := createViewQuery("public", "v", "SELECT id FROM public.users;\n", true); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	expected = `CREATE OR REPLACE VIEW "public"."v" AS SELECT 1`
+	if got := createViewQuery("public", "v", "SELECT 1", false); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,66 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// See https://docs.aws.amazon.com/secretsmanager/latest/apireference/API_GetSecretValue.html
+var secretsManagerApi = awsJsonApi{
+	signingName:    "secretsmanager",
+	targetPrefix:   "secretsmanager",
+	endpointPrefix: "secretsmanager",
+}
+
+type secretsManagerGetSecretValueInput struct {
+	SecretId string `json:"SecretId"`
+}
+
+type secretsManagerGetSecretValueOutput struct {
+	SecretString string `json:"SecretString"`
+}
+
+// secretArnRegion returns the region of a secret from its ARN,
+// e.g. `arn:aws:secretsmanager:eu-central-1:123456789012:secret:redshift/etl-AbCdEf`.
+func secretArnRegion(arn string) (string, error) {
+	parts := strings.SplitN(arn, ":", 7)
+	if len(parts) != 7 || parts[0] != "arn" || parts[2] != "secretsmanager" || parts[3] == "" || parts[5] != "secret" {
+		return "", fmt.Errorf("invalid secret ARN %q, expected arn:<partition>:secretsmanager:<region>:<account>:secret:<name>", arn)
+	}
+	return parts[3], nil
+}
+
+// secretPassword extracts the password from the value of a secret. Secrets in the JSON format
+// of the Redshift secrets, e.g. `{"username": "etl", "password": "..."}`, are read from the
+// `password` key, any other value is the password as is.
+func secretPassword(secretString string) (string, error) {
+	var credentials map[string]interface{}
+	if err := json.Unmarshal([]byte(secretString), &credentials); err != nil {
+		return secretString, nil
+	}
+	password, ok := credentials["password"].(string)
+	if !ok || password == "" {
+		return "", fmt.Errorf("the secret is a JSON object without a password key")
+	}
+	return password, nil
+}
+
+// getSecretPassword fetches the password stored in the secret with the provider's AWS configuration.
+func getSecretPassword(ctx context.Context, cfg aws.Config, endpoint, arn string) (string, error) {
+	var output secretsManagerGetSecretValueOutput
+	if err := secretsManagerApi.call(ctx, cfg, endpoint, "GetSecretValue", secretsManagerGetSecretValueInput{SecretId: arn}, &output); err != nil {
+		return "", fmt.Errorf("could not read secret %s: %w", arn, err)
+	}
+	if output.SecretString == "" {
+		return "", fmt.Errorf("secret %s has no string value", arn)
+	}
+	password, err := secretPassword(output.SecretString)
+	if err != nil {
+		return "", fmt.Errorf("could not read secret %s: %w", arn, err)
+	}
+	return password, nil
+}
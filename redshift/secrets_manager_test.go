@@ -0,0 +1,111 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecretArnRegion(t *testing.T) {
+	tests := map[string]struct {
+		arn     string
+		region  string
+		wantErr bool
+	}{
+		"valid": {
+			arn:    "arn:aws:secretsmanager:eu-central-1:123456789012:secret:redshift/etl-AbCdEf",
+			region: "eu-central-1",
+		},
+		"name with colon": {
+			arn:    "arn:aws-cn:secretsmanager:cn-north-1:123456789012:secret:redshift:etl-AbCdEf",
+			region: "cn-north-1",
+		},
+		"other service": {
+			arn:     "arn:aws:ssm:eu-central-1:123456789012:parameter/redshift/etl",
+			wantErr: true,
+		},
+		"not an arn": {
+			arn:     "redshift/etl",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			region, err := secretArnRegion(tt.arn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("secretArnRegion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.region {
+				t.Errorf("Expected region %q but got %q", tt.region, region)
+			}
+		})
+	}
+}
+
+func TestSecretPassword(t *testing.T) {
+	tests := map[string]struct {
+		secret   string
+		password string
+		wantErr  bool
+	}{
+		"plain":              {secret: "ez5Kiey1", password: "ez5Kiey1"},
+		"redshift secret":    {secret: `{"username":"etl","password":"ez5Kiey1"}`, password: "ez5Kiey1"},
+		"json without key":   {secret: `{"username":"etl"}`, wantErr: true},
+		"json string secret": {secret: `"ez5Kiey1"`, password: `"ez5Kiey1"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			password, err := secretPassword(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("secretPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if password != tt.password {
+				t.Errorf("Expected password %q but got %q", tt.password, password)
+			}
+		})
+	}
+}
+
+func TestGetSecretPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "secretsmanager.GetSecretValue" {
+			t.Errorf("Expected target secretsmanager.GetSecretValue but got %q", target)
+		}
+		if auth := r.Header.Get("Authorization"); !strings.Contains(auth, "/eu-central-1/secretsmanager/aws4_request") {
+			t.Errorf("Expected request signed for secretsmanager in eu-central-1 but got %q", auth)
+		}
+
+		var input secretsManagerGetSecretValueInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatalf("Unable to decode request: %s", err)
+		}
+		if input.SecretId == "missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws.secretsmanager#ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", awsJsonProtocolMediaType)
+		w.Write([]byte(`{"ARN":"arn","Name":"redshift/etl","SecretString":"{\"username\":\"etl\",\"password\":\"ez5Kiey1\"}"}`))
+	}))
+	defer server.Close()
+
+	password, err := getSecretPassword(context.Background(), testAwsConfig(), server.URL, "redshift/etl")
+	if err != nil {
+		t.Fatalf("getSecretPassword() error = %v", err)
+	}
+	if password != "ez5Kiey1" {
+		t.Errorf("Expected password from the secret but got %q", password)
+	}
+
+	_, err = getSecretPassword(context.Background(), testAwsConfig(), server.URL, "missing")
+	expected := "could not read secret missing: GetSecretValue failed: ResourceNotFoundException: Secrets Manager can't find the specified secret."
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q but got %v", expected, err)
+	}
+}
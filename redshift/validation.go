@@ -180,3 +180,4 @@ var dbGroupValidate = validation.All(
 
 var awsAccountIdRegexp = regexp.MustCompile(`^\d{12}$`)
 var uuidRegex = regexp.MustCompile("^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}$")
+var sqlStateRegexp = regexp.MustCompile(`^[0-9A-Za-z]{5}$`)